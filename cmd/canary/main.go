@@ -0,0 +1,167 @@
+// Command canary fetches pages with known content from each Bible provider
+// and reports the selectors that no longer match, so that a site redesign is
+// noticed before users see failed lookups.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"bible-api-service/internal/bible"
+	"bible-api-service/internal/bible/providers/biblecom"
+	"bible-api-service/internal/bible/providers/biblegateway"
+	"bible-api-service/internal/bible/providers/biblehub"
+	"bible-api-service/internal/bible/providers/biblenow"
+	"bible-api-service/internal/bible/providers/httpx"
+	"bible-api-service/internal/bible/providers/markup"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// result is the outcome of a single canary check.
+type result struct {
+	Provider string `json:"provider"`
+	Check    string `json:"check"`
+	URL      string `json:"url"`
+	// Error is set when the page could not be fetched or parsed.
+	Error  string           `json:"error,omitempty"`
+	Broken []bible.Selector `json:"broken,omitempty"`
+}
+
+func (r result) ok() bool {
+	return r.Error == "" && len(r.Broken) == 0
+}
+
+// runChecks runs the canary checks of the providers, in name order.
+func runChecks(ctx context.Context, client *http.Client, providers map[string]bible.CanaryProvider) []result {
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var results []result
+	for _, name := range names {
+		for _, c := range providers[name].CanaryChecks() {
+			results = append(results, check(ctx, client, name, c))
+		}
+	}
+	return results
+}
+
+func check(ctx context.Context, client *http.Client, provider string, c bible.CanaryCheck) result {
+	res := result{Provider: provider, Check: c.Name, URL: c.URL}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.URL, nil)
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		res.Error = fmt.Sprintf("status code: %d", resp.StatusCode)
+		return res
+	}
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	res.Broken = markup.Missing(doc.Selection, c.Selectors...)
+	return res
+}
+
+func printReport(w io.Writer, results []result, format string) error {
+	if format == "json" {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(results)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, r := range results {
+		switch {
+		case r.Error != "":
+			fmt.Fprintf(tw, "%s\t%s\tERROR\t%s\n", r.Provider, r.Check, r.Error)
+		case len(r.Broken) > 0:
+			broken := make([]string, len(r.Broken))
+			for i, sel := range r.Broken {
+				broken[i] = fmt.Sprintf("%s (%s)", sel.Name, sel.Query)
+			}
+			fmt.Fprintf(tw, "%s\t%s\tBROKEN\t%s\n", r.Provider, r.Check, strings.Join(broken, ", "))
+		default:
+			fmt.Fprintf(tw, "%s\t%s\tok\t\n", r.Provider, r.Check)
+		}
+	}
+	return tw.Flush()
+}
+
+// selectProviders returns the named providers, or all of them if names is empty.
+func selectProviders(all map[string]bible.CanaryProvider, names string) (map[string]bible.CanaryProvider, error) {
+	if strings.TrimSpace(names) == "" {
+		return all, nil
+	}
+	selected := make(map[string]bible.CanaryProvider)
+	for _, name := range strings.Split(names, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		p, ok := all[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown provider %q", name)
+		}
+		selected[name] = p
+	}
+	return selected, nil
+}
+
+func main() {
+	var providerNames, format string
+	var timeout time.Duration
+	flag.StringVar(&providerNames, "providers", "", "comma-separated providers to check (default: all)")
+	flag.StringVar(&format, "format", "text", "report format: text or json")
+	flag.DurationVar(&timeout, "timeout", 2*time.Minute, "time allowed for all checks")
+	flag.Parse()
+
+	if format != "text" && format != "json" {
+		log.Fatalf("Invalid -format %q: want text or json", format)
+	}
+
+	providers := map[string]bible.CanaryProvider{
+		"biblegateway": biblegateway.NewScraper(),
+		"biblehub":     biblehub.NewScraper(),
+		"biblenow":     biblenow.NewScraper(),
+		"biblecom":     biblecom.NewScraper(),
+	}
+	selected, err := selectProviders(providers, providerNames)
+	if err != nil {
+		log.Fatalf("Invalid -providers: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	results := runChecks(ctx, httpx.NewClient(), selected)
+	if err := printReport(os.Stdout, results, format); err != nil {
+		log.Fatalf("Failed to print report: %v", err)
+	}
+	for _, r := range results {
+		if !r.ok() {
+			os.Exit(1)
+		}
+	}
+}
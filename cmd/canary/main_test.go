@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"bible-api-service/internal/bible"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubCanary []bible.CanaryCheck
+
+func (s stubCanary) CanaryChecks() []bible.CanaryCheck { return s }
+
+func TestRunChecks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/verse":
+			w.Write([]byte(`<div class="passage-text"><sup class="versenum">16</sup>For God so loved the world</div>`))
+		case "/redesigned":
+			w.Write([]byte(`<main class="reader"><p>For God so loved the world</p></main>`))
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	passage := bible.Selector{Name: "passage", Query: ".passage-text"}
+	verseNum := bible.Selector{Name: "verse number", Query: "sup.versenum"}
+	providers := map[string]bible.CanaryProvider{
+		"second": stubCanary{{Name: "down", URL: server.URL + "/down", Selectors: []bible.Selector{passage}}},
+		"first": stubCanary{
+			{Name: "verse", URL: server.URL + "/verse", Selectors: []bible.Selector{passage, verseNum}},
+			{Name: "redesigned", URL: server.URL + "/redesigned", Selectors: []bible.Selector{passage, verseNum}},
+		},
+	}
+
+	results := runChecks(context.Background(), server.Client(), providers)
+	require.Len(t, results, 3)
+
+	assert.Equal(t, "first", results[0].Provider)
+	assert.True(t, results[0].ok())
+
+	assert.False(t, results[1].ok())
+	assert.Equal(t, []bible.Selector{passage, verseNum}, results[1].Broken)
+
+	assert.Equal(t, "second", results[2].Provider)
+	assert.Equal(t, "status code: 503", results[2].Error)
+
+	var text bytes.Buffer
+	require.NoError(t, printReport(&text, results, "text"))
+	assert.Contains(t, text.String(), "ok")
+	assert.Contains(t, text.String(), "BROKEN  passage (.passage-text), verse number (sup.versenum)")
+	assert.Contains(t, text.String(), "ERROR   status code: 503")
+
+	var out bytes.Buffer
+	require.NoError(t, printReport(&out, results, "json"))
+	var decoded []result
+	require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	assert.Equal(t, results, decoded)
+}
+
+func TestSelectProviders(t *testing.T) {
+	all := map[string]bible.CanaryProvider{"biblehub": stubCanary{}, "biblenow": stubCanary{}}
+
+	selected, err := selectProviders(all, "")
+	require.NoError(t, err)
+	assert.Len(t, selected, 2)
+
+	selected, err = selectProviders(all, " BibleHub ")
+	require.NoError(t, err)
+	assert.Contains(t, selected, "biblehub")
+	assert.Len(t, selected, 1)
+
+	_, err = selectProviders(all, "biblehub,unknown")
+	assert.Error(t, err)
+}
@@ -4,31 +4,47 @@ import (
 	"bible-api-service/internal/bible"
 	"bible-api-service/internal/config"
 	"bible-api-service/internal/handlers"
+	"bible-api-service/internal/logging"
 	"bible-api-service/internal/middleware"
 	"bible-api-service/internal/secrets"
 	"context"
-	"log"
+	"fmt"
+	"log/slog"
+	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	gofeatureflag "github.com/thomaspoignant/go-feature-flag"
 )
 
 func main() {
-	config.InitFeatureFlags()
-	defer gofeatureflag.Close()
+	logging.Init()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
+	if err := run(ctx); err != nil {
+		slog.Error("server failed", "error", err)
+		os.Exit(1)
 	}
+}
 
-	ctx := context.Background()
+// run starts the server and blocks until ctx is cancelled and in-flight
+// requests have drained.
+func run(ctx context.Context) error {
+	config.InitFeatureFlags()
+	// Deferred so that it runs only after in-flight requests have drained
+	defer gofeatureflag.Close()
+
+	serverConfig := config.LoadServerConfig()
 	projectID := os.Getenv("GCP_PROJECT_ID")
 
 	secretsClient, err := secrets.NewClient(ctx, projectID)
 	if err != nil {
-		log.Fatalf("could not create secrets client: %v", err)
+		return fmt.Errorf("could not create secrets client: %w", err)
 	}
 
 	authMiddleware := middleware.NewAuthMiddleware(secretsClient)
@@ -40,7 +56,12 @@ func main() {
 
 	versionManager, err := bible.NewVersionManager(versionsConfigPath)
 	if err != nil {
-		log.Fatalf("could not initialize version manager: %v", err)
+		return fmt.Errorf("could not initialize version manager: %w", err)
+	}
+
+	if interval := config.VersionsPollingInterval(); interval > 0 {
+		slog.Info("Versions config polling interval configured", "interval", interval.String())
+		go versionManager.Watch(ctx, config.NewVersionsRetriever(versionsConfigPath), interval)
 	}
 
 	// Register Routes
@@ -48,12 +69,62 @@ func main() {
 
 	versionsHandler := handlers.NewVersionsHandler(versionManager)
 
-	http.Handle("/query", middleware.Logging(authMiddleware.APIKeyAuth(queryHandler)))
+	healthHandler := handlers.NewHealthHandler(versionManager, queryHandler.ProviderManager, queryHandler.GetLLMClient, config.FeatureFlagsReady)
+
+	mux := http.NewServeMux()
+	mux.Handle("/query", middleware.RequestID(middleware.Logging(authMiddleware.APIKeyAuth(middleware.MaxBytes(serverConfig.MaxBodyBytes, queryHandler)))))
 	// Apply auth middleware to maintain security consistency
-	http.Handle("/bible-versions", middleware.Logging(authMiddleware.APIKeyAuth(versionsHandler)))
+	mux.Handle("/bible-versions", middleware.RequestID(middleware.Logging(authMiddleware.APIKeyAuth(versionsHandler))))
+	mux.Handle("/bible-versions/languages", middleware.RequestID(middleware.Logging(authMiddleware.APIKeyAuth(http.HandlerFunc(versionsHandler.ListLanguages)))))
+	mux.Handle("/bible-versions/{code}", middleware.RequestID(middleware.Logging(authMiddleware.APIKeyAuth(http.HandlerFunc(versionsHandler.GetVersion)))))
+	mux.Handle("/bible-versions/{code}/books", middleware.RequestID(middleware.Logging(authMiddleware.APIKeyAuth(http.HandlerFunc(versionsHandler.ListBooks)))))
+	mux.Handle("/bible-versions/{code}/books/{book}", middleware.RequestID(middleware.Logging(authMiddleware.APIKeyAuth(http.HandlerFunc(versionsHandler.GetBook)))))
+
+	// Probes are unauthenticated so that load balancers can reach them
+	mux.HandleFunc("/healthz", healthHandler.Liveness)
+	mux.HandleFunc("/readyz", healthHandler.Readiness)
+	mux.Handle("/status", middleware.RequestID(middleware.Logging(authMiddleware.APIKeyAuth(http.HandlerFunc(healthHandler.Status)))))
+
+	srv := &http.Server{
+		Addr:              ":" + serverConfig.Port,
+		Handler:           mux,
+		ReadTimeout:       serverConfig.ReadTimeout,
+		ReadHeaderTimeout: serverConfig.ReadHeaderTimeout,
+		WriteTimeout:      serverConfig.WriteTimeout,
+		IdleTimeout:       serverConfig.IdleTimeout,
+	}
+
+	ln, err := net.Listen("tcp", srv.Addr)
+	if err != nil {
+		return fmt.Errorf("could not listen on %s: %w", srv.Addr, err)
+	}
+
+	slog.Info("Server starting", "port", serverConfig.Port)
+	return serve(ctx, srv, ln, serverConfig.ShutdownTimeout)
+}
+
+// serve runs srv on ln until ctx is cancelled, then stops accepting connections
+// and waits up to shutdownTimeout for in-flight requests and streams to finish.
+func serve(ctx context.Context, srv *http.Server, ln net.Listener, shutdownTimeout time.Duration) error {
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.Serve(ln)
+	}()
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("could not serve on %s: %w", ln.Addr(), err)
+	case <-ctx.Done():
+	}
+
+	slog.Info("Shutting down, draining in-flight requests", "timeout", shutdownTimeout.String())
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
 
-	log.Printf("Server starting on port %s\n", port)
-	if err := http.ListenAndServe(":"+port, nil); err != nil {
-		log.Fatalf("could not listen on port %s %v", port, err)
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		slog.Warn("In-flight requests did not drain in time, closing connections", "error", err)
+		srv.Close()
 	}
+	slog.Info("Server stopped")
+	return nil
 }
@@ -2,7 +2,9 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"os"
 	"strings"
@@ -52,4 +54,74 @@ func TestMain(t *testing.T) {
 	if !strings.Contains(string(body), "Missing API Key") {
 		t.Errorf("expected response body to contain 'Missing API Key', but it didn't")
 	}
+
+	// Liveness probe is unauthenticated
+	healthRes, err := http.Get("http://localhost:8091/healthz")
+	if err != nil {
+		t.Fatalf("could not send GET request: %v", err)
+	}
+	defer healthRes.Body.Close()
+	if healthRes.StatusCode != http.StatusOK {
+		t.Errorf("expected status code %d from /healthz, got %d", http.StatusOK, healthRes.StatusCode)
+	}
+
+	if res.Header.Get("X-Request-ID") == "" {
+		t.Errorf("expected X-Request-ID header on /query response")
+	}
+}
+
+func TestServe_DrainsInFlightRequests(t *testing.T) {
+	started := make(chan struct{})
+	mux := http.NewServeMux()
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(200 * time.Millisecond)
+		w.Write([]byte("done"))
+	})
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("could not listen: %v", err)
+	}
+	url := "http://" + ln.Addr().String() + "/slow"
+	srv := &http.Server{Handler: mux}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() {
+		served <- serve(ctx, srv, ln, 5*time.Second)
+	}()
+
+	type result struct {
+		body string
+		err  error
+	}
+	results := make(chan result, 1)
+	go func() {
+		res, err := http.Get(url)
+		if err != nil {
+			results <- result{err: err}
+			return
+		}
+		defer res.Body.Close()
+		body, err := ioutil.ReadAll(res.Body)
+		results <- result{body: string(body), err: err}
+	}()
+
+	<-started
+	cancel()
+
+	r := <-results
+	if r.err != nil {
+		t.Fatalf("in-flight request failed during shutdown: %v", r.err)
+	}
+	if r.body != "done" {
+		t.Errorf("expected in-flight request to complete, got body %q", r.body)
+	}
+	if err := <-served; err != nil {
+		t.Errorf("expected clean shutdown, got %v", err)
+	}
+
+	if _, err := http.Get(url); err == nil {
+		t.Errorf("expected new connections to be refused after shutdown")
+	}
 }
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"bible-api-service/internal/bible"
+)
+
+// fieldChange is a single changed field of a version. Provider mappings are
+// reported as "providers.<name>".
+type fieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// versionChange lists the changed fields of a version present before and after the update.
+type versionChange struct {
+	Code    string        `json:"code"`
+	Changes []fieldChange `json:"changes"`
+}
+
+// versionDiff is the structured difference between two versions files.
+type versionDiff struct {
+	Added   []bible.Version `json:"added"`
+	Removed []bible.Version `json:"removed"`
+	Changed []versionChange `json:"changed"`
+	// Review lists matching decisions that need to be confirmed, or resolved
+	// in the overrides file.
+	Review []reviewItem `json:"review"`
+}
+
+// snapshot deep-copies the version map so later updates do not affect it.
+func snapshot(versions map[string]*bible.Version) map[string]bible.Version {
+	copied := make(map[string]bible.Version, len(versions))
+	for code, v := range versions {
+		c := *v
+		c.Providers = make(map[string]string, len(v.Providers))
+		for name, value := range v.Providers {
+			c.Providers[name] = value
+		}
+		copied[code] = c
+	}
+	return copied
+}
+
+// diffVersions compares two snapshots keyed by upper-case version code.
+func diffVersions(before, after map[string]bible.Version) versionDiff {
+	d := versionDiff{
+		Added:   []bible.Version{},
+		Removed: []bible.Version{},
+		Changed: []versionChange{},
+		Review:  []reviewItem{},
+	}
+
+	for _, code := range sortedCodes(after) {
+		if _, ok := before[code]; !ok {
+			d.Added = append(d.Added, after[code])
+		}
+	}
+
+	for _, code := range sortedCodes(before) {
+		old := before[code]
+		updated, ok := after[code]
+		if !ok {
+			d.Removed = append(d.Removed, old)
+			continue
+		}
+
+		var changes []fieldChange
+		for _, f := range []fieldChange{
+			{"name", old.Name, updated.Name},
+			{"abbreviation", old.Abbreviation, updated.Abbreviation},
+			{"publisher", old.Publisher, updated.Publisher},
+			{"language", old.Language, updated.Language},
+			{"language_code", old.LanguageCode, updated.LanguageCode},
+			{"direction", old.Direction, updated.Direction},
+			{"coverage", old.Coverage, updated.Coverage},
+			{"versification", old.Versification, updated.Versification},
+			{"canon", old.Canon, updated.Canon},
+		} {
+			if f.From != f.To {
+				changes = append(changes, f)
+			}
+		}
+
+		providers := make(map[string]bool)
+		for name := range old.Providers {
+			providers[name] = true
+		}
+		for name := range updated.Providers {
+			providers[name] = true
+		}
+		names := make([]string, 0, len(providers))
+		for name := range providers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if old.Providers[name] != updated.Providers[name] {
+				changes = append(changes, fieldChange{Field: "providers." + name, From: old.Providers[name], To: updated.Providers[name]})
+			}
+		}
+
+		if len(changes) > 0 {
+			d.Changed = append(d.Changed, versionChange{Code: updated.Code, Changes: changes})
+		}
+	}
+
+	return d
+}
+
+func sortedCodes(versions map[string]bible.Version) []string {
+	codes := make([]string, 0, len(versions))
+	for code := range versions {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// summary returns a one-line count of the changes.
+func (d versionDiff) summary() string {
+	summary := fmt.Sprintf("%d added, %d removed, %d changed", len(d.Added), len(d.Removed), len(d.Changed))
+	if len(d.Review) > 0 {
+		summary += fmt.Sprintf(", %d to review", len(d.Review))
+	}
+	return summary
+}
+
+// write prints the diff as text or JSON.
+func (d versionDiff) write(w io.Writer, format string) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(d)
+	case "", "text":
+		for _, v := range d.Added {
+			fmt.Fprintf(w, "+ %s\t%s (%s)\n", v.Code, v.Name, v.Language)
+		}
+		for _, v := range d.Removed {
+			fmt.Fprintf(w, "- %s\t%s (%s)\n", v.Code, v.Name, v.Language)
+		}
+		for _, c := range d.Changed {
+			fmt.Fprintf(w, "~ %s\n", c.Code)
+			for _, f := range c.Changes {
+				fmt.Fprintf(w, "    %s: %q -> %q\n", f.Field, f.From, f.To)
+			}
+		}
+		for _, r := range d.Review {
+			fmt.Fprintf(w, "! %s %s %q -> %s %q: %s\n", r.Provider, r.ProviderCode, r.ProviderName, r.Code, r.Name, r.Reason)
+		}
+		_, err := fmt.Fprintln(w, d.summary())
+		return err
+	default:
+		return fmt.Errorf("unknown diff format %q", format)
+	}
+}
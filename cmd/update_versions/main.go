@@ -1,6 +1,9 @@
 package main
 
 import (
+	"flag"
+	"fmt"
+	"io"
 	"log"
 	"os"
 	"path/filepath"
@@ -16,31 +19,55 @@ import (
 	"gopkg.in/yaml.v2"
 )
 
-func run(providers map[string]bible.Provider, outputPath string) error {
-	log.Println("Fetching Bible versions from all providers...")
+// defaultMaxDrop is the share of a provider's existing mappings that may
+// disappear in a single run before the update is rejected.
+const defaultMaxDrop = 0.2
 
-	// Unified map: Code -> Version
-	// Use VersionConfig struct to match file format, or map to bible.Version
-	// The original used bible.Version which has similar fields but maybe not exactly same yaml tags?
-	// bible.Version in internal/bible/version.go:
-	/*
-	type Version struct {
-		Code      string            `json:"code" yaml:"code"`
-		Name      string            `json:"name" yaml:"name"`
-		Language  string            `json:"language" yaml:"language"`
-		Providers map[string]string `json:"providers" yaml:"providers"`
-	}
-	*/
-	// So it matches.
+// options controls how run updates the versions file.
+type options struct {
+	// OutputPath is the versions file to read and update.
+	OutputPath string
+	// DryRun computes and validates the update without writing it.
+	DryRun bool
+	// Diff prints the changes to DiffOutput. It is implied by DryRun.
+	Diff bool
+	// DiffFormat is "text" or "json".
+	DiffFormat string
+	DiffOutput io.Writer
+	// OverridesPath is the hand-maintained overrides file. It is optional.
+	OverridesPath string
+	// MaxDrop is the largest share of a provider's existing mappings that may
+	// be dropped. Negative disables the check.
+	MaxDrop float64
+}
+
+func run(providers map[string]bible.Provider, opts options) error {
+	if opts.DiffOutput == nil {
+		opts.DiffOutput = os.Stdout
+	}
+
+	overrides, err := loadOverrides(opts.OverridesPath)
+	if err != nil {
+		return err
+	}
 
+	// Order provider names to ensure deterministic results
+	pNames := make([]string, 0, len(providers))
+	for k := range providers {
+		pNames = append(pNames, k)
+	}
+	orderProviders(pNames)
+	log.Printf("Fetching Bible versions from %s...", strings.Join(pNames, ", "))
+
+	// Unified map: Code -> Version
 	versionMap := make(map[string]*bible.Version)
 
 	// 1. Read existing config first to preserve data
-	existingData, err := os.ReadFile(outputPath)
+	existingData, err := os.ReadFile(opts.OutputPath)
 	if err == nil && len(existingData) > 0 {
 		var existingVersions []bible.Version
 		if err := yaml.Unmarshal(existingData, &existingVersions); err == nil {
-			log.Printf("Loaded %d existing versions from %s", len(existingVersions), outputPath)
+			log.Printf("Loaded %d existing versions from %s", len(existingVersions), opts.OutputPath)
 			for i := range existingVersions {
 				v := &existingVersions[i]
 				code := strings.ToUpper(v.Code)
@@ -53,34 +80,48 @@ func run(providers map[string]bible.Provider, outputPath string) error {
 		log.Printf("Warning: Could not read existing config (or empty): %v", err)
 	}
 
-	// Sort provider names to ensure deterministic results
-	pNames := make([]string, 0, len(providers))
-	for k := range providers {
-		pNames = append(pNames, k)
-	}
-	sort.Strings(pNames)
+	before := snapshot(versionMap)
+	m := &matcher{versions: versionMap, overrides: overrides}
 
 	// 2. Fetch from providers
+	var problems []string
 	for _, pName := range pNames {
 		provider := providers[pName]
 		log.Printf("Fetching versions from %s...", pName)
 		pVersions, err := provider.GetVersions()
 		if err != nil {
 			log.Printf("Error fetching versions from %s: %v", pName, err)
-			// Continue with other providers instead of failing
+			problems = append(problems, fmt.Sprintf("%s: %v", pName, err))
 			continue
 		}
 		log.Printf("Found %d versions from %s", len(pVersions), pName)
+		if len(pVersions) == 0 {
+			problems = append(problems, fmt.Sprintf("%s returned no versions", pName))
+			continue
+		}
 
+		fetched := make(map[string]bool, len(pVersions))
 		for _, v := range pVersions {
-			code := strings.ToUpper(v.Code)
-			if code == "" {
+			if v.Code == "" {
+				continue
+			}
+			code, accept := m.match(pName, v)
+			if !accept {
+				// Keep a mapping that predates the conflict until it is reviewed
+				if existing, ok := versionMap[code]; ok && existing.Providers[pName] == v.Value {
+					fetched[code] = true
+				}
 				continue
 			}
+			fetched[code] = true
 
 			if _, exists := versionMap[code]; !exists {
+				newCode := v.Code // Keep original casing if new
+				if !strings.EqualFold(newCode, code) {
+					newCode = code
+				}
 				versionMap[code] = &bible.Version{
-					Code:      v.Code, // Keep original casing if new
+					Code:      newCode,
 					Name:      v.Name,
 					Language:  v.Language,
 					Providers: make(map[string]string),
@@ -112,6 +153,53 @@ func run(providers map[string]bible.Provider, outputPath string) error {
 				}
 			}
 		}
+
+		// Drop mappings the provider no longer lists, and versions left with no provider
+		previous, dropped := 0, 0
+		for code, v := range before {
+			if _, ok := v.Providers[pName]; !ok {
+				continue
+			}
+			previous++
+			if fetched[code] {
+				continue
+			}
+			dropped++
+			if current, ok := versionMap[code]; ok {
+				delete(current.Providers, pName)
+				if len(current.Providers) == 0 {
+					delete(versionMap, code)
+				}
+			}
+		}
+		if previous > 0 && opts.MaxDrop >= 0 {
+			if ratio := float64(dropped) / float64(previous); ratio > opts.MaxDrop {
+				problems = append(problems, fmt.Sprintf("%s dropped %d of %d versions (%.0f%%, limit %.0f%%)", pName, dropped, previous, ratio*100, opts.MaxDrop*100))
+			}
+		}
+	}
+
+	// 3. Normalize metadata, then apply the hand-maintained overrides on top
+	for _, v := range versionMap {
+		normalizeVersion(v)
+	}
+	overrides.apply(versionMap)
+
+	changes := diffVersions(before, snapshot(versionMap))
+	changes.Review = m.review
+	if opts.Diff || opts.DryRun {
+		if err := changes.write(opts.DiffOutput, opts.DiffFormat); err != nil {
+			return err
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("validation failed: %s", strings.Join(problems, "; "))
+	}
+
+	if opts.DryRun {
+		log.Printf("Dry run: %s not written (%s)", opts.OutputPath, changes.summary())
+		return nil
 	}
 
 	// Convert map to slice
@@ -120,9 +208,7 @@ func run(providers map[string]bible.Provider, outputPath string) error {
 		unifiedVersions = append(unifiedVersions, *v)
 	}
 
-	// Sort by Code (Code is usually uppercase or standard)
-	// Original sorted by Language then Code. I found sorting by Code easier for diffs.
-	// But let's stick to Code for deterministic output.
+	// Sort by Code for deterministic output and readable diffs
 	sort.Slice(unifiedVersions, func(i, j int) bool {
 		return strings.ToUpper(unifiedVersions[i].Code) < strings.ToUpper(unifiedVersions[j].Code)
 	})
@@ -133,20 +219,60 @@ func run(providers map[string]bible.Provider, outputPath string) error {
 	}
 
 	// Ensure directory exists
-	dir := filepath.Dir(outputPath)
+	dir := filepath.Dir(opts.OutputPath)
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return err
 	}
 
-	if err := os.WriteFile(outputPath, data, 0644); err != nil {
+	if err := os.WriteFile(opts.OutputPath, data, 0644); err != nil {
 		return err
 	}
 
-	log.Printf("Successfully updated %s with %d versions", outputPath, len(unifiedVersions))
+	log.Printf("Successfully updated %s with %d versions (%s)", opts.OutputPath, len(unifiedVersions), changes.summary())
 	return nil
 }
 
+// selectProviders returns the providers named in the comma-separated list,
+// or all of them if the list is empty.
+func selectProviders(all map[string]bible.Provider, names string) (map[string]bible.Provider, error) {
+	if strings.TrimSpace(names) == "" {
+		return all, nil
+	}
+
+	selected := make(map[string]bible.Provider)
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		p, ok := all[name]
+		if !ok {
+			known := make([]string, 0, len(all))
+			for k := range all {
+				known = append(known, k)
+			}
+			sort.Strings(known)
+			return nil, fmt.Errorf("unknown provider %q (known: %s)", name, strings.Join(known, ", "))
+		}
+		selected[name] = p
+	}
+	return selected, nil
+}
+
 func main() {
+	var opts options
+	var providerNames string
+	var offline bool
+	flag.StringVar(&opts.OutputPath, "output", "configs/versions.yaml", "versions file to update")
+	flag.StringVar(&providerNames, "providers", "", "comma-separated providers to scrape (default: all)")
+	flag.BoolVar(&opts.DryRun, "dry-run", false, "print the changes without writing the versions file")
+	flag.BoolVar(&opts.Diff, "diff", false, "print the changes made to the versions file")
+	flag.StringVar(&opts.DiffFormat, "diff-format", "text", "diff output format: text or json")
+	flag.StringVar(&opts.OverridesPath, "overrides", "configs/versions_overrides.yaml", "hand-maintained overrides applied on every run")
+	flag.BoolVar(&offline, "offline", false, "skip scraping and only re-normalize the existing versions file")
+	flag.Float64Var(&opts.MaxDrop, "max-drop", defaultMaxDrop, "largest share of a provider's existing versions that may disappear (negative disables)")
+	flag.Parse()
+
 	// Initialize providers with scrapers
 	// Note: Scrapers might need configuration (e.g. User-Agent, timeouts) which are default in NewScraper
 	providers := map[string]bible.Provider{
@@ -156,7 +282,16 @@ func main() {
 		"biblecom":     biblecom.NewScraper(),
 	}
 
-	if err := run(providers, "configs/versions.yaml"); err != nil {
+	selected, err := selectProviders(providers, providerNames)
+	if err != nil {
+		log.Fatalf("Invalid -providers: %v", err)
+	}
+
+	if offline {
+		selected = map[string]bible.Provider{}
+	}
+
+	if err := run(selected, opts); err != nil {
 		log.Fatalf("Failed to update versions: %v", err)
 	}
 }
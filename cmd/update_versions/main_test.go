@@ -1,6 +1,8 @@
 package main
 
 import (
+	"bytes"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -109,7 +111,7 @@ func TestRun_Success(t *testing.T) {
 	outputPath := filepath.Join(tempDir, "versions.yaml")
 
 	// Run
-	err := run(providers, outputPath)
+	err := run(providers, options{OutputPath: outputPath, MaxDrop: defaultMaxDrop})
 	require.NoError(t, err)
 
 	// Verify File Content
@@ -180,16 +182,151 @@ func TestRun_ScraperError(t *testing.T) {
 	tempDir := t.TempDir()
 	outputPath := filepath.Join(tempDir, "versions.yaml")
 
-	err := run(providers, outputPath)
-	// Expect no error, just logging and skipping
-	assert.NoError(t, err)
+	err := run(providers, options{OutputPath: outputPath, MaxDrop: defaultMaxDrop})
+	// A provider that returns nothing fails validation and leaves the file untouched
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "biblegateway")
 
-	// File should exist but contain no versions
-	data, err := os.ReadFile(outputPath)
+	_, err = os.Stat(outputPath)
+	assert.True(t, os.IsNotExist(err))
+}
+
+// stubProvider returns a fixed list of versions.
+type stubProvider struct {
+	versions []bible.ProviderVersion
+}
+
+func (s *stubProvider) GetVerse(book, chapter, verse, version string) (string, error) {
+	return "", nil
+}
+
+func (s *stubProvider) SearchWords(query, version string) ([]bible.SearchResult, error) {
+	return nil, nil
+}
+
+func (s *stubProvider) GetVersions() ([]bible.ProviderVersion, error) {
+	return s.versions, nil
+}
+
+func writeVersions(t *testing.T, path string, versions []bible.Version) {
+	t.Helper()
+	data, err := yaml.Marshal(versions)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0644))
+}
+
+func TestRun_DryRunPrintsDiff(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "versions.yaml")
+	writeVersions(t, outputPath, []bible.Version{
+		{Code: "ESV", Name: "English Standard Version", Language: "English", LanguageCode: "eng", Direction: "ltr", Providers: map[string]string{"biblehub": "esv"}},
+		{Code: "OLD", Name: "Old Version", Language: "English", LanguageCode: "eng", Direction: "ltr", Providers: map[string]string{"biblehub": "old"}},
+		{Code: "KJV", Name: "King James Version", Language: "English", LanguageCode: "eng", Direction: "ltr", Providers: map[string]string{"biblehub": "kjv"}},
+		{Code: "NIV", Name: "New International Version", Language: "English", LanguageCode: "eng", Direction: "ltr", Providers: map[string]string{"biblehub": "niv"}},
+		{Code: "NLT", Name: "New Living Translation", Language: "English", LanguageCode: "eng", Direction: "ltr", Providers: map[string]string{"biblehub": "nlt"}},
+	})
+	original, err := os.ReadFile(outputPath)
+	require.NoError(t, err)
+
+	providers := map[string]bible.Provider{
+		"biblehub": &stubProvider{versions: []bible.ProviderVersion{
+			{Code: "ESV", Value: "esv2", Name: "English Standard Version", Language: "English"},
+			{Code: "KJV", Value: "kjv", Name: "King James Version", Language: "English"},
+			{Code: "NIV", Value: "niv", Name: "New International Version", Language: "English"},
+			{Code: "NLT", Value: "nlt", Name: "New Living Translation", Language: "English"},
+			{Code: "NASB", Value: "nasb", Name: "New American Standard Bible", Language: "English"},
+		}},
+	}
+
+	var out bytes.Buffer
+	err = run(providers, options{OutputPath: outputPath, DryRun: true, DiffFormat: "json", DiffOutput: &out, MaxDrop: defaultMaxDrop})
+	require.NoError(t, err)
+
+	var d versionDiff
+	require.NoError(t, json.Unmarshal(out.Bytes(), &d))
+	require.Len(t, d.Added, 1)
+	assert.Equal(t, "NASB", d.Added[0].Code)
+	require.Len(t, d.Removed, 1)
+	assert.Equal(t, "OLD", d.Removed[0].Code)
+	require.Len(t, d.Changed, 1)
+	assert.Equal(t, "ESV", d.Changed[0].Code)
+	assert.Equal(t, []fieldChange{{Field: "providers.biblehub", From: "esv", To: "esv2"}}, d.Changed[0].Changes)
+
+	// Dry run leaves the file untouched
+	current, err := os.ReadFile(outputPath)
 	require.NoError(t, err)
+	assert.Equal(t, string(original), string(current))
+}
+
+func TestRun_DropThreshold(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "versions.yaml")
+	writeVersions(t, outputPath, []bible.Version{
+		{Code: "ESV", Name: "English Standard Version", Language: "English", Providers: map[string]string{"biblehub": "esv", "biblegateway": "ESV"}},
+		{Code: "KJV", Name: "King James Version", Language: "English", Providers: map[string]string{"biblehub": "kjv"}},
+	})
 
+	providers := map[string]bible.Provider{
+		"biblehub": &stubProvider{versions: []bible.ProviderVersion{
+			{Code: "ESV", Value: "esv", Name: "English Standard Version", Language: "English"},
+		}},
+	}
+
+	err := run(providers, options{OutputPath: outputPath, MaxDrop: 0.2})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "biblehub dropped 1 of 2 versions")
+
+	// Disabling the check applies the update, keeping mappings of providers that were not scraped
+	require.NoError(t, run(providers, options{OutputPath: outputPath, MaxDrop: -1}))
+	data, err := os.ReadFile(outputPath)
+	require.NoError(t, err)
 	var versions []bible.Version
-	err = yaml.Unmarshal(data, &versions)
+	require.NoError(t, yaml.Unmarshal(data, &versions))
+	require.Len(t, versions, 1)
+	assert.Equal(t, map[string]string{"biblehub": "esv", "biblegateway": "ESV"}, versions[0].Providers)
+}
+
+func TestRun_EmptyProvider(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "versions.yaml")
+	providers := map[string]bible.Provider{"biblehub": &stubProvider{}}
+
+	err := run(providers, options{OutputPath: outputPath, MaxDrop: defaultMaxDrop})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "biblehub returned no versions")
+}
+
+func TestDiffVersions_Text(t *testing.T) {
+	before := map[string]bible.Version{
+		"ESV": {Code: "ESV", Name: "English Standard Version", Language: "English", Providers: map[string]string{"biblehub": "esv"}},
+	}
+	after := map[string]bible.Version{
+		"ESV": {Code: "ESV", Name: "English Standard Version", Language: "English", Providers: map[string]string{"biblehub": "esv", "biblenow": "en/bible/esv"}},
+		"KJV": {Code: "KJV", Name: "King James Version", Language: "English", Providers: map[string]string{"biblehub": "kjv"}},
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, diffVersions(before, after).write(&out, "text"))
+	assert.Equal(t, "+ KJV\tKing James Version (English)\n"+
+		"~ ESV\n"+
+		"    providers.biblenow: \"\" -> \"en/bible/esv\"\n"+
+		"1 added, 0 removed, 1 changed\n", out.String())
+
+	assert.Error(t, diffVersions(before, after).write(&out, "xml"))
+}
+
+func TestSelectProviders(t *testing.T) {
+	all := map[string]bible.Provider{
+		"biblehub": &stubProvider{},
+		"biblenow": &stubProvider{},
+	}
+
+	selected, err := selectProviders(all, "")
 	require.NoError(t, err)
-	assert.Empty(t, versions)
+	assert.Len(t, selected, 2)
+
+	selected, err = selectProviders(all, " biblenow ")
+	require.NoError(t, err)
+	assert.Len(t, selected, 1)
+	assert.Contains(t, selected, "biblenow")
+
+	_, err = selectProviders(all, "biblenow,unknown")
+	assert.Error(t, err)
 }
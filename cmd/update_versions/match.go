@@ -0,0 +1,366 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	"bible-api-service/internal/bible"
+
+	"gopkg.in/yaml.v2"
+)
+
+// providerPriority is the order in which providers are merged. Earlier
+// providers define the canonical code when entries are linked by name.
+var providerPriority = []string{"biblegateway", "biblehub", "biblenow", "biblecom"}
+
+// knownAliases maps provider codes or values that name a different version
+// than their code suggests to the canonical code, per provider.
+var knownAliases = map[string]map[string]string{
+	// BibleHub's "erv" is the English Revised Version of 1885, not the Easy-to-Read Version
+	"biblehub": {"ERV": "RV1885"},
+	"biblenow": {"EN/BIBLE/ENGLISH-REVISED-VERSION": "RV1885"},
+}
+
+// mappingOverride pins a provider entry to a canonical version, or ignores it.
+type mappingOverride struct {
+	Provider string `yaml:"provider"`
+	// Value is the provider-specific value of the entry; Code matches the provider code instead.
+	Value  string `yaml:"value,omitempty"`
+	Code   string `yaml:"code,omitempty"`
+	Target string `yaml:"target,omitempty"`
+	Ignore bool   `yaml:"ignore,omitempty"`
+}
+
+// overrides is the hand-maintained file applied on every run, so that manual
+// corrections survive regeneration.
+type overrides struct {
+	Mappings []mappingOverride `yaml:"mappings"`
+	// Versions pins metadata. Non-empty fields replace the scraped values and
+	// provider mappings are added.
+	Versions []bible.Version `yaml:"versions"`
+}
+
+// loadOverrides reads the overrides file. A missing file means no overrides.
+func loadOverrides(path string) (*overrides, error) {
+	o := &overrides{}
+	if path == "" {
+		return o, nil
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return o, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.UnmarshalStrict(data, o); err != nil {
+		return nil, fmt.Errorf("invalid overrides file %s: %w", path, err)
+	}
+	for i, m := range o.Mappings {
+		if m.Provider == "" || (m.Value == "" && m.Code == "") || (m.Target == "" && !m.Ignore) {
+			return nil, fmt.Errorf("invalid overrides file %s: mapping %d needs a provider, a value or code, and a target or ignore", path, i+1)
+		}
+	}
+	return o, nil
+}
+
+func (o *overrides) mapping(provider string, pv bible.ProviderVersion) (mappingOverride, bool) {
+	for _, m := range o.Mappings {
+		if m.Provider != provider {
+			continue
+		}
+		if (m.Value != "" && m.Value == pv.Value) || (m.Code != "" && strings.EqualFold(m.Code, pv.Code)) {
+			return m, true
+		}
+	}
+	return mappingOverride{}, false
+}
+
+// apply pins the metadata and mappings of the overridden versions.
+func (o *overrides) apply(versionMap map[string]*bible.Version) {
+	for _, pinned := range o.Versions {
+		code := strings.ToUpper(pinned.Code)
+		v, ok := versionMap[code]
+		if !ok {
+			v = &bible.Version{Code: pinned.Code, Providers: make(map[string]string)}
+			versionMap[code] = v
+		}
+		for _, f := range []struct {
+			dst *string
+			src string
+		}{
+			{&v.Name, pinned.Name},
+			{&v.Abbreviation, pinned.Abbreviation},
+			{&v.Publisher, pinned.Publisher},
+			{&v.Language, pinned.Language},
+			{&v.LanguageCode, pinned.LanguageCode},
+			{&v.Direction, pinned.Direction},
+			{&v.Coverage, pinned.Coverage},
+			{&v.Versification, pinned.Versification},
+			{&v.Canon, pinned.Canon},
+		} {
+			if f.src != "" {
+				*f.dst = f.src
+			}
+		}
+		if v.Providers == nil {
+			v.Providers = make(map[string]string)
+		}
+		for name, value := range pinned.Providers {
+			v.Providers[name] = value
+		}
+	}
+}
+
+// reviewItem is a matching decision that needs a human to confirm it.
+type reviewItem struct {
+	Provider     string `json:"provider"`
+	ProviderCode string `json:"provider_code"`
+	ProviderName string `json:"provider_name"`
+	Code         string `json:"code"`
+	Name         string `json:"name"`
+	Reason       string `json:"reason"`
+}
+
+// matcher links provider entries to canonical versions.
+type matcher struct {
+	versions  map[string]*bible.Version
+	overrides *overrides
+	review    []reviewItem
+}
+
+// match returns the canonical code for a provider entry. accept is false if the
+// entry conflicts with the version that has its code; the conflict is recorded
+// for review and code is still returned so the caller can keep an existing mapping.
+func (m *matcher) match(provider string, pv bible.ProviderVersion) (code string, accept bool) {
+	if o, ok := m.overrides.mapping(provider, pv); ok {
+		if o.Ignore {
+			return "", false
+		}
+		return strings.ToUpper(o.Target), true
+	}
+
+	code = strings.ToUpper(pv.Code)
+	if alias, ok := knownAliases[provider][code]; ok {
+		return alias, true
+	}
+	if alias, ok := knownAliases[provider][strings.ToUpper(pv.Value)]; ok {
+		return alias, true
+	}
+
+	candidate := describe(provider, pv)
+	if existing, ok := m.versions[code]; ok {
+		if reason := mismatch(*existing, candidate); reason != "" {
+			m.flag(provider, pv, existing, reason)
+			return code, false
+		}
+		return code, true
+	}
+
+	if linked := m.findByName(provider, candidate); linked != nil {
+		m.flag(provider, pv, linked, "linked by name to a version with a different code")
+		return strings.ToUpper(linked.Code), true
+	}
+	return code, true
+}
+
+func (m *matcher) flag(provider string, pv bible.ProviderVersion, v *bible.Version, reason string) {
+	log.Printf("Review: %s %s %q -> %s %q: %s", provider, pv.Code, pv.Name, v.Code, v.Name, reason)
+	m.review = append(m.review, reviewItem{
+		Provider:     provider,
+		ProviderCode: pv.Code,
+		ProviderName: pv.Name,
+		Code:         v.Code,
+		Name:         v.Name,
+		Reason:       reason,
+	})
+}
+
+// findByName returns the single existing version in the same language whose
+// name has the same significant words as the candidate and that the provider
+// does not map yet. It returns nil if there is no match or more than one.
+func (m *matcher) findByName(provider string, candidate bible.Version) *bible.Version {
+	words := nameTokens(candidate.Name, candidate.Code)
+	if len(words) < 2 {
+		return nil
+	}
+
+	codes := make([]string, 0, len(m.versions))
+	for code := range m.versions {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	var found *bible.Version
+	for _, code := range codes {
+		v := m.versions[code]
+		if _, mapped := v.Providers[provider]; mapped {
+			continue
+		}
+		if !sameTokens(words, nameTokens(cleanName(*v), v.Code)) {
+			continue
+		}
+		if !languagesAgree(describeExisting(*v), candidate) {
+			continue
+		}
+		if found != nil {
+			return nil
+		}
+		found = v
+	}
+	return found
+}
+
+// describe returns the provider entry as a normalized version, for comparison.
+func describe(provider string, pv bible.ProviderVersion) bible.Version {
+	v := bible.Version{Code: pv.Code, Name: pv.Name, Language: pv.Language, Providers: map[string]string{provider: pv.Value}}
+	normalizeVersion(&v)
+	return v
+}
+
+// describeExisting normalizes a copy of an existing version, which may not have
+// been normalized yet if it was added earlier in this run.
+func describeExisting(v bible.Version) bible.Version {
+	c := v
+	c.Providers = make(map[string]string, len(v.Providers))
+	for name, value := range v.Providers {
+		c.Providers[name] = value
+	}
+	normalizeVersion(&c)
+	return c
+}
+
+func cleanName(v bible.Version) string {
+	name, _, _ := splitName(v.Name, v.Code)
+	return name
+}
+
+// mismatch explains why a provider entry is unlikely to be the version that
+// has the same code, or returns an empty string if they look the same.
+func mismatch(existing bible.Version, candidate bible.Version) string {
+	e := describeExisting(existing)
+	if !languagesAgree(e, candidate) {
+		return fmt.Sprintf("same code but different language (%s, %s)", e.LanguageCode, candidate.LanguageCode)
+	}
+	// Names in different scripts cannot be compared word by word
+	if scriptOf(e.Name) != scriptOf(candidate.Name) {
+		return ""
+	}
+	if nameSimilarity(nameTokens(e.Name, e.Code), nameTokens(candidate.Name, candidate.Code)) < 0.5 {
+		return "same code but different name"
+	}
+	return ""
+}
+
+func languagesAgree(a, b bible.Version) bool {
+	return a.LanguageCode == "" || b.LanguageCode == "" || a.LanguageCode == b.LanguageCode
+}
+
+// nameStopWords are words too common in version names to tell versions apart.
+var nameStopWords = map[string]bool{
+	"the": true, "of": true, "and": true, "a": true,
+	"bible": true, "holy": true, "version": true, "translation": true, "edition": true,
+}
+
+// nameTokens returns the significant lower-case words of a version name,
+// ignoring the version code.
+func nameTokens(name, code string) map[string]bool {
+	tokens := make(map[string]bool)
+	code = strings.ToLower(code)
+	for _, word := range strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if nameStopWords[word] || word == code {
+			continue
+		}
+		tokens[word] = true
+	}
+	return tokens
+}
+
+// nameSimilarity is the share of the shorter name's words found in the other
+// name. Names without significant words are treated as similar.
+func nameSimilarity(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 1
+	}
+	shared := 0
+	for word := range a {
+		if b[word] {
+			shared++
+		}
+	}
+	shorter := len(a)
+	if len(b) < shorter {
+		shorter = len(b)
+	}
+	return float64(shared) / float64(shorter)
+}
+
+func sameTokens(a, b map[string]bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for word := range a {
+		if !b[word] {
+			return false
+		}
+	}
+	return true
+}
+
+// scripts are the writing systems distinguished when comparing names.
+var scripts = map[string]*unicode.RangeTable{
+	"Latin":      unicode.Latin,
+	"Cyrillic":   unicode.Cyrillic,
+	"Greek":      unicode.Greek,
+	"Han":        unicode.Han,
+	"Hangul":     unicode.Hangul,
+	"Kana":       unicode.Hiragana,
+	"Arabic":     unicode.Arabic,
+	"Hebrew":     unicode.Hebrew,
+	"Devanagari": unicode.Devanagari,
+	"Thai":       unicode.Thai,
+}
+
+// scriptOf returns the script most letters of s are written in.
+func scriptOf(s string) string {
+	counts := make(map[string]int)
+	for _, r := range s {
+		for name, table := range scripts {
+			if unicode.Is(table, r) {
+				counts[name]++
+				break
+			}
+		}
+	}
+	best, bestCount := "", 0
+	for name, count := range counts {
+		if count > bestCount || (count == bestCount && name < best) {
+			best, bestCount = name, count
+		}
+	}
+	return best
+}
+
+// orderProviders sorts provider names by providerPriority, then alphabetically.
+func orderProviders(names []string) {
+	rank := func(name string) int {
+		for i, p := range providerPriority {
+			if p == name {
+				return i
+			}
+		}
+		return len(providerPriority)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if ri, rj := rank(names[i]), rank(names[j]); ri != rj {
+			return ri < rj
+		}
+		return names[i] < names[j]
+	})
+}
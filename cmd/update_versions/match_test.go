@@ -0,0 +1,148 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"bible-api-service/internal/bible"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func readVersions(t *testing.T, path string) map[string]bible.Version {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var versions []bible.Version
+	require.NoError(t, yaml.Unmarshal(data, &versions))
+	byCode := make(map[string]bible.Version, len(versions))
+	for _, v := range versions {
+		byCode[v.Code] = v
+	}
+	return byCode
+}
+
+func TestRun_Matching(t *testing.T) {
+	providers := map[string]bible.Provider{
+		"biblegateway": &stubProvider{versions: []bible.ProviderVersion{
+			{Code: "ERV", Value: "ERV", Name: "Easy-to-Read Version (ERV)", Language: "English (EN)"},
+			{Code: "YLT", Value: "YLT", Name: "Young's Literal Translation (YLT)", Language: "English (EN)"},
+			{Code: "RVR1960", Value: "RVR1960", Name: "Reina-Valera 1960 (RVR1960)", Language: "Español (ES)"},
+		}},
+		"biblehub": &stubProvider{versions: []bible.ProviderVersion{
+			{Code: "ERV", Value: "erv", Name: "English Revised Version", Language: "English"},
+		}},
+		"biblenow": &stubProvider{versions: []bible.ProviderVersion{
+			{Code: "RVR1960", Value: "en/bible/revised-version-1960", Name: "Revised Version 1960", Language: "English"},
+		}},
+		"biblecom": &stubProvider{versions: []bible.ProviderVersion{
+			{Code: "YLT98", Value: "821", Name: "Young's Literal Translation of the Holy Bible", Language: "Unknown"},
+		}},
+	}
+
+	outputPath := filepath.Join(t.TempDir(), "versions.yaml")
+	require.NoError(t, run(providers, options{OutputPath: outputPath, MaxDrop: defaultMaxDrop}))
+	versions := readVersions(t, outputPath)
+
+	t.Run("Known alias", func(t *testing.T) {
+		assert.Equal(t, map[string]string{"biblegateway": "ERV"}, versions["ERV"].Providers)
+		assert.Equal(t, map[string]string{"biblehub": "erv"}, versions["RV1885"].Providers)
+	})
+
+	t.Run("Linked by name", func(t *testing.T) {
+		assert.Equal(t, map[string]string{"biblegateway": "YLT", "biblecom": "821"}, versions["YLT"].Providers)
+		assert.NotContains(t, versions, "YLT98")
+	})
+
+	t.Run("Conflict is not merged", func(t *testing.T) {
+		assert.Equal(t, map[string]string{"biblegateway": "RVR1960"}, versions["RVR1960"].Providers)
+	})
+}
+
+func TestRun_ConflictKeepsExistingMapping(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "versions.yaml")
+	writeVersions(t, outputPath, []bible.Version{
+		{Code: "ERV", Name: "Easy-to-Read Version", Language: "English", Providers: map[string]string{"biblegateway": "ERV", "biblenow": "english-revised-version"}},
+	})
+
+	providers := map[string]bible.Provider{
+		"biblenow": &stubProvider{versions: []bible.ProviderVersion{
+			{Code: "ERV", Value: "english-revised-version", Name: "English Revised Version", Language: "English"},
+		}},
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, run(providers, options{OutputPath: outputPath, Diff: true, DiffOutput: &out, MaxDrop: defaultMaxDrop}))
+	assert.Contains(t, out.String(), `! biblenow ERV "English Revised Version" -> ERV "Easy-to-Read Version": same code but different name`)
+
+	versions := readVersions(t, outputPath)
+	assert.Equal(t, "english-revised-version", versions["ERV"].Providers["biblenow"])
+}
+
+func TestRun_Overrides(t *testing.T) {
+	dir := t.TempDir()
+	outputPath := filepath.Join(dir, "versions.yaml")
+	overridesPath := filepath.Join(dir, "overrides.yaml")
+	require.NoError(t, os.WriteFile(overridesPath, []byte(`
+mappings:
+  - provider: biblehub
+    value: bsb
+    target: BSB
+  - provider: biblehub
+    code: JUNK
+    ignore: true
+versions:
+  - code: BSB
+    name: Berean Standard Bible
+    coverage: full
+    versification: hebrew
+    canon: catholic
+`), 0644))
+
+	providers := map[string]bible.Provider{
+		"biblehub": &stubProvider{versions: []bible.ProviderVersion{
+			{Code: "BSB", Value: "bsb", Name: "(Click for Chapter)", Language: "English"},
+			{Code: "JUNK", Value: "junk", Name: "Not a Bible", Language: "English"},
+		}},
+	}
+
+	require.NoError(t, run(providers, options{OutputPath: outputPath, OverridesPath: overridesPath, MaxDrop: defaultMaxDrop}))
+	versions := readVersions(t, outputPath)
+
+	require.Contains(t, versions, "BSB")
+	assert.Equal(t, "Berean Standard Bible", versions["BSB"].Name)
+	assert.Equal(t, bible.CoverageFull, versions["BSB"].Coverage)
+	assert.Equal(t, string(bible.VersificationHebrew), versions["BSB"].Versification)
+	assert.Equal(t, bible.CanonCatholic, versions["BSB"].Canon)
+	assert.Equal(t, "bsb", versions["BSB"].Providers["biblehub"])
+	assert.NotContains(t, versions, "JUNK")
+}
+
+func TestLoadOverrides(t *testing.T) {
+	dir := t.TempDir()
+
+	o, err := loadOverrides(filepath.Join(dir, "missing.yaml"))
+	require.NoError(t, err)
+	assert.Empty(t, o.Mappings)
+
+	invalid := filepath.Join(dir, "invalid.yaml")
+	require.NoError(t, os.WriteFile(invalid, []byte("mappings:\n  - provider: biblehub\n    value: bsb\n"), 0644))
+	_, err = loadOverrides(invalid)
+	assert.Error(t, err)
+
+	unknownField := filepath.Join(dir, "unknown.yaml")
+	require.NoError(t, os.WriteFile(unknownField, []byte("mapping: []\n"), 0644))
+	_, err = loadOverrides(unknownField)
+	assert.Error(t, err)
+}
+
+func TestNameSimilarity(t *testing.T) {
+	assert.Equal(t, 1.0, nameSimilarity(nameTokens("The Message", "MSG"), nameTokens("MSG The Message", "MSG")))
+	assert.Equal(t, 0.0, nameSimilarity(nameTokens("Easy-to-Read Version", "ERV"), nameTokens("English Revised Version", "ERV")))
+	assert.Equal(t, "Latin", scriptOf("Reina-Valera 1960"))
+	assert.Equal(t, "Han", scriptOf("新标点和合本"))
+}
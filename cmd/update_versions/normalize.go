@@ -0,0 +1,297 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"bible-api-service/internal/bible"
+)
+
+// language describes a language known to the normalizer.
+type language struct {
+	iso1 string // ISO 639-1, if the language has one
+	iso3 string // ISO 639-3
+	name string // English name
+	rtl  bool
+}
+
+// languages lists the languages seen in provider data. Providers identify
+// languages by ISO 639-1 codes, ISO 639-3 codes or names, so all three are indexed.
+var languages = []language{
+	{"af", "afr", "Afrikaans", false},
+	{"am", "amh", "Amharic", false},
+	{"ar", "ara", "Arabic", true},
+	{"", "awa", "Awadhi", false},
+	{"be", "bel", "Belarusian", false},
+	{"bg", "bul", "Bulgarian", false},
+	{"bn", "ben", "Bengali", false},
+	{"cs", "ces", "Czech", false},
+	{"", "chr", "Cherokee", false},
+	{"", "ckb", "Central Kurdish", true},
+	{"cy", "cym", "Welsh", false},
+	{"da", "dan", "Danish", false},
+	{"de", "deu", "German", false},
+	{"el", "ell", "Greek", false},
+	{"en", "eng", "English", false},
+	{"eo", "epo", "Esperanto", false},
+	{"es", "spa", "Spanish", false},
+	{"et", "est", "Estonian", false},
+	{"fa", "fas", "Persian", true},
+	{"fi", "fin", "Finnish", false},
+	{"fr", "fra", "French", false},
+	{"ga", "gle", "Irish", false},
+	{"", "grc", "Ancient Greek", false},
+	{"gu", "guj", "Gujarati", false},
+	{"ha", "hau", "Hausa", false},
+	{"he", "heb", "Hebrew", true},
+	{"hi", "hin", "Hindi", false},
+	{"hr", "hrv", "Croatian", false},
+	{"ht", "hat", "Haitian Creole", false},
+	{"hu", "hun", "Hungarian", false},
+	{"hy", "hye", "Armenian", false},
+	{"id", "ind", "Indonesian", false},
+	{"ig", "ibo", "Igbo", false},
+	{"is", "isl", "Icelandic", false},
+	{"it", "ita", "Italian", false},
+	{"ja", "jpn", "Japanese", false},
+	{"ka", "kat", "Georgian", false},
+	{"km", "khm", "Khmer", false},
+	{"kn", "kan", "Kannada", false},
+	{"ko", "kor", "Korean", false},
+	{"ku", "kur", "Kurdish", false},
+	{"la", "lat", "Latin", false},
+	{"lt", "lit", "Lithuanian", false},
+	{"lv", "lav", "Latvian", false},
+	{"mi", "mri", "Maori", false},
+	{"mk", "mkd", "Macedonian", false},
+	{"ml", "mal", "Malayalam", false},
+	{"mr", "mar", "Marathi", false},
+	{"ms", "msa", "Malay", false},
+	{"my", "mya", "Burmese", false},
+	{"", "nds", "Low German", false},
+	{"ne", "nep", "Nepali", false},
+	{"nl", "nld", "Dutch", false},
+	{"no", "nor", "Norwegian", false},
+	{"or", "ori", "Odia", false},
+	{"pa", "pan", "Punjabi", false},
+	{"pl", "pol", "Polish", false},
+	{"ps", "pus", "Pashto", true},
+	{"pt", "por", "Portuguese", false},
+	{"qu", "que", "Quechua", false},
+	{"ro", "ron", "Romanian", false},
+	{"ru", "rus", "Russian", false},
+	{"sk", "slk", "Slovak", false},
+	{"sl", "slv", "Slovenian", false},
+	{"so", "som", "Somali", false},
+	{"sq", "sqi", "Albanian", false},
+	{"sr", "srp", "Serbian", false},
+	{"sv", "swe", "Swedish", false},
+	{"sw", "swa", "Swahili", false},
+	{"ta", "tam", "Tamil", false},
+	{"te", "tel", "Telugu", false},
+	{"th", "tha", "Thai", false},
+	{"tl", "tgl", "Tagalog", false},
+	{"tr", "tur", "Turkish", false},
+	{"", "twi", "Twi", false},
+	{"ug", "uig", "Uyghur", true},
+	{"uk", "ukr", "Ukrainian", false},
+	{"ur", "urd", "Urdu", true},
+	{"vi", "vie", "Vietnamese", false},
+	{"yi", "yid", "Yiddish", true},
+	{"yo", "yor", "Yoruba", false},
+	{"zh", "zho", "Chinese", false},
+	{"zu", "zul", "Zulu", false},
+}
+
+var (
+	languagesByISO3 = make(map[string]language)
+	languagesByISO1 = make(map[string]language)
+	languagesByName = make(map[string]language)
+)
+
+func init() {
+	for _, l := range languages {
+		languagesByISO3[l.iso3] = l
+		if l.iso1 != "" {
+			languagesByISO1[l.iso1] = l
+		}
+		languagesByName[strings.ToLower(l.name)] = l
+	}
+}
+
+// toISO3 converts an ISO 639-1 or ISO 639-3 code to ISO 639-3.
+func toISO3(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	switch len(code) {
+	case 2:
+		if l, ok := languagesByISO1[code]; ok {
+			return l.iso3
+		}
+	case 3:
+		// Three-letter codes from providers are already ISO 639-3, known or not
+		return code
+	}
+	return ""
+}
+
+var (
+	// trailingAbbreviation matches "Name (ABBR)Publisher", the format Bible.com
+	// and Bible Gateway use for version names. The publisher is optional.
+	trailingAbbreviation = regexp.MustCompile(`^(.*\S)\s*\(([^()]+)\)\s*(.*)$`)
+	// labelledLanguage matches Bible Gateway's "Español (ES)" language labels.
+	labelledLanguage = regexp.MustCompile(`^(.*\S)\s*\(([A-Za-z]{2,3})\)$`)
+	// isoAbbreviation matches Bible.com abbreviations built from an ISO 639-3
+	// code, such as "aau" or "acrN".
+	isoAbbreviation = regexp.MustCompile(`^([a-z]{3})[A-Z0-9]*$`)
+)
+
+// splitName separates the abbreviation and publisher glued onto a version name.
+// The parenthesised part is only treated as an abbreviation if it matches the
+// version code or is followed by a publisher, so that names such as
+// "Chinese Union Version (Traditional)" are left alone.
+func splitName(raw, code string) (name, abbreviation, publisher string) {
+	m := trailingAbbreviation.FindStringSubmatch(strings.TrimSpace(raw))
+	if m == nil {
+		return strings.TrimSpace(raw), "", ""
+	}
+	if !strings.EqualFold(m[2], code) && m[3] == "" {
+		return strings.TrimSpace(raw), "", ""
+	}
+	return m[1], m[2], strings.TrimSpace(m[3])
+}
+
+// Coverage markers, checked in order. A name mentioning portions wins over a
+// testament, which wins over a full Bible.
+var coverageMarkers = []struct {
+	coverage string
+	markers  []string
+}{
+	{bible.CoveragePortions, []string{"portion", "porciones", "selection", "gospel of", "evangelio de", "psalms", "salmos", "proverbs"}},
+	{bible.CoverageNT, []string{"new testament", "nuevo testamento", "novo testamento", "nuovo testamento", "nouveau testament", "neues testament", "nieuwe testament", "nowy testament", "nový zákon", "novi zavjet", "bagong tipan", "новый завет", "нов завет", "новий заповіт"}},
+	{bible.CoverageOT, []string{"old testament", "antiguo testamento", "antigo testamento", "ancien testament", "altes testament", "tanakh", "septuagint"}},
+	{bible.CoverageFull, []string{"bible", "biblia", "bíblia", "bibel", "bijbel", "bibbia", "biblija", "bibla", "raamattu", "библия", "біблія", "with apocrypha"}},
+}
+
+// coverageOf guesses the testament coverage from the version name. It returns
+// an empty string if the name gives no hint.
+func coverageOf(name string) string {
+	lower := strings.ToLower(name)
+	for _, c := range coverageMarkers {
+		for _, marker := range c.markers {
+			if strings.Contains(lower, marker) {
+				return c.coverage
+			}
+		}
+	}
+	return ""
+}
+
+// isRTLScript reports whether most letters in s are in a right-to-left script.
+func isRTLScript(s string) bool {
+	rtl, total := 0, 0
+	for _, r := range s {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		total++
+		if unicode.In(r, unicode.Arabic, unicode.Hebrew, unicode.Syriac, unicode.Thaana, unicode.Nko) {
+			rtl++
+		}
+	}
+	return total > 0 && rtl*2 > total
+}
+
+// languageInName finds a language whose English name appears as a word in the
+// version name, e.g. "Plain English Version".
+func languageInName(name string) (language, bool) {
+	for _, word := range strings.FieldsFunc(name, func(r rune) bool { return !unicode.IsLetter(r) }) {
+		if l, ok := languagesByName[strings.ToLower(word)]; ok {
+			return l, true
+		}
+	}
+	return language{}, false
+}
+
+// normalizeVersion cleans up the metadata of a merged version in place: it
+// splits the abbreviation and publisher off the name, derives the ISO 639-3
+// language code, and marks the text direction and testament coverage.
+// It is idempotent, so already normalized entries are left unchanged.
+func normalizeVersion(v *bible.Version) {
+	// A name that was already split keeps its own parentheses, as in
+	// "Authorized (King James) Version", so it is only split again if a
+	// provider glued the abbreviation back on.
+	if v.Abbreviation == "" || strings.Contains(v.Name, "("+v.Abbreviation+")") {
+		name, abbreviation, publisher := splitName(v.Name, v.Code)
+		v.Name = name
+		if abbreviation != "" {
+			v.Abbreviation = abbreviation
+		}
+		if publisher != "" {
+			v.Publisher = publisher
+		}
+	}
+
+	normalizeLanguage(v)
+
+	v.Direction = bible.DirectionLTR
+	if l, ok := languagesByISO3[v.LanguageCode]; (ok && l.rtl) || isRTLScript(v.Name) {
+		v.Direction = bible.DirectionRTL
+	}
+
+	// Publishers such as "Wycliffe Bible Translators" say nothing about coverage
+	if coverage := coverageOf(v.Name); coverage != "" {
+		v.Coverage = coverage
+	}
+}
+
+func normalizeLanguage(v *bible.Version) {
+	label := strings.TrimSpace(v.Language)
+	code := ""
+	if m := labelledLanguage.FindStringSubmatch(label); m != nil {
+		label, code = m[1], toISO3(m[2])
+	}
+
+	// Bible.com does not report languages, and older runs labelled all of its
+	// versions English, so its label cannot be trusted on its own.
+	_, onBibleCom := v.Providers["biblecom"]
+	reliable := label != "" && label != "Unknown" && !(onBibleCom && len(v.Providers) == 1)
+
+	if code == "" {
+		if path, ok := v.Providers["biblenow"]; ok {
+			if prefix, _, found := strings.Cut(path, "/"); found {
+				code = toISO3(prefix)
+			}
+		}
+	}
+	if code == "" && onBibleCom {
+		if m := isoAbbreviation.FindStringSubmatch(v.Abbreviation); m != nil {
+			code = m[1]
+		}
+	}
+	if code == "" && reliable {
+		if l, ok := languagesByName[strings.ToLower(label)]; ok {
+			code = l.iso3
+		}
+	}
+	// A code from an earlier run came from a label that has since been
+	// cleaned up, so it beats a guess from the name.
+	if code == "" && reliable {
+		code = v.LanguageCode
+	}
+	if code == "" {
+		if l, ok := languageInName(v.Name); ok {
+			code = l.iso3
+		}
+	}
+
+	v.LanguageCode = code
+	switch {
+	case reliable:
+		v.Language = label
+	case languagesByISO3[code].name != "":
+		v.Language = languagesByISO3[code].name
+	default:
+		v.Language = "Unknown"
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"testing"
+
+	"bible-api-service/internal/bible"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitName(t *testing.T) {
+	tests := []struct {
+		raw, code                     string
+		name, abbreviation, publisher string
+	}{
+		{"God so Sokior-ok Iwon (aau)Wycliffe Bible Translators, Inc.", "AAU", "God so Sokior-ok Iwon", "aau", "Wycliffe Bible Translators, Inc."},
+		{"Ang Biblia, 2001 (ABTAG2001)", "ABTAG2001", "Ang Biblia, 2001", "ABTAG2001", ""},
+		{"Chinese Contemporary Bible (Simplified) (CCB)", "CCB", "Chinese Contemporary Bible (Simplified)", "CCB", ""},
+		{"Chinese Union Version (Traditional)", "CUV", "Chinese Union Version (Traditional)", "", ""},
+		{"New American Standard Bible", "NASB1995", "New American Standard Bible", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			name, abbreviation, publisher := splitName(tt.raw, tt.code)
+			assert.Equal(t, tt.name, name)
+			assert.Equal(t, tt.abbreviation, abbreviation)
+			assert.Equal(t, tt.publisher, publisher)
+		})
+	}
+}
+
+func TestNormalizeVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    bible.Version
+		expected bible.Version
+	}{
+		{
+			name:  "Bible.com entry with ISO abbreviation and publisher",
+			input: bible.Version{Code: "AAU", Name: "God so Sokior-ok Iwon (aau)Wycliffe Bible Translators, Inc.", Language: "English", Providers: map[string]string{"biblecom": "1015"}},
+			expected: bible.Version{Code: "AAU", Name: "God so Sokior-ok Iwon", Abbreviation: "aau", Publisher: "Wycliffe Bible Translators, Inc.",
+				Language: "Unknown", LanguageCode: "aau", Direction: bible.DirectionLTR, Providers: map[string]string{"biblecom": "1015"}},
+		},
+		{
+			name:  "Bible.com portions",
+			input: bible.Version{Code: "ABM", Name: "Abanyom LP New Testament Portions (ABM)The Seed Company", Language: "English", Providers: map[string]string{"biblecom": "3421"}},
+			expected: bible.Version{Code: "ABM", Name: "Abanyom LP New Testament Portions", Abbreviation: "ABM", Publisher: "The Seed Company",
+				Language: "Unknown", Direction: bible.DirectionLTR, Coverage: bible.CoveragePortions, Providers: map[string]string{"biblecom": "3421"}},
+		},
+		{
+			name:  "Bible.com entry naming its language",
+			input: bible.Version{Code: "PEV", Name: "Plain English Version (PEV)Wycliffe Bible Translators, Inc.", Language: "English", Providers: map[string]string{"biblecom": "2"}},
+			expected: bible.Version{Code: "PEV", Name: "Plain English Version", Abbreviation: "PEV", Publisher: "Wycliffe Bible Translators, Inc.",
+				Language: "English", LanguageCode: "eng", Direction: bible.DirectionLTR, Providers: map[string]string{"biblecom": "2"}},
+		},
+		{
+			name:  "Bible Gateway labelled language",
+			input: bible.Version{Code: "RVR1960", Name: "Reina-Valera 1960 (RVR1960)", Language: "Español (ES)", Providers: map[string]string{"biblegateway": "RVR1960"}},
+			expected: bible.Version{Code: "RVR1960", Name: "Reina-Valera 1960", Abbreviation: "RVR1960",
+				Language: "Español", LanguageCode: "spa", Direction: bible.DirectionLTR, Providers: map[string]string{"biblegateway": "RVR1960"}},
+		},
+		{
+			name:  "Right-to-left language",
+			input: bible.Version{Code: "WLC", Name: "The Westminster Leningrad Codex (WLC)", Language: "עברית (HE)", Providers: map[string]string{"biblegateway": "WLC"}},
+			expected: bible.Version{Code: "WLC", Name: "The Westminster Leningrad Codex", Abbreviation: "WLC",
+				Language: "עברית", LanguageCode: "heb", Direction: bible.DirectionRTL, Providers: map[string]string{"biblegateway": "WLC"}},
+		},
+		{
+			name:  "Already split name with parentheses",
+			input: bible.Version{Code: "AKJV", Name: "Authorized (King James) Version", Abbreviation: "AKJV", Language: "English", Providers: map[string]string{"biblegateway": "AKJV"}},
+			expected: bible.Version{Code: "AKJV", Name: "Authorized (King James) Version", Abbreviation: "AKJV",
+				Language: "English", LanguageCode: "eng", Direction: bible.DirectionLTR, Providers: map[string]string{"biblegateway": "AKJV"}},
+		},
+		{
+			name:  "Language code from an earlier run",
+			input: bible.Version{Code: "SBLGNT", Name: "SBL Greek New Testament", Abbreviation: "SBLGNT", Language: "Κοινη", LanguageCode: "grc", Providers: map[string]string{"biblegateway": "SBLGNT"}},
+			expected: bible.Version{Code: "SBLGNT", Name: "SBL Greek New Testament", Abbreviation: "SBLGNT",
+				Language: "Κοινη", LanguageCode: "grc", Direction: bible.DirectionLTR, Coverage: bible.CoverageNT, Providers: map[string]string{"biblegateway": "SBLGNT"}},
+		},
+		{
+			name:  "BibleNow path language and New Testament",
+			input: bible.Version{Code: "NTV", Name: "Nuevo Testamento Viviente", Language: "Español", Providers: map[string]string{"biblenow": "es/biblia/nuevo-testamento-viviente"}},
+			expected: bible.Version{Code: "NTV", Name: "Nuevo Testamento Viviente",
+				Language: "Español", LanguageCode: "spa", Direction: bible.DirectionLTR, Coverage: bible.CoverageNT, Providers: map[string]string{"biblenow": "es/biblia/nuevo-testamento-viviente"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := tt.input
+			normalizeVersion(&v)
+			assert.Equal(t, tt.expected, v)
+
+			// Normalizing again must not change anything
+			normalizeVersion(&v)
+			assert.Equal(t, tt.expected, v)
+		})
+	}
+}
+
+func TestCoverageOf(t *testing.T) {
+	assert.Equal(t, bible.CoverageFull, coverageOf("Common English Bible"))
+	assert.Equal(t, bible.CoverageNT, coverageOf("Tyndale House Greek New Testament"))
+	assert.Equal(t, bible.CoveragePortions, coverageOf("Abanyom LP New Testament Portions"))
+	assert.Equal(t, "", coverageOf("New International Version"))
+}
@@ -0,0 +1,200 @@
+package bible
+
+import "strings"
+
+// Canons, the sets of books a version may contain. Each includes the ones
+// before it.
+const (
+	// CanonProtestant is the 66 books of the Hebrew Bible and the New
+	// Testament. Versions without a canon have it.
+	CanonProtestant = "protestant"
+	// CanonCatholic adds the deuterocanonical books, such as Tobit, Sirach and
+	// 1 and 2 Maccabees, and the Greek additions to Esther and Daniel.
+	CanonCatholic = "catholic"
+	// CanonOrthodox adds the books only Orthodox Bibles and editions with the
+	// full Apocrypha have, such as 1 Esdras, 3 Maccabees and the Prayer of
+	// Manasseh.
+	CanonOrthodox = "orthodox"
+)
+
+// Book is a book of the Bible.
+type Book struct {
+	// Name is the English name providers know the book by, e.g. "1 Maccabees".
+	Name string `json:"name"`
+	// USFM is the book's USFM identifier, e.g. "1MA".
+	USFM string `json:"usfm"`
+	// Canon is the smallest canon containing the book.
+	Canon   string `json:"canon"`
+	aliases []string
+}
+
+// books lists the books of all canons in the order Bibles print them.
+// Leaving out the books a canon lacks gives the order of that canon.
+var books = []Book{
+	{Name: "Genesis", USFM: "GEN", Canon: CanonProtestant, aliases: []string{"gen", "gn"}},
+	{Name: "Exodus", USFM: "EXO", Canon: CanonProtestant, aliases: []string{"exod", "ex"}},
+	{Name: "Leviticus", USFM: "LEV", Canon: CanonProtestant, aliases: []string{"lv"}},
+	{Name: "Numbers", USFM: "NUM", Canon: CanonProtestant, aliases: []string{"nm"}},
+	{Name: "Deuteronomy", USFM: "DEU", Canon: CanonProtestant, aliases: []string{"deut", "dt"}},
+	{Name: "Joshua", USFM: "JOS", Canon: CanonProtestant, aliases: []string{"josh"}},
+	{Name: "Judges", USFM: "JDG", Canon: CanonProtestant, aliases: []string{"judg"}},
+	{Name: "Ruth", USFM: "RUT", Canon: CanonProtestant, aliases: []string{"rth"}},
+	{Name: "1 Samuel", USFM: "1SA", Canon: CanonProtestant, aliases: []string{"1 sam"}},
+	{Name: "2 Samuel", USFM: "2SA", Canon: CanonProtestant, aliases: []string{"2 sam"}},
+	{Name: "1 Kings", USFM: "1KI", Canon: CanonProtestant, aliases: []string{"1 kgs"}},
+	{Name: "2 Kings", USFM: "2KI", Canon: CanonProtestant, aliases: []string{"2 kgs"}},
+	{Name: "1 Chronicles", USFM: "1CH", Canon: CanonProtestant, aliases: []string{"1 chr", "1 chron"}},
+	{Name: "2 Chronicles", USFM: "2CH", Canon: CanonProtestant, aliases: []string{"2 chr", "2 chron"}},
+	{Name: "Prayer of Manasseh", USFM: "MAN", Canon: CanonOrthodox, aliases: []string{"prayer of manasses"}},
+	{Name: "1 Esdras", USFM: "1ES", Canon: CanonOrthodox, aliases: []string{"1 esd", "3 esdras"}},
+	{Name: "Ezra", USFM: "EZR", Canon: CanonProtestant},
+	{Name: "Nehemiah", USFM: "NEH", Canon: CanonProtestant},
+	{Name: "2 Esdras", USFM: "2ES", Canon: CanonOrthodox, aliases: []string{"2 esd", "4 esdras"}},
+	{Name: "Tobit", USFM: "TOB", Canon: CanonCatholic, aliases: []string{"tobias"}},
+	{Name: "Judith", USFM: "JDT", Canon: CanonCatholic, aliases: []string{"jdth"}},
+	{Name: "Esther", USFM: "EST", Canon: CanonProtestant, aliases: []string{"esth"}},
+	{Name: "Additions to Esther", USFM: "ESG", Canon: CanonCatholic, aliases: []string{"greek esther", "esther (greek)", "rest of esther"}},
+	{Name: "1 Maccabees", USFM: "1MA", Canon: CanonCatholic, aliases: []string{"1 macc", "1 mac"}},
+	{Name: "2 Maccabees", USFM: "2MA", Canon: CanonCatholic, aliases: []string{"2 macc", "2 mac"}},
+	{Name: "3 Maccabees", USFM: "3MA", Canon: CanonOrthodox, aliases: []string{"3 macc", "3 mac"}},
+	{Name: "4 Maccabees", USFM: "4MA", Canon: CanonOrthodox, aliases: []string{"4 macc", "4 mac"}},
+	{Name: "Job", USFM: "JOB", Canon: CanonProtestant},
+	{Name: "Psalms", USFM: "PSA", Canon: CanonProtestant, aliases: []string{"psalm", "ps", "pss"}},
+	{Name: "Psalm 151", USFM: "PS2", Canon: CanonOrthodox},
+	{Name: "Proverbs", USFM: "PRO", Canon: CanonProtestant, aliases: []string{"prov", "prv"}},
+	{Name: "Ecclesiastes", USFM: "ECC", Canon: CanonProtestant, aliases: []string{"eccl", "eccles", "qoheleth"}},
+	{Name: "Song of Solomon", USFM: "SNG", Canon: CanonProtestant, aliases: []string{"song of songs", "song", "canticles", "canticle of canticles"}},
+	{Name: "Wisdom", USFM: "WIS", Canon: CanonCatholic, aliases: []string{"wisdom of solomon", "wisd"}},
+	{Name: "Sirach", USFM: "SIR", Canon: CanonCatholic, aliases: []string{"ecclesiasticus", "ecclus", "ben sira", "wisdom of ben sira"}},
+	{Name: "Isaiah", USFM: "ISA", Canon: CanonProtestant},
+	{Name: "Jeremiah", USFM: "JER", Canon: CanonProtestant},
+	{Name: "Lamentations", USFM: "LAM", Canon: CanonProtestant},
+	{Name: "Baruch", USFM: "BAR", Canon: CanonCatholic},
+	{Name: "Letter of Jeremiah", USFM: "LJE", Canon: CanonCatholic, aliases: []string{"epistle of jeremiah", "epistle of jeremy"}},
+	{Name: "Ezekiel", USFM: "EZK", Canon: CanonProtestant, aliases: []string{"ezek"}},
+	{Name: "Daniel", USFM: "DAN", Canon: CanonProtestant},
+	{Name: "Prayer of Azariah", USFM: "S3Y", Canon: CanonCatholic, aliases: []string{"song of the three young men", "song of three youths", "song of the three holy children"}},
+	{Name: "Susanna", USFM: "SUS", Canon: CanonCatholic},
+	{Name: "Bel and the Dragon", USFM: "BEL", Canon: CanonCatholic},
+	{Name: "Hosea", USFM: "HOS", Canon: CanonProtestant},
+	{Name: "Joel", USFM: "JOL", Canon: CanonProtestant},
+	{Name: "Amos", USFM: "AMO", Canon: CanonProtestant},
+	{Name: "Obadiah", USFM: "OBA", Canon: CanonProtestant, aliases: []string{"obad"}},
+	{Name: "Jonah", USFM: "JON", Canon: CanonProtestant},
+	{Name: "Micah", USFM: "MIC", Canon: CanonProtestant},
+	{Name: "Nahum", USFM: "NAM", Canon: CanonProtestant, aliases: []string{"nah"}},
+	{Name: "Habakkuk", USFM: "HAB", Canon: CanonProtestant},
+	{Name: "Zephaniah", USFM: "ZEP", Canon: CanonProtestant, aliases: []string{"zeph"}},
+	{Name: "Haggai", USFM: "HAG", Canon: CanonProtestant},
+	{Name: "Zechariah", USFM: "ZEC", Canon: CanonProtestant, aliases: []string{"zech"}},
+	{Name: "Malachi", USFM: "MAL", Canon: CanonProtestant},
+	{Name: "Matthew", USFM: "MAT", Canon: CanonProtestant, aliases: []string{"matt", "mt"}},
+	{Name: "Mark", USFM: "MRK", Canon: CanonProtestant, aliases: []string{"mk"}},
+	{Name: "Luke", USFM: "LUK", Canon: CanonProtestant, aliases: []string{"lk"}},
+	{Name: "John", USFM: "JHN", Canon: CanonProtestant, aliases: []string{"jn"}},
+	{Name: "Acts", USFM: "ACT", Canon: CanonProtestant},
+	{Name: "Romans", USFM: "ROM", Canon: CanonProtestant},
+	{Name: "1 Corinthians", USFM: "1CO", Canon: CanonProtestant, aliases: []string{"1 cor"}},
+	{Name: "2 Corinthians", USFM: "2CO", Canon: CanonProtestant, aliases: []string{"2 cor"}},
+	{Name: "Galatians", USFM: "GAL", Canon: CanonProtestant},
+	{Name: "Ephesians", USFM: "EPH", Canon: CanonProtestant},
+	{Name: "Philippians", USFM: "PHP", Canon: CanonProtestant, aliases: []string{"phil"}},
+	{Name: "Colossians", USFM: "COL", Canon: CanonProtestant},
+	{Name: "1 Thessalonians", USFM: "1TH", Canon: CanonProtestant, aliases: []string{"1 thess"}},
+	{Name: "2 Thessalonians", USFM: "2TH", Canon: CanonProtestant, aliases: []string{"2 thess"}},
+	{Name: "1 Timothy", USFM: "1TI", Canon: CanonProtestant, aliases: []string{"1 tim"}},
+	{Name: "2 Timothy", USFM: "2TI", Canon: CanonProtestant, aliases: []string{"2 tim"}},
+	{Name: "Titus", USFM: "TIT", Canon: CanonProtestant},
+	{Name: "Philemon", USFM: "PHM", Canon: CanonProtestant, aliases: []string{"philem"}},
+	{Name: "Hebrews", USFM: "HEB", Canon: CanonProtestant},
+	{Name: "James", USFM: "JAS", Canon: CanonProtestant},
+	{Name: "1 Peter", USFM: "1PE", Canon: CanonProtestant, aliases: []string{"1 pet"}},
+	{Name: "2 Peter", USFM: "2PE", Canon: CanonProtestant, aliases: []string{"2 pet"}},
+	{Name: "1 John", USFM: "1JN", Canon: CanonProtestant},
+	{Name: "2 John", USFM: "2JN", Canon: CanonProtestant},
+	{Name: "3 John", USFM: "3JN", Canon: CanonProtestant},
+	{Name: "Jude", USFM: "JUD", Canon: CanonProtestant},
+	{Name: "Revelation", USFM: "REV", Canon: CanonProtestant, aliases: []string{"revelations", "apocalypse"}},
+}
+
+// booksByKey indexes books by the bookKey of their names, aliases and USFM
+// identifiers.
+var booksByKey = func() map[string]Book {
+	m := make(map[string]Book)
+	for _, b := range books {
+		for _, name := range append([]string{b.Name, b.USFM}, b.aliases...) {
+			m[bookKey(name)] = b
+		}
+	}
+	return m
+}()
+
+// numberPrefixes spells out the book numbers that names may start with.
+var numberPrefixes = map[string]string{
+	"i": "1", "ii": "2", "iii": "3", "iv": "4",
+	"first": "1", "second": "2", "third": "3", "fourth": "4",
+	"1st": "1", "2nd": "2", "3rd": "3", "4th": "4",
+}
+
+// bookKey normalizes a book name for lookup: case, spaces, dots and
+// parentheses are ignored and a leading "I", "II" or "First" is read as a
+// number, so "I Macc." and "1macc" are the same key.
+func bookKey(name string) string {
+	fields := strings.Fields(strings.ToLower(name))
+	if len(fields) > 1 {
+		if n, ok := numberPrefixes[fields[0]]; ok {
+			fields[0] = n
+		}
+	}
+	return strings.NewReplacer(".", "", "(", "", ")", "").Replace(strings.Join(fields, ""))
+}
+
+// LookupBook finds a book by its name, an abbreviation or its USFM
+// identifier.
+func LookupBook(name string) (Book, bool) {
+	b, ok := booksByKey[bookKey(name)]
+	return b, ok
+}
+
+// ReferenceBook finds the book a reference to chapter of the named book is
+// in. Most versions number Psalm 151 as chapter 151 of the Psalms, so that
+// chapter is taken as the book.
+func ReferenceBook(name, chapter string) (Book, bool) {
+	b, ok := LookupBook(name)
+	if ok && b.USFM == "PSA" && strings.TrimSpace(chapter) == "151" {
+		return LookupBook("PS2")
+	}
+	return b, ok
+}
+
+// NormalizeReference spells out the book of a reference, e.g. "Matt. 6:14"
+// as "Matthew 6:14", so that references from different sources compare
+// equal. References to unknown books only have their spacing tidied.
+func NormalizeReference(ref string) string {
+	ref = strings.Join(strings.Fields(ref), " ")
+	i := strings.LastIndex(ref, " ")
+	if i < 0 {
+		return ref
+	}
+	if b, ok := LookupBook(ref[:i]); ok {
+		return b.Name + ref[i:]
+	}
+	return ref
+}
+
+// HasBook reports whether the version's canon contains the book.
+func (v Version) HasBook(b Book) bool {
+	return canonHas(v.Canon, b)
+}
+
+// canonHas reports whether canon contains the book. An empty canon is
+// CanonProtestant.
+func canonHas(canon string, b Book) bool {
+	switch b.Canon {
+	case CanonCatholic:
+		return canon == CanonCatholic || canon == CanonOrthodox
+	case CanonOrthodox:
+		return canon == CanonOrthodox
+	}
+	return true
+}
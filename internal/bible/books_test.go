@@ -0,0 +1,90 @@
+package bible
+
+import "testing"
+
+func TestLookupBook(t *testing.T) {
+	for name, want := range map[string]string{
+		"Genesis":        "GEN",
+		"psalm":          "PSA",
+		"Song of Songs":  "SNG",
+		"1 John":         "1JN",
+		"I John":         "1JN",
+		"1john":          "1JN",
+		"Tobit":          "TOB",
+		"Ecclesiasticus": "SIR",
+		"I Macc.":        "1MA",
+		"Second Esdras":  "2ES",
+		"Esther (Greek)": "ESG",
+		"bar":            "BAR",
+		"3MA":            "3MA",
+	} {
+		b, ok := LookupBook(name)
+		if !ok || b.USFM != want {
+			t.Errorf("LookupBook(%q) = %+v, %v, want %s", name, b, ok, want)
+		}
+	}
+	if b, ok := LookupBook("Hezekiah"); ok {
+		t.Errorf("LookupBook(Hezekiah) = %+v, want not found", b)
+	}
+}
+
+func TestLookupBook_Unambiguous(t *testing.T) {
+	for _, b := range books {
+		for _, name := range append([]string{b.Name, b.USFM}, b.aliases...) {
+			if got, _ := LookupBook(name); got.USFM != b.USFM {
+				t.Errorf("%q finds %s, want %s", name, got.USFM, b.USFM)
+			}
+		}
+	}
+}
+
+func TestReferenceBook(t *testing.T) {
+	if b, _ := ReferenceBook("Psalms", "151"); b.USFM != "PS2" {
+		t.Errorf("ReferenceBook(Psalms 151) = %s, want PS2", b.USFM)
+	}
+	if b, _ := ReferenceBook("Psalms", "150"); b.USFM != "PSA" {
+		t.Errorf("ReferenceBook(Psalms 150) = %s, want PSA", b.USFM)
+	}
+}
+
+func TestVersion_HasBook(t *testing.T) {
+	john, _ := LookupBook("John")
+	tobit, _ := LookupBook("Tobit")
+	esdras, _ := LookupBook("1 Esdras")
+	tests := []struct {
+		canon               string
+		john, tobit, esdras bool
+	}{
+		{"", true, false, false},
+		{CanonProtestant, true, false, false},
+		{CanonCatholic, true, true, false},
+		{CanonOrthodox, true, true, true},
+	}
+	for _, tt := range tests {
+		v := Version{Code: "TEST", Canon: tt.canon}
+		if got := v.HasBook(john); got != tt.john {
+			t.Errorf("canon %q: HasBook(John) = %v", tt.canon, got)
+		}
+		if got := v.HasBook(tobit); got != tt.tobit {
+			t.Errorf("canon %q: HasBook(Tobit) = %v", tt.canon, got)
+		}
+		if got := v.HasBook(esdras); got != tt.esdras {
+			t.Errorf("canon %q: HasBook(1 Esdras) = %v", tt.canon, got)
+		}
+	}
+}
+
+func TestNormalizeReference(t *testing.T) {
+	for ref, want := range map[string]string{
+		"Matt. 6:14":    "Matthew 6:14",
+		"1 Jn 1:9":      "1 John 1:9",
+		"psalm  32:1-5": "Psalms 32:1-5",
+		"Eph 4":         "Ephesians 4",
+		"Hezekiah 1:1":  "Hezekiah 1:1",
+		"John":          "John",
+	} {
+		if got := NormalizeReference(ref); got != want {
+			t.Errorf("NormalizeReference(%q) = %q, want %q", ref, got, want)
+		}
+	}
+}
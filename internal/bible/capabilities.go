@@ -0,0 +1,142 @@
+package bible
+
+import "fmt"
+
+// Capabilities a provider can offer. Every provider serves verses; the others
+// come from the optional interfaces it implements.
+const (
+	CapabilityVerses           = "verses"
+	CapabilitySearch           = "search"
+	CapabilityFootnotes        = "footnotes"
+	CapabilityInterlinear      = "interlinear"
+	CapabilityLexicon          = "lexicon"
+	CapabilityTopics           = "topics"
+	CapabilityChapterStructure = "chapter_structure"
+	CapabilityAudio            = "audio"
+)
+
+// capabilities lists every capability in the order they are reported.
+var capabilities = []string{
+	CapabilityVerses,
+	CapabilitySearch,
+	CapabilityFootnotes,
+	CapabilityInterlinear,
+	CapabilityLexicon,
+	CapabilityTopics,
+	CapabilityChapterStructure,
+	CapabilityAudio,
+}
+
+// Searcher is implemented by providers that can search the text of a version.
+type Searcher interface {
+	SearchWords(query, version string) ([]SearchResult, error)
+}
+
+// ChapterStructureProvider is implemented by providers that can tell how many
+// chapters a book has in a version.
+type ChapterStructureProvider interface {
+	GetChapterCount(book, version string) (int, error)
+}
+
+// AudioLinkProvider is implemented by providers with audio recordings of a
+// version. The link is to the recording of a whole chapter.
+type AudioLinkProvider interface {
+	GetAudioLink(book, chapter, version string) (string, error)
+}
+
+// AllCapabilities returns every known capability.
+func AllCapabilities() []string {
+	return append([]string(nil), capabilities...)
+}
+
+// IsCapability reports whether name is a known capability.
+func IsCapability(name string) bool {
+	for _, c := range capabilities {
+		if c == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Base returns the provider p decorates, such as the scraper an
+// ObservedProvider wraps, or p itself if it decorates none.
+func Base(p Provider) Provider {
+	for {
+		u, ok := p.(interface{ Unwrap() Provider })
+		if !ok {
+			return p
+		}
+		p = u.Unwrap()
+	}
+}
+
+// Capabilities returns the capabilities of p in the order AllCapabilities
+// lists them. Decorators forward every optional interface, so they are
+// looked through to the provider they decorate.
+func Capabilities(p Provider) []string {
+	if p == nil {
+		return nil
+	}
+	var found []string
+	for _, c := range capabilities {
+		if HasCapability(p, c) {
+			found = append(found, c)
+		}
+	}
+	return found
+}
+
+// HasCapability reports whether p offers a capability.
+func HasCapability(p Provider, capability string) bool {
+	if p == nil {
+		return false
+	}
+	var ok bool
+	switch base := Base(p); capability {
+	case CapabilityVerses:
+		ok = true
+	case CapabilitySearch:
+		_, ok = base.(Searcher)
+	case CapabilityFootnotes:
+		_, ok = base.(PassageProvider)
+	case CapabilityInterlinear:
+		_, ok = base.(InterlinearProvider)
+	case CapabilityLexicon:
+		_, ok = base.(LexiconProvider)
+	case CapabilityTopics:
+		_, ok = base.(TopicProvider)
+	case CapabilityChapterStructure:
+		_, ok = base.(ChapterStructureProvider)
+	case CapabilityAudio:
+		_, ok = base.(AudioLinkProvider)
+	}
+	return ok
+}
+
+// Search searches for a word or phrase in version through p.
+func Search(p Provider, query, version string) ([]SearchResult, error) {
+	s, ok := p.(Searcher)
+	if !ok {
+		return nil, fmt.Errorf("%w: provider cannot search", ErrUnsupportedVersion)
+	}
+	return s.SearchWords(query, version)
+}
+
+// GetChapterCount returns the number of chapters of book in version from p.
+func GetChapterCount(p Provider, book, version string) (int, error) {
+	cp, ok := p.(ChapterStructureProvider)
+	if !ok {
+		return 0, fmt.Errorf("%w: provider has no chapter structure", ErrUnsupportedVersion)
+	}
+	return cp.GetChapterCount(book, version)
+}
+
+// GetAudioLink returns the link to the recording of a chapter of version from p.
+func GetAudioLink(p Provider, book, chapter, version string) (string, error) {
+	ap, ok := p.(AudioLinkProvider)
+	if !ok {
+		return "", fmt.Errorf("%w: provider has no audio", ErrUnsupportedVersion)
+	}
+	return ap.GetAudioLink(book, chapter, version)
+}
@@ -0,0 +1,106 @@
+package bible
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"bible-api-service/internal/circuit"
+	"bible-api-service/internal/metrics"
+)
+
+// verseProvider only serves verses.
+type verseProvider struct{}
+
+func (verseProvider) GetVerse(book, chapter, verse, version string) (string, error) {
+	return "", nil
+}
+
+func (verseProvider) GetVersions() ([]ProviderVersion, error) {
+	return nil, nil
+}
+
+// openTopicProvider is a topical index whose circuit is open.
+type openTopicProvider struct {
+	topicProvider
+}
+
+func (p *openTopicProvider) State() circuit.State {
+	return circuit.Open
+}
+
+func TestCapabilities(t *testing.T) {
+	registry := metrics.NewRegistry(time.Minute)
+	tests := []struct {
+		name     string
+		provider Provider
+		want     []string
+	}{
+		{"verses only", verseProvider{}, []string{CapabilityVerses}},
+		{"searcher", &MockProvider{}, []string{CapabilityVerses, CapabilitySearch}},
+		// The decorator's methods do not count, only the wrapped provider's
+		{"observed", NewObservedProvider("test", verseProvider{}, registry), []string{CapabilityVerses}},
+		{"observed index", NewObservedProvider("test", &topicProvider{}, registry), []string{CapabilityVerses, CapabilitySearch, CapabilityTopics}},
+		{"nil", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Capabilities(tt.provider); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Capabilities() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if !IsCapability(CapabilityAudio) || IsCapability("video") {
+		t.Error("IsCapability does not match the known capabilities")
+	}
+}
+
+func TestSearch(t *testing.T) {
+	observed := NewObservedProvider("test", verseProvider{}, metrics.NewRegistry(time.Minute))
+	for _, p := range []Provider{verseProvider{}, observed} {
+		if _, err := Search(p, "love", "KJV"); !errors.Is(err, ErrUnsupportedVersion) {
+			t.Errorf("Search(%T) error = %v, want ErrUnsupportedVersion", p, err)
+		}
+	}
+	if _, err := GetAudioLink(observed, "John", "3", "KJV"); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("GetAudioLink error = %v, want ErrUnsupportedVersion", err)
+	}
+	if _, err := GetChapterCount(observed, "Esther", "NRSVCE"); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("GetChapterCount error = %v, want ErrUnsupportedVersion", err)
+	}
+}
+
+func TestProviderManager_Capabilities(t *testing.T) {
+	index := &topicProvider{}
+	pm := NewProviderManager(verseProvider{})
+	pm.RegisterProvider(DefaultProviderName, verseProvider{})
+	pm.RegisterProvider("biblehub", &MockProvider{})
+	pm.RegisterProvider("index", index)
+
+	if got, want := pm.Capabilities("biblehub"), []string{CapabilityVerses, CapabilitySearch}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Capabilities(biblehub) = %v, want %v", got, want)
+	}
+	if got := pm.Capabilities("unknown"); got != nil {
+		t.Errorf("Capabilities(unknown) = %v, want nil", got)
+	}
+	if got, want := pm.ProvidersWith(CapabilitySearch), []string{"biblehub", "index"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ProvidersWith(search) = %v, want %v", got, want)
+	}
+
+	if p, err := pm.ProviderWith(CapabilityTopics); err != nil || p != index {
+		t.Errorf("ProviderWith(topics) = %v, %v, want the index", p, err)
+	}
+	if p, err := pm.ProviderWith(CapabilityVerses); err != nil || p != (verseProvider{}) {
+		t.Errorf("ProviderWith(verses) = %v, %v, want the default provider", p, err)
+	}
+	if _, err := pm.ProviderWith(CapabilityAudio); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("ProviderWith(audio) error = %v, want ErrUnsupportedVersion", err)
+	}
+
+	pm.RegisterProvider("index", &openTopicProvider{})
+	if _, err := pm.ProviderWith(CapabilityTopics); !errors.Is(err, ErrUpstreamUnavailable) {
+		t.Errorf("ProviderWith(topics) with an open circuit error = %v, want ErrUpstreamUnavailable", err)
+	}
+}
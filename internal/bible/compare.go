@@ -0,0 +1,56 @@
+package bible
+
+import "sort"
+
+// ComparisonRow is a verse of a passage side by side in several versions.
+type ComparisonRow struct {
+	Book    string `json:"book"`
+	Chapter int    `json:"chapter"`
+	Verse   int    `json:"verse"`
+	// Versions maps version codes to their text of the verse, numbered as the
+	// version numbers it. Versions that lack the verse are left out.
+	Versions map[string]VerseText `json:"versions"`
+}
+
+// AlignVerses lines up the verses of a passage in several versions. verses maps
+// version codes to the verses of each, numbered in the version's scheme, and
+// schemes maps them to those schemes; versions missing from it use
+// VersificationEnglish. Rows are numbered in the scheme to and sorted. A psalm
+// title that is numbered in some versions only gets a row with verse 0.
+func AlignVerses(verses map[string][]VerseText, schemes map[string]Versification, to Versification) []ComparisonRow {
+	type key struct{ chapter, verse int }
+	rows := make(map[key]*ComparisonRow)
+	for code, list := range verses {
+		from, ok := schemes[code]
+		if !ok {
+			from = VersificationEnglish
+		}
+		for _, v := range list {
+			ref := MapVerse(VerseRef{Book: v.Book, Chapter: v.Chapter, Verse: v.Verse}, from, to)
+			k := key{ref.Chapter, ref.Verse}
+			row, ok := rows[k]
+			if !ok {
+				row = &ComparisonRow{Book: v.Book, Chapter: ref.Chapter, Verse: ref.Verse, Versions: make(map[string]VerseText)}
+				rows[k] = row
+			}
+			if existing, ok := row.Versions[code]; ok {
+				// Titles numbered as two verses share the title row
+				v.Text = existing.Text + " " + v.Text
+				v.Verse = existing.Verse
+			}
+			row.Versions[code] = v
+		}
+	}
+
+	aligned := make([]ComparisonRow, 0, len(rows))
+	for _, row := range rows {
+		aligned = append(aligned, *row)
+	}
+	sort.Slice(aligned, func(i, j int) bool {
+		if aligned[i].Chapter != aligned[j].Chapter {
+			return aligned[i].Chapter < aligned[j].Chapter
+		}
+		return aligned[i].Verse < aligned[j].Verse
+	})
+	return aligned
+}
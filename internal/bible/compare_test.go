@@ -0,0 +1,58 @@
+package bible
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAlignVerses(t *testing.T) {
+	verses := map[string][]VerseText{
+		"ESV": {
+			{Book: "Malachi", Chapter: 3, Verse: 18, Text: "Then once more"},
+			{Book: "Malachi", Chapter: 4, Verse: 1, Text: "For behold, the day is coming"},
+		},
+		"NABRE": {
+			{Book: "Malachi", Chapter: 3, Verse: 18, Text: "Then you will again see"},
+			{Book: "Malachi", Chapter: 3, Verse: 19, Text: "For the day is coming"},
+		},
+	}
+	schemes := map[string]Versification{"NABRE": VersificationHebrew}
+
+	want := []ComparisonRow{
+		{Book: "Malachi", Chapter: 3, Verse: 18, Versions: map[string]VerseText{
+			"ESV":   verses["ESV"][0],
+			"NABRE": verses["NABRE"][0],
+		}},
+		{Book: "Malachi", Chapter: 4, Verse: 1, Versions: map[string]VerseText{
+			"ESV":   verses["ESV"][1],
+			"NABRE": verses["NABRE"][1],
+		}},
+	}
+	if got := AlignVerses(verses, schemes, VersificationEnglish); !reflect.DeepEqual(got, want) {
+		t.Errorf("AlignVerses() = %+v, want %+v", got, want)
+	}
+}
+
+func TestAlignVerses_PsalmTitle(t *testing.T) {
+	verses := map[string][]VerseText{
+		"ESV": {{Book: "Psalm", Chapter: 51, Verse: 1, Text: "Have mercy on me"}},
+		"JPS": {
+			{Book: "Psalm", Chapter: 51, Verse: 1, Text: "For the Leader."},
+			{Book: "Psalm", Chapter: 51, Verse: 2, Text: "When Nathan came."},
+			{Book: "Psalm", Chapter: 51, Verse: 3, Text: "Be gracious unto me"},
+		},
+	}
+	schemes := map[string]Versification{"JPS": VersificationHebrew}
+
+	got := AlignVerses(verses, schemes, VersificationEnglish)
+	if len(got) != 2 {
+		t.Fatalf("AlignVerses() returned %d rows, want 2: %+v", len(got), got)
+	}
+	title := VerseText{Book: "Psalm", Chapter: 51, Verse: 1, Text: "For the Leader. When Nathan came."}
+	if got[0].Verse != 0 || !reflect.DeepEqual(got[0].Versions, map[string]VerseText{"JPS": title}) {
+		t.Errorf("title row = %+v", got[0])
+	}
+	if got[1].Verse != 1 || len(got[1].Versions) != 2 || got[1].Versions["JPS"].Verse != 3 {
+		t.Errorf("first verse row = %+v", got[1])
+	}
+}
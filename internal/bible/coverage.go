@@ -0,0 +1,15 @@
+package bible
+
+// HasTestament reports whether the version contains the Old (CoverageOT) or
+// New (CoverageNT) Testament. Versions with unknown coverage are assumed to be
+// complete; versions with portions only contain neither.
+func (v Version) HasTestament(testament string) bool {
+	switch v.Coverage {
+	case "", CoverageFull:
+		return true
+	case CoveragePortions:
+		return false
+	default:
+		return v.Coverage == testament
+	}
+}
@@ -0,0 +1,25 @@
+package bible
+
+import "testing"
+
+func TestVersion_HasTestament(t *testing.T) {
+	tests := []struct {
+		coverage string
+		ot, nt   bool
+	}{
+		{"", true, true},
+		{CoverageFull, true, true},
+		{CoverageOT, true, false},
+		{CoverageNT, false, true},
+		{CoveragePortions, false, false},
+	}
+	for _, tt := range tests {
+		v := Version{Coverage: tt.coverage}
+		if got := v.HasTestament(CoverageOT); got != tt.ot {
+			t.Errorf("coverage %q: HasTestament(ot) = %v, want %v", tt.coverage, got, tt.ot)
+		}
+		if got := v.HasTestament(CoverageNT); got != tt.nt {
+			t.Errorf("coverage %q: HasTestament(nt) = %v, want %v", tt.coverage, got, tt.nt)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package bible
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Sentinel errors classifying provider failures. Providers wrap them with
+// context, e.g. fmt.Errorf("%w: unknown book: %s", ErrInvalidReference, book),
+// and callers match them with errors.Is. ErrMarkupChanged completes the set.
+var (
+	// ErrNotFound means the reference is well formed but the provider has no text for it.
+	ErrNotFound = errors.New("not found")
+	// ErrInvalidReference means the book, chapter or verse range is malformed or unknown.
+	ErrInvalidReference = errors.New("invalid reference")
+	// ErrUnsupportedVersion means the version, or the requested operation on it, is not offered.
+	ErrUnsupportedVersion = errors.New("unsupported version")
+	// ErrUpstreamUnavailable means the provider's site could not be reached or failed to answer.
+	ErrUpstreamUnavailable = errors.New("upstream unavailable")
+	// ErrRateLimited means the provider's site is throttling requests.
+	ErrRateLimited = errors.New("rate limited")
+)
+
+// StatusError reports an unexpected HTTP status from a provider's site. It
+// matches ErrNotFound for 404 and 410, ErrRateLimited for 429 and
+// ErrUpstreamUnavailable for anything else.
+type StatusError struct {
+	Provider   string
+	URL        string
+	StatusCode int
+}
+
+// NewStatusError returns a *StatusError for the response.
+func NewStatusError(provider string, res *http.Response) error {
+	e := &StatusError{Provider: provider, StatusCode: res.StatusCode}
+	if res.Request != nil {
+		e.URL = res.Request.URL.String()
+	}
+	return e
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s: %s: status code: %d from %s", e.Provider, e.Unwrap(), e.StatusCode, e.URL)
+}
+
+// Unwrap returns the sentinel error matching the status code.
+func (e *StatusError) Unwrap() error {
+	switch e.StatusCode {
+	case http.StatusNotFound, http.StatusGone:
+		return ErrNotFound
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	default:
+		return ErrUpstreamUnavailable
+	}
+}
+
+// IsRequestError reports whether err is caused by what was asked for rather
+// than by the provider: not found, invalid reference or unsupported version.
+func IsRequestError(err error) bool {
+	return errors.Is(err, ErrNotFound) || errors.Is(err, ErrInvalidReference) || errors.Is(err, ErrUnsupportedVersion)
+}
@@ -0,0 +1,110 @@
+package bible
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"bible-api-service/internal/circuit"
+	"bible-api-service/internal/metrics"
+)
+
+func TestStatusError(t *testing.T) {
+	tests := []struct {
+		status int
+		want   error
+	}{
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusGone, ErrNotFound},
+		{http.StatusTooManyRequests, ErrRateLimited},
+		{http.StatusServiceUnavailable, ErrUpstreamUnavailable},
+		{http.StatusForbidden, ErrUpstreamUnavailable},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "https://biblehub.com/esv/john/3.htm", nil)
+		err := fmt.Errorf("failed to fetch chapter 3: %w", NewStatusError("biblehub", &http.Response{StatusCode: tt.status, Request: req}))
+		if !errors.Is(err, tt.want) {
+			t.Errorf("status %d: expected %v, got %v", tt.status, tt.want, err)
+		}
+		if !strings.Contains(err.Error(), fmt.Sprintf("status code: %d", tt.status)) || !strings.Contains(err.Error(), "https://biblehub.com/esv/john/3.htm") {
+			t.Errorf("status %d: unexpected message %q", tt.status, err.Error())
+		}
+	}
+}
+
+func TestIsRequestError(t *testing.T) {
+	requestErrors := []error{
+		fmt.Errorf("verse %w", ErrNotFound),
+		fmt.Errorf("%w: unknown book: Hezekiah", ErrInvalidReference),
+		fmt.Errorf("%w: version not found: XYZ", ErrUnsupportedVersion),
+		fmt.Errorf("%w: search not supported on Bible.com", ErrUnsupportedVersion),
+		&StatusError{StatusCode: http.StatusNotFound},
+	}
+	for _, err := range requestErrors {
+		if !IsRequestError(err) {
+			t.Errorf("expected %v to be a request error", err)
+		}
+	}
+
+	providerErrors := []error{
+		nil,
+		errors.New("boom"),
+		&StatusError{StatusCode: http.StatusTooManyRequests},
+		&MarkupError{Provider: "biblegateway"},
+		fmt.Errorf("%w: dial tcp: timeout", ErrUpstreamUnavailable),
+	}
+	for _, err := range providerErrors {
+		if IsRequestError(err) {
+			t.Errorf("expected %v not to be a request error", err)
+		}
+	}
+}
+
+func TestObservedProvider_RequestErrors(t *testing.T) {
+	registry := metrics.NewRegistry(time.Minute)
+	mock := &MockProvider{
+		GetVerseFunc: func(book, chapter, verse, version string) (string, error) {
+			return "", fmt.Errorf("%w: unknown book: %s", ErrInvalidReference, book)
+		},
+		SearchWordsFunc: func(query, version string) ([]SearchResult, error) {
+			return nil, fmt.Errorf("%w: search not supported on Bible.com", ErrUnsupportedVersion)
+		},
+	}
+	p := NewObservedProvider("biblecom", mock, registry).WithBreaker(circuit.Config{ConsecutiveFailures: 2, OpenTimeout: time.Hour})
+
+	for i := 0; i < 5; i++ {
+		if _, err := p.GetVerse("Hezekiah", "1", "1", "111"); !errors.Is(err, ErrInvalidReference) {
+			t.Fatalf("expected the request error to be passed through, got %v", err)
+		}
+		if _, err := p.SearchWords("love", "111"); !errors.Is(err, ErrUnsupportedVersion) {
+			t.Fatalf("expected the request error to be passed through, got %v", err)
+		}
+	}
+
+	if state := p.State(); state != circuit.Closed {
+		t.Errorf("expected request errors not to trip the breaker, got %s", state)
+	}
+	if stats := registry.Snapshot(metrics.ComponentBible)["biblecom"]; stats.Requests != 10 || stats.Errors != 0 {
+		t.Errorf("expected request errors not to count against health, got %+v", stats)
+	}
+}
+
+func TestObservedProvider_OpenCircuitIsUnavailable(t *testing.T) {
+	mock := &MockProvider{
+		GetVerseFunc: func(book, chapter, verse, version string) (string, error) {
+			return "", &StatusError{Provider: "biblenow", StatusCode: http.StatusBadGateway}
+		},
+	}
+	p := NewObservedProvider("biblenow", mock, metrics.NewRegistry(time.Minute)).WithBreaker(circuit.Config{ConsecutiveFailures: 1, OpenTimeout: time.Hour})
+
+	p.GetVerse("John", "3", "16", "KJV")
+	_, err := p.GetVerse("John", "3", "16", "KJV")
+	if !errors.Is(err, circuit.ErrOpen) || !errors.Is(err, ErrUpstreamUnavailable) {
+		t.Errorf("expected an open circuit to be reported as unavailable, got %v", err)
+	}
+}
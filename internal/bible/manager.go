@@ -2,6 +2,9 @@ package bible
 
 import (
 	"fmt"
+	"sort"
+
+	"bible-api-service/internal/circuit"
 )
 
 // DefaultProviderName is the name of the default provider (Bible Gateway).
@@ -37,6 +40,63 @@ func (m *ProviderManager) GetProvider(name string) (Provider, error) {
 	return nil, fmt.Errorf("provider not found: %s", name)
 }
 
+// ProviderNames returns the names of all registered providers in sorted order.
+func (m *ProviderManager) ProviderNames() []string {
+	names := make([]string, 0, len(m.providers))
+	for name := range m.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ProviderState returns the circuit state of a registered provider.
+// Providers without a circuit breaker, and unknown providers, are reported as closed.
+func (m *ProviderManager) ProviderState(name string) circuit.State {
+	if p, ok := m.providers[name].(interface{ State() circuit.State }); ok {
+		return p.State()
+	}
+	return circuit.Closed
+}
+
+// Capabilities returns the capabilities of a registered provider, or nil for
+// unknown providers.
+func (m *ProviderManager) Capabilities(name string) []string {
+	return Capabilities(m.providers[name])
+}
+
+// ProvidersWith returns the names of the providers offering a capability in
+// sorted order.
+func (m *ProviderManager) ProvidersWith(capability string) []string {
+	var names []string
+	for _, name := range m.ProviderNames() {
+		if HasCapability(m.providers[name], capability) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// ProviderWith returns a provider offering a capability, for data that does
+// not depend on the version, such as lexicon entries. The default provider
+// is preferred, and providers whose circuit is open are skipped. The error
+// wraps ErrUnsupportedVersion when no provider offers the capability.
+func (m *ProviderManager) ProviderWith(capability string) (Provider, error) {
+	names := m.ProvidersWith(capability)
+	if len(names) == 0 {
+		return nil, fmt.Errorf("%w: no provider supports %s", ErrUnsupportedVersion, capability)
+	}
+	sort.SliceStable(names, func(i, j int) bool {
+		return names[i] == DefaultProviderName && names[j] != DefaultProviderName
+	})
+	for _, name := range names {
+		if m.ProviderState(name) != circuit.Open {
+			return m.providers[name], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: no healthy provider supports %s", ErrUpstreamUnavailable, capability)
+}
+
 // GetVerse fetches a verse using the primary provider.
 // In the future, this could implement fallback logic.
 func (m *ProviderManager) GetVerse(book, chapter, verse, version string) (string, error) {
@@ -52,5 +112,5 @@ func (m *ProviderManager) SearchWords(query, version string) ([]SearchResult, er
 	if m.primary == nil {
 		return nil, fmt.Errorf("no primary provider configured")
 	}
-	return m.primary.SearchWords(query, version)
+	return Search(m.primary, query, version)
 }
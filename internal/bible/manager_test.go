@@ -1,7 +1,12 @@
 package bible
 
 import (
+	"errors"
 	"testing"
+	"time"
+
+	"bible-api-service/internal/circuit"
+	"bible-api-service/internal/metrics"
 )
 
 type MockProvider struct {
@@ -123,3 +128,65 @@ func TestProviderManager(t *testing.T) {
 		}
 	})
 }
+
+func TestObservedProvider(t *testing.T) {
+	registry := metrics.NewRegistry(time.Minute)
+	mock := &MockProvider{
+		GetVerseFunc: func(book, chapter, verse, version string) (string, error) {
+			return "", errors.New("upstream down")
+		},
+	}
+	p := NewObservedProvider("biblehub", mock, registry)
+
+	if _, err := p.GetVerse("John", "11", "35", "ESV"); err == nil {
+		t.Fatal("expected error to be passed through")
+	}
+	if _, err := p.SearchWords("wept", "ESV"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	stats := registry.Snapshot(metrics.ComponentBible)["biblehub"]
+	if stats.Requests != 2 || stats.Errors != 1 {
+		t.Errorf("expected 2 requests and 1 error, got %+v", stats)
+	}
+	if p.Unwrap() != mock {
+		t.Error("expected Unwrap to return the wrapped provider")
+	}
+}
+
+func TestObservedProvider_Breaker(t *testing.T) {
+	registry := metrics.NewRegistry(time.Minute)
+	calls := 0
+	mock := &MockProvider{
+		GetVerseFunc: func(book, chapter, verse, version string) (string, error) {
+			calls++
+			return "", errors.New("upstream down")
+		},
+	}
+	p := NewObservedProvider("biblenow", mock, registry).WithBreaker(circuit.Config{ConsecutiveFailures: 2, OpenTimeout: time.Hour})
+
+	pm := NewProviderManager(nil)
+	pm.RegisterProvider("biblenow", p)
+
+	for i := 0; i < 2; i++ {
+		p.GetVerse("John", "11", "35", "KJV")
+	}
+	if state := pm.ProviderState("biblenow"); state != circuit.Open {
+		t.Fatalf("expected open circuit, got %s", state)
+	}
+
+	_, err := p.GetVerse("John", "11", "35", "KJV")
+	if !errors.Is(err, circuit.ErrOpen) {
+		t.Errorf("expected ErrOpen, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected open circuit to skip upstream call, got %d calls", calls)
+	}
+
+	if state := registry.Snapshot(metrics.ComponentBible)["biblenow"].State; state != "open" {
+		t.Errorf("expected breaker state in metrics, got %q", state)
+	}
+	if state := pm.ProviderState("unknown"); state != circuit.Closed {
+		t.Errorf("expected unknown provider to be closed, got %s", state)
+	}
+}
@@ -0,0 +1,47 @@
+package bible
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrMarkupChanged matches every MarkupError with errors.Is.
+var ErrMarkupChanged = errors.New("provider markup changed")
+
+// Selector is a CSS selector a scraper depends on to parse a page.
+type Selector struct {
+	// Name identifies the selector in errors, metrics and canary reports.
+	Name  string `json:"name"`
+	Query string `json:"query"`
+}
+
+// MarkupError reports that a critical selector matched nothing on a page that
+// was otherwise served successfully, which usually means the site was redesigned.
+type MarkupError struct {
+	Provider string
+	Selector Selector
+	URL      string
+}
+
+func (e *MarkupError) Error() string {
+	return fmt.Sprintf("%s: %s: selector %s (%s) matched nothing on %s", e.Provider, ErrMarkupChanged, e.Selector.Name, e.Selector.Query, e.URL)
+}
+
+// Unwrap makes errors.Is(err, ErrMarkupChanged) report true.
+func (e *MarkupError) Unwrap() error {
+	return ErrMarkupChanged
+}
+
+// CanaryCheck is a page with known content that a canary run fetches to check
+// that a provider's selectors still match.
+type CanaryCheck struct {
+	// Name describes the page, e.g. "verse John 3:16".
+	Name      string
+	URL       string
+	Selectors []Selector
+}
+
+// CanaryProvider is implemented by providers that declare canary checks.
+type CanaryProvider interface {
+	CanaryChecks() []CanaryCheck
+}
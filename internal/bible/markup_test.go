@@ -0,0 +1,57 @@
+package bible
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"bible-api-service/internal/metrics"
+)
+
+func TestMarkupError(t *testing.T) {
+	err := fmt.Errorf("failed to fetch chapter 3: %w", &MarkupError{
+		Provider: "biblehub",
+		Selector: Selector{Name: "verse number", Query: ".reftext"},
+		URL:      "https://biblehub.com/esv/john/3.htm",
+	})
+
+	if !errors.Is(err, ErrMarkupChanged) {
+		t.Error("expected errors.Is to match ErrMarkupChanged")
+	}
+	var markupErr *MarkupError
+	if !errors.As(err, &markupErr) || markupErr.Selector.Name != "verse number" {
+		t.Fatalf("expected a wrapped MarkupError, got %v", err)
+	}
+	for _, want := range []string{"biblehub", "verse number", ".reftext", "https://biblehub.com/esv/john/3.htm"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in %q", want, err.Error())
+		}
+	}
+}
+
+func TestObservedProvider_MarkupChange(t *testing.T) {
+	registry := metrics.NewRegistry(time.Minute)
+	mock := &MockProvider{
+		GetVerseFunc: func(book, chapter, verse, version string) (string, error) {
+			return "", &MarkupError{Provider: "biblegateway", Selector: Selector{Name: "passage", Query: ".passage-text"}}
+		},
+		SearchWordsFunc: func(query, version string) ([]SearchResult, error) {
+			return nil, errors.New("upstream down")
+		},
+	}
+	p := NewObservedProvider("biblegateway", mock, registry)
+
+	p.GetVerse("John", "3", "16", "ESV")
+	p.GetVerse("John", "3", "17", "ESV")
+	p.SearchWords("love", "ESV")
+
+	stats := registry.Snapshot(metrics.ComponentBible)["biblegateway"]
+	if stats.Errors != 3 {
+		t.Errorf("expected 3 errors, got %d", stats.Errors)
+	}
+	if stats.MarkupChanges != 2 || stats.LastMarkupChange != "passage" {
+		t.Errorf("expected 2 markup changes of the passage selector, got %d %q", stats.MarkupChanges, stats.LastMarkupChange)
+	}
+}
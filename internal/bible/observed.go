@@ -0,0 +1,191 @@
+package bible
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"bible-api-service/internal/circuit"
+	"bible-api-service/internal/metrics"
+)
+
+// ObservedProvider decorates a Provider, recording the latency and outcome of
+// every call in a metrics registry so that provider health can be reported.
+// It can optionally guard the provider with a circuit breaker.
+type ObservedProvider struct {
+	name     string
+	provider Provider
+	metrics  *metrics.Registry
+	breaker  *circuit.Breaker
+}
+
+// NewObservedProvider wraps p, recording observations under the given provider name.
+func NewObservedProvider(name string, p Provider, registry *metrics.Registry) *ObservedProvider {
+	if registry == nil {
+		registry = metrics.Default
+	}
+	return &ObservedProvider{name: name, provider: p, metrics: registry}
+}
+
+// WithBreaker guards the provider with a circuit breaker built from cfg.
+// State changes are published to the metrics registry. Unless cfg sets
+// IsFailure, request errors (see IsRequestError) do not count as failures.
+func (o *ObservedProvider) WithBreaker(cfg circuit.Config) *ObservedProvider {
+	if cfg.IsFailure == nil {
+		cfg.IsFailure = isProviderFailure
+	}
+	onStateChange := cfg.OnStateChange
+	cfg.OnStateChange = func(from, to circuit.State) {
+		o.metrics.SetState(metrics.ComponentBible, o.name, to.String())
+		if onStateChange != nil {
+			onStateChange(from, to)
+		}
+	}
+	o.breaker = circuit.New(cfg)
+	o.metrics.SetState(metrics.ComponentBible, o.name, circuit.Closed.String())
+	return o
+}
+
+// Unwrap returns the underlying provider.
+func (o *ObservedProvider) Unwrap() Provider {
+	return o.provider
+}
+
+// State returns the circuit state of the provider. Providers without a breaker are always closed.
+func (o *ObservedProvider) State() circuit.State {
+	if o.breaker == nil {
+		return circuit.Closed
+	}
+	return o.breaker.State()
+}
+
+// BreakerStats returns a snapshot of the provider's circuit breaker, if any.
+func (o *ObservedProvider) BreakerStats() (circuit.Stats, bool) {
+	if o.breaker == nil {
+		return circuit.Stats{}, false
+	}
+	return o.breaker.Stats(), true
+}
+
+func (o *ObservedProvider) call(fn func() error) error {
+	if o.breaker != nil {
+		if err := o.breaker.Allow(); err != nil {
+			return fmt.Errorf("provider %s: %w: %w", o.name, ErrUpstreamUnavailable, err)
+		}
+	}
+
+	start := time.Now()
+	err := fn()
+	elapsed := time.Since(start)
+
+	// Bad references say nothing about the provider's health
+	observed := err
+	if !isProviderFailure(err) {
+		observed = nil
+	}
+	o.metrics.Observe(metrics.ComponentBible, o.name, elapsed, observed)
+	var markupErr *MarkupError
+	if errors.As(err, &markupErr) {
+		o.metrics.RecordMarkupChange(metrics.ComponentBible, o.name, markupErr.Selector.Name)
+		slog.Error("Provider markup changed", "provider", o.name, "selector", markupErr.Selector.Name, "query", markupErr.Selector.Query, "url", markupErr.URL)
+	}
+	if o.breaker != nil {
+		o.breaker.Record(elapsed, err)
+	}
+	return err
+}
+
+func isProviderFailure(err error) bool {
+	return err != nil && !IsRequestError(err)
+}
+
+// GetVerse fetches a verse from the underlying provider.
+func (o *ObservedProvider) GetVerse(book, chapter, verse, version string) (string, error) {
+	var text string
+	err := o.call(func() (err error) {
+		text, err = o.provider.GetVerse(book, chapter, verse, version)
+		return err
+	})
+	return text, err
+}
+
+// GetPassage fetches a passage and its notes from the underlying provider.
+func (o *ObservedProvider) GetPassage(book, chapter, verse, version string, opts VerseOptions) (Passage, error) {
+	var passage Passage
+	err := o.call(func() (err error) {
+		passage, err = GetPassage(o.provider, book, chapter, verse, version, opts)
+		return err
+	})
+	return passage, err
+}
+
+// SearchWords searches using the underlying provider.
+func (o *ObservedProvider) SearchWords(query, version string) ([]SearchResult, error) {
+	var results []SearchResult
+	err := o.call(func() (err error) {
+		results, err = Search(o.provider, query, version)
+		return err
+	})
+	return results, err
+}
+
+// GetVersions fetches the versions list from the underlying provider.
+func (o *ObservedProvider) GetVersions() ([]ProviderVersion, error) {
+	var versions []ProviderVersion
+	err := o.call(func() (err error) {
+		versions, err = o.provider.GetVersions()
+		return err
+	})
+	return versions, err
+}
+
+// GetInterlinear fetches an interlinear passage from the underlying provider.
+func (o *ObservedProvider) GetInterlinear(book, chapter, verse string) ([]InterlinearVerse, error) {
+	var verses []InterlinearVerse
+	err := o.call(func() (err error) {
+		verses, err = GetInterlinear(o.provider, book, chapter, verse)
+		return err
+	})
+	return verses, err
+}
+
+// GetLexiconEntry fetches a lexicon entry from the underlying provider.
+func (o *ObservedProvider) GetLexiconEntry(strongs string) (LexiconEntry, error) {
+	var entry LexiconEntry
+	err := o.call(func() (err error) {
+		entry, err = GetLexiconEntry(o.provider, strongs)
+		return err
+	})
+	return entry, err
+}
+
+// LookupTopic looks a topic up in the underlying provider.
+func (o *ObservedProvider) LookupTopic(topic string) (Topic, error) {
+	var t Topic
+	err := o.call(func() (err error) {
+		t, err = LookupTopic(o.provider, topic)
+		return err
+	})
+	return t, err
+}
+
+// GetChapterCount fetches the number of chapters of a book from the underlying provider.
+func (o *ObservedProvider) GetChapterCount(book, version string) (int, error) {
+	var count int
+	err := o.call(func() (err error) {
+		count, err = GetChapterCount(o.provider, book, version)
+		return err
+	})
+	return count, err
+}
+
+// GetAudioLink fetches the audio link of a chapter from the underlying provider.
+func (o *ObservedProvider) GetAudioLink(book, chapter, version string) (string, error) {
+	var link string
+	err := o.call(func() (err error) {
+		link, err = GetAudioLink(o.provider, book, chapter, version)
+		return err
+	})
+	return link, err
+}
@@ -0,0 +1,66 @@
+package bible
+
+// Footnote is a translator's note attached to a verse.
+type Footnote struct {
+	// Verse is the reference the note belongs to, e.g. "John 3:16".
+	Verse string `json:"verse"`
+	// Marker is the letter that links the note to the text, e.g. "a".
+	Marker string `json:"marker"`
+	Text   string `json:"text"`
+}
+
+// CrossReference lists the passages a verse refers the reader to.
+type CrossReference struct {
+	// Verse is the reference the cross-reference belongs to, e.g. "John 3:16".
+	Verse string `json:"verse"`
+	// Marker is the letter that links the cross-reference to the text, e.g. "A".
+	Marker  string   `json:"marker"`
+	Targets []string `json:"targets"`
+}
+
+// VerseOptions selects the notes returned alongside a passage.
+type VerseOptions struct {
+	Footnotes       bool
+	CrossReferences bool
+	// VerseNumbers precedes each verse with its number, as <sup>N </sup>,
+	// for the formats and features that split a passage into verses.
+	VerseNumbers bool
+}
+
+// Passage is the text of a reference together with the notes requested in VerseOptions.
+type Passage struct {
+	Text            string
+	Footnotes       []Footnote
+	CrossReferences []CrossReference
+}
+
+// PassageProvider is implemented by providers that can return the notes of a
+// passage instead of discarding them.
+type PassageProvider interface {
+	GetPassage(book, chapter, verse, version string, opts VerseOptions) (Passage, error)
+}
+
+// NumberedVerseProvider is implemented by providers whose GetVerse text does
+// not number its verses. GetNumberedVerse returns the numbered text, for
+// VerseOptions.VerseNumbers.
+type NumberedVerseProvider interface {
+	GetNumberedVerse(book, chapter, verse, version string) (string, error)
+}
+
+// GetPassage fetches a passage from p. Providers that do not implement
+// PassageProvider return the text from GetVerse, or from GetNumberedVerse
+// when opts asks for verse numbers, and no notes.
+func GetPassage(p Provider, book, chapter, verse, version string, opts VerseOptions) (Passage, error) {
+	if pp, ok := p.(PassageProvider); ok {
+		return pp.GetPassage(book, chapter, verse, version, opts)
+	}
+	getVerse := p.GetVerse
+	if np, ok := p.(NumberedVerseProvider); ok && opts.VerseNumbers {
+		getVerse = np.GetNumberedVerse
+	}
+	text, err := getVerse(book, chapter, verse, version)
+	if err != nil {
+		return Passage{}, err
+	}
+	return Passage{Text: text, Footnotes: []Footnote{}, CrossReferences: []CrossReference{}}, nil
+}
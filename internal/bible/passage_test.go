@@ -0,0 +1,70 @@
+package bible
+
+import (
+	"testing"
+	"time"
+
+	"bible-api-service/internal/metrics"
+)
+
+type passageProvider struct {
+	MockProvider
+	passage Passage
+}
+
+func (p *passageProvider) GetPassage(book, chapter, verse, version string, opts VerseOptions) (Passage, error) {
+	return p.passage, nil
+}
+
+type numberedVerseProvider struct {
+	MockProvider
+}
+
+func (p *numberedVerseProvider) GetNumberedVerse(book, chapter, verse, version string) (string, error) {
+	return "<sup>16 </sup>text", nil
+}
+
+func TestGetPassage(t *testing.T) {
+	t.Run("falls back to GetVerse", func(t *testing.T) {
+		p := &MockProvider{GetVerseFunc: func(book, chapter, verse, version string) (string, error) {
+			return "text", nil
+		}}
+		passage, err := GetPassage(p, "John", "3", "16", "ESV", VerseOptions{Footnotes: true, CrossReferences: true})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if passage.Text != "text" {
+			t.Errorf("expected text, got %q", passage.Text)
+		}
+		if passage.Footnotes == nil || len(passage.Footnotes) != 0 || passage.CrossReferences == nil || len(passage.CrossReferences) != 0 {
+			t.Errorf("expected empty notes, got %+v", passage)
+		}
+	})
+
+	t.Run("numbers verses on request", func(t *testing.T) {
+		p := &numberedVerseProvider{MockProvider{GetVerseFunc: func(book, chapter, verse, version string) (string, error) {
+			return "text", nil
+		}}}
+		for opts, want := range map[VerseOptions]string{{}: "text", {VerseNumbers: true}: "<sup>16 </sup>text"} {
+			passage, err := GetPassage(NewObservedProvider("test", p, metrics.NewRegistry(time.Minute)), "John", "3", "16", "ESV", opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if passage.Text != want {
+				t.Errorf("expected %q for %+v, got %q", want, opts, passage.Text)
+			}
+		}
+	})
+
+	t.Run("uses PassageProvider through ObservedProvider", func(t *testing.T) {
+		want := Passage{Text: "text", Footnotes: []Footnote{{Verse: "John 3:16", Marker: "a", Text: "note"}}}
+		p := NewObservedProvider("test", &passageProvider{passage: want}, metrics.NewRegistry(time.Minute))
+		passage, err := GetPassage(p, "John", "3", "16", "ESV", VerseOptions{Footnotes: true})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(passage.Footnotes) != 1 || passage.Footnotes[0] != want.Footnotes[0] {
+			t.Errorf("expected %+v, got %+v", want, passage)
+		}
+	})
+}
@@ -15,15 +15,13 @@ type ProviderVersion struct {
 	Language string `json:"language"`
 }
 
-// Provider defines the interface for a Bible data provider.
+// Provider defines the interface for a Bible data provider. Providers offer
+// more through optional interfaces, such as Searcher; see Capabilities.
 type Provider interface {
 	// GetVerse fetches a single Bible verse or passage by reference.
 	// Returns the content as a string (often HTML) or an error.
 	GetVerse(book, chapter, verse, version string) (string, error)
 
-	// SearchWords searches for a word or phrase and returns a list of relevant verses.
-	SearchWords(query, version string) ([]SearchResult, error)
-
 	// GetVersions fetches the list of available Bible versions.
 	GetVersions() ([]ProviderVersion, error)
 }
@@ -0,0 +1,73 @@
+package biblecom
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"bible-api-service/internal/bible"
+	"bible-api-service/internal/bible/providers/markup"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// audioURL returns the audio page of a chapter, e.g. https://www.bible.com/audio-bible/111/JHN.3.
+func (s *Scraper) audioURL(version, usfmBook string, chapter int) string {
+	return fmt.Sprintf("%s/audio-bible/%s/%s.%d", s.baseURL, version, usfmBook, chapter)
+}
+
+// GetAudioLink returns the link to the recording of a chapter of a version.
+// Versions without recordings have no audio pages, so they are not found.
+func (s *Scraper) GetAudioLink(book, chapter, version string) (string, error) {
+	if version == "" {
+		version = "111" // Default to NIV ID
+	}
+
+	usfmBook, err := mapBookToUSFM(book)
+	if err != nil {
+		return "", err
+	}
+	if usfmBook == "PSA" && chapter == "151" {
+		usfmBook, chapter = "PS2", "1"
+	}
+	chapterNum, err := strconv.Atoi(chapter)
+	if err != nil {
+		return "", fmt.Errorf("%w: invalid chapter format: %v", bible.ErrInvalidReference, err)
+	}
+
+	pageURL := s.audioURL(version, usfmBook, chapterNum)
+	req, err := http.NewRequest("GET", pageURL, nil)
+	if err != nil {
+		return "", err
+	}
+	res, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch audio of chapter %d: %w: %w", chapterNum, bible.ErrUpstreamUnavailable, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != 200 {
+		return "", fmt.Errorf("failed to fetch audio of chapter %d: %w", chapterNum, bible.NewStatusError(providerName, res))
+	}
+
+	doc, err := goquery.NewDocumentFromReader(res.Body)
+	if err != nil {
+		return "", err
+	}
+	if err := markup.Require(doc.Selection, providerName, pageURL, audioSelector); err != nil {
+		return "", err
+	}
+
+	// Recordings are usually linked without a scheme, e.g. //audio-bible-cdn...
+	src, _ := doc.Find(audioSelector.Query).First().Attr("src")
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return "", err
+	}
+	link, err := base.Parse(src)
+	if err != nil {
+		return "", fmt.Errorf("invalid audio link %q: %w", src, err)
+	}
+	return link.String(), nil
+}
@@ -0,0 +1,52 @@
+package biblecom
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"bible-api-service/internal/bible"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAudioLink(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/audio-bible/111/JHN.3":
+			w.Write([]byte(`<html><body><audio controls><source src="//audio-bible-cdn.example.com/111/JHN.3.mp3" type="audio/mpeg"></audio></body></html>`))
+		case "/audio-bible/111/PS2.1":
+			w.Write([]byte(`<html><body><audio src="/audio/111/PS2.1.mp3"></audio></body></html>`))
+		case "/audio-bible/111/GEN.1":
+			w.Write([]byte(`<html><body><div class="player"></div></body></html>`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	scraper := NewScraper()
+	scraper.baseURL = server.URL
+
+	link, err := scraper.GetAudioLink("John", "3", "111")
+	require.NoError(t, err)
+	assert.Equal(t, "http://audio-bible-cdn.example.com/111/JHN.3.mp3", link)
+
+	// Psalm 151 is a book of its own on Bible.com
+	link, err = scraper.GetAudioLink("Psalms", "151", "111")
+	require.NoError(t, err)
+	assert.Equal(t, server.URL+"/audio/111/PS2.1.mp3", link)
+
+	_, err = scraper.GetAudioLink("John", "3", "59")
+	assert.ErrorIs(t, err, bible.ErrNotFound)
+
+	_, err = scraper.GetAudioLink("John", "three", "111")
+	assert.ErrorIs(t, err, bible.ErrInvalidReference)
+
+	_, err = scraper.GetAudioLink("Genesis", "1", "111")
+	var markupErr *bible.MarkupError
+	if assert.ErrorAs(t, err, &markupErr) {
+		assert.Equal(t, audioSelector, markupErr.Selector)
+	}
+}
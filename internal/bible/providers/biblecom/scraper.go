@@ -8,6 +8,8 @@ import (
 	"strings"
 
 	"bible-api-service/internal/bible"
+	"bible-api-service/internal/bible/providers/httpx"
+	"bible-api-service/internal/bible/providers/markup"
 	"bible-api-service/internal/util"
 
 	"github.com/PuerkitoBio/goquery"
@@ -22,13 +24,27 @@ type Scraper struct {
 // NewScraper creates a new Scraper.
 func NewScraper() *Scraper {
 	return &Scraper{
-		client:  &http.Client{},
+		client:  httpx.NewClient(),
 		baseURL: "https://www.bible.com",
 	}
 }
 
+// chapterURL returns the page of a chapter, e.g. https://www.bible.com/bible/111/JHN.3.
+func (s *Scraper) chapterURL(version, usfmBook string, chapter int) string {
+	return fmt.Sprintf("%s/bible/%s/%s.%d", s.baseURL, version, usfmBook, chapter)
+}
+
 // GetVerse fetches a verse or range of verses from Bible.com.
 func (s *Scraper) GetVerse(book, chapter, verse, version string) (string, error) {
+	return s.getVerse(book, chapter, verse, version, false)
+}
+
+// GetNumberedVerse is GetVerse with each verse preceded by its number.
+func (s *Scraper) GetNumberedVerse(book, chapter, verse, version string) (string, error) {
+	return s.getVerse(book, chapter, verse, version, true)
+}
+
+func (s *Scraper) getVerse(book, chapter, verse, version string, numbered bool) (string, error) {
 	if version == "" {
 		version = "111" // Default to NIV ID
 	}
@@ -37,6 +53,10 @@ func (s *Scraper) GetVerse(book, chapter, verse, version string) (string, error)
 	if err != nil {
 		return "", err
 	}
+	// Bible.com has Psalm 151 as a book of its own
+	if usfmBook == "PSA" && chapter == "151" {
+		usfmBook, chapter = "PS2", "1"
+	}
 
 	startVerse := 1
 	endVerse := 999
@@ -44,13 +64,13 @@ func (s *Scraper) GetVerse(book, chapter, verse, version string) (string, error)
 
 	startChapterVal, err := strconv.Atoi(chapter)
 	if err != nil {
-		return "", fmt.Errorf("invalid chapter format: %v", err)
+		return "", fmt.Errorf("%w: invalid chapter format: %v", bible.ErrInvalidReference, err)
 	}
 
 	if verse != "" {
 		parsed, err := util.ParseVerseRange(verse)
 		if err != nil {
-			return "", fmt.Errorf("invalid verse range: %v", err)
+			return "", fmt.Errorf("%w: invalid verse range: %v", bible.ErrInvalidReference, err)
 		}
 		startVerse = parsed.StartVerse
 		endVerse = parsed.EndVerse
@@ -76,23 +96,21 @@ func (s *Scraper) GetVerse(book, chapter, verse, version string) (string, error)
 			currentEndV = endVerse
 		}
 
-		// URL format: https://www.bible.com/bible/{versionID}/{BookUSFM}.{Chapter}
-		url := fmt.Sprintf("%s/bible/%s/%s.%d", s.baseURL, version, usfmBook, currentChap)
+		url := s.chapterURL(version, usfmBook, currentChap)
 
 		req, err := http.NewRequest("GET", url, nil)
 		if err != nil {
 			return "", err
 		}
-		req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; BibleAIAPI/1.0)")
 
 		res, err := s.client.Do(req)
 		if err != nil {
-			return "", fmt.Errorf("failed to fetch chapter %d: %v", currentChap, err)
+			return "", fmt.Errorf("failed to fetch chapter %d: %w: %w", currentChap, bible.ErrUpstreamUnavailable, err)
 		}
 		defer res.Body.Close()
 
 		if res.StatusCode != 200 {
-			return "", fmt.Errorf("failed to fetch chapter %d, status code: %d", currentChap, res.StatusCode)
+			return "", fmt.Errorf("failed to fetch chapter %d: %w", currentChap, bible.NewStatusError(providerName, res))
 		}
 
 		doc, err := goquery.NewDocumentFromReader(res.Body)
@@ -100,6 +118,10 @@ func (s *Scraper) GetVerse(book, chapter, verse, version string) (string, error)
 			return "", err
 		}
 
+		if err := markup.Require(doc.Selection, providerName, url, verseSelector); err != nil {
+			return "", err
+		}
+
 		var chapterTextBuilder strings.Builder
 
 		for v := currentStartV; v <= currentEndV; v++ {
@@ -123,10 +145,13 @@ func (s *Scraper) GetVerse(book, chapter, verse, version string) (string, error)
 				continue
 			}
 
-			text := strings.TrimSpace(selection.Text())
+			text := strings.TrimSpace(vocabulary.HTML(selection.Contents()))
 			if chapterTextBuilder.Len() > 0 {
 				chapterTextBuilder.WriteString(" ")
 			}
+			if numbered {
+				fmt.Fprintf(&chapterTextBuilder, "<sup>%d </sup>", v)
+			}
 			chapterTextBuilder.WriteString(text)
 		}
 
@@ -140,7 +165,7 @@ func (s *Scraper) GetVerse(book, chapter, verse, version string) (string, error)
 
 	finalResult := strings.TrimSpace(allTextBuilder.String())
 	if finalResult == "" {
-		return "", fmt.Errorf("verses not found")
+		return "", fmt.Errorf("verses %w", bible.ErrNotFound)
 	}
 
 	return finalResult, nil
@@ -154,16 +179,15 @@ func (s *Scraper) GetVersions() ([]bible.ProviderVersion, error) {
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; BibleAIAPI/1.0)")
 
 	res, err := s.client.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %w", bible.ErrUpstreamUnavailable, err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("failed to fetch versions, status code: %d", res.StatusCode)
+		return nil, fmt.Errorf("failed to fetch versions: %w", bible.NewStatusError(providerName, res))
 	}
 
 	doc, err := goquery.NewDocumentFromReader(res.Body)
@@ -171,13 +195,17 @@ func (s *Scraper) GetVersions() ([]bible.ProviderVersion, error) {
 		return nil, err
 	}
 
+	if err := markup.Require(doc.Selection, providerName, url, versionLinkSelector); err != nil {
+		return nil, err
+	}
+
 	var versions []bible.ProviderVersion
 
 	// Regex to parse href: /versions/(\d+)-([a-zA-Z0-9]+)-(.*)
 	// Example: /versions/111-niv-new-international-version
 	re := regexp.MustCompile(`^/versions/(\d+)-([a-zA-Z0-9]+)-(.*)$`)
 
-	doc.Find("a[href^='/versions/']").Each(func(i int, s *goquery.Selection) {
+	doc.Find(versionLinkSelector.Query).Each(func(i int, s *goquery.Selection) {
 		href, exists := s.Attr("href")
 		if !exists {
 			return
@@ -199,7 +227,7 @@ func (s *Scraper) GetVersions() ([]bible.ProviderVersion, error) {
 				Name:     name,
 				Value:    id,        // Provider specific ID
 				Code:     code,      // Unified code (e.g. NIV)
-				Language: "English", // We might want to infer language, but default to English or parse from section headers if needed.
+				Language: "Unknown", // The versions page does not say; update_versions derives it from the abbreviation where possible
 			})
 		}
 	})
@@ -207,90 +235,10 @@ func (s *Scraper) GetVersions() ([]bible.ProviderVersion, error) {
 	return versions, nil
 }
 
-// SearchWords is not supported on Bible.com.
-func (s *Scraper) SearchWords(query, version string) ([]bible.SearchResult, error) {
-	return nil, fmt.Errorf("search not supported on Bible.com")
-}
-
+// mapBookToUSFM returns the USFM identifier Bible.com uses for a book.
 func mapBookToUSFM(book string) (string, error) {
-	book = strings.ToLower(strings.TrimSpace(book))
-
-	// USFM Mapping
-	m := map[string]string{
-		"genesis":         "GEN",
-		"exodus":          "EXO",
-		"leviticus":       "LEV",
-		"numbers":         "NUM",
-		"deuteronomy":     "DEU",
-		"joshua":          "JOS",
-		"judges":          "JDG",
-		"ruth":            "RUT",
-		"1 samuel":        "1SA",
-		"2 samuel":        "2SA",
-		"1 kings":         "1KI",
-		"2 kings":         "2KI",
-		"1 chronicles":    "1CH",
-		"2 chronicles":    "2CH",
-		"ezra":            "EZR",
-		"nehemiah":        "NEH",
-		"esther":          "EST",
-		"job":             "JOB",
-		"psalms":          "PSA",
-		"psalm":           "PSA", // Alias
-		"proverbs":        "PRO",
-		"ecclesiastes":    "ECC",
-		"song of solomon": "SNG",
-		"song of songs":   "SNG", // Alias
-		"isaiah":          "ISA",
-		"jeremiah":        "JER",
-		"lamentations":    "LAM",
-		"ezekiel":         "EZK",
-		"daniel":          "DAN",
-		"hosea":           "HOS",
-		"joel":            "JOL",
-		"amos":            "AMO",
-		"obadiah":         "OBA",
-		"jonah":           "JON",
-		"micah":           "MIC",
-		"nahum":           "NAM",
-		"habakkuk":        "HAB",
-		"zephaniah":       "ZEP",
-		"haggai":          "HAG",
-		"zechariah":       "ZEC",
-		"malachi":         "MAL",
-
-		"matthew":         "MAT",
-		"mark":            "MRK",
-		"luke":            "LUK",
-		"john":            "JHN",
-		"acts":            "ACT",
-		"romans":          "ROM",
-		"1 corinthians":   "1CO",
-		"2 corinthians":   "2CO",
-		"galatians":       "GAL",
-		"ephesians":       "EPH",
-		"philippians":     "PHP",
-		"colossians":      "COL",
-		"1 thessalonians": "1TH",
-		"2 thessalonians": "2TH",
-		"1 timothy":       "1TI",
-		"2 timothy":       "2TI",
-		"titus":           "TIT",
-		"philemon":        "PHM",
-		"hebrews":         "HEB",
-		"james":           "JAS",
-		"1 peter":         "1PE",
-		"2 peter":         "2PE",
-		"1 john":          "1JN",
-		"2 john":          "2JN",
-		"3 john":          "3JN",
-		"jude":            "JUD",
-		"revelation":      "REV",
+	if b, ok := bible.LookupBook(book); ok {
+		return b.USFM, nil
 	}
-
-	if val, ok := m[book]; ok {
-		return val, nil
-	}
-
-	return "", fmt.Errorf("unknown book: %s", book)
+	return "", fmt.Errorf("%w: unknown book: %s", bible.ErrInvalidReference, book)
 }
@@ -5,6 +5,8 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"bible-api-service/internal/bible"
+
 	"github.com/stretchr/testify/assert"
 )
 
@@ -61,6 +63,11 @@ func TestGetVerse(t *testing.T) {
 	text, err = scraper.GetVerse("John", "3", "1-2", "111")
 	assert.NoError(t, err)
 	assert.Equal(t, "For God so loved the world that he gave his one and only Son", text)
+
+	// Test case 3: Numbered verse range
+	text, err = scraper.GetNumberedVerse("John", "3", "1-2", "111")
+	assert.NoError(t, err)
+	assert.Equal(t, "<sup>1 </sup>For God so loved the world <sup>2 </sup>that he gave his one and only Son", text)
 }
 
 func TestGetVersions(t *testing.T) {
@@ -100,10 +107,84 @@ func TestGetVersions(t *testing.T) {
 	assert.Equal(t, "KJV", versions[1].Code)
 }
 
-func TestSearchWords(t *testing.T) {
+func TestGetVerse_ErrorClassification(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/bible/111/JHN.3" {
+			w.Write([]byte(`<html><body><span data-usfm="JHN.3.1">For God so loved the world</span></body></html>`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	scraper := NewScraper()
+	scraper.baseURL = server.URL
+
+	_, err := scraper.GetVerse("Hezekiah", "1", "1", "111")
+	assert.ErrorIs(t, err, bible.ErrInvalidReference)
+
+	_, err = scraper.GetVerse("John", "x", "1", "111")
+	assert.ErrorIs(t, err, bible.ErrInvalidReference)
+
+	_, err = scraper.GetVerse("John", "3", "40", "111")
+	assert.ErrorIs(t, err, bible.ErrNotFound)
+
+	_, err = scraper.GetVerse("John", "30", "1", "111")
+	assert.ErrorIs(t, err, bible.ErrNotFound)
+}
+
+func TestGetVerse_SemanticMarkup(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<html><body><div class="ChapterContent_chapter__uvbXo">
+			<span data-usfm="MAT.5.3" class="ChapterContent_verse__57FIw"><span class="ChapterContent_label__R2PLt">3</span><span class="ChapterContent_wj__ZCpX7"><span class="ChapterContent_content__RrUqA">“Blessed are the poor in spirit,</span></span><span class="ChapterContent_note__YlDW0 ChapterContent_f__bFOBP"><span class="ChapterContent_body__O3qjr">Or humble</span></span></span>
+		</div></body></html>`))
+	}))
+	defer server.Close()
+
+	scraper := NewScraper()
+	scraper.baseURL = server.URL
+
+	text, err := scraper.GetVerse("Matthew", "5", "3", "111")
+	assert.NoError(t, err)
+	assert.Equal(t, `<span class="woj">“Blessed are the poor in spirit,</span>`, text)
+
+	text, err = scraper.GetNumberedVerse("Matthew", "5", "3", "111")
+	assert.NoError(t, err)
+	assert.Equal(t, `<sup>3 </sup><span class="woj">“Blessed are the poor in spirit,</span>`, text)
+}
+
+func TestGetVerse_Deuterocanon(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/bible/37/SIR.1":
+			w.Write([]byte(`<span data-usfm="SIR.1.1">All wisdom is from the Lord</span>`))
+		case "/bible/37/PS2.1":
+			w.Write([]byte(`<span data-usfm="PS2.1.1">I was small among my brothers</span>`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
 	scraper := NewScraper()
-	results, err := scraper.SearchWords("test", "NIV")
-	assert.Error(t, err)
-	assert.Nil(t, results)
-	assert.Contains(t, err.Error(), "not supported")
+	scraper.baseURL = server.URL
+
+	text, err := scraper.GetVerse("Ecclesiasticus", "1", "1", "37")
+	assert.NoError(t, err)
+	assert.Equal(t, "All wisdom is from the Lord", text)
+
+	text, err = scraper.GetVerse("Psalm", "151", "1", "37")
+	assert.NoError(t, err)
+	assert.Equal(t, "I was small among my brothers", text)
+}
+
+func TestMapBookToUSFM(t *testing.T) {
+	for book, want := range map[string]string{"Song of Songs": "SNG", "1 Maccabees": "1MA", "Tobit": "TOB", "Baruch": "BAR", "psalm": "PSA"} {
+		got, err := mapBookToUSFM(book)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got, book)
+	}
+
+	_, err := mapBookToUSFM("Hezekiah")
+	assert.ErrorIs(t, err, bible.ErrInvalidReference)
 }
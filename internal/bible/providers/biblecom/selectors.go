@@ -0,0 +1,41 @@
+package biblecom
+
+import (
+	"bible-api-service/internal/bible"
+	"bible-api-service/internal/bible/providers/markup"
+)
+
+const providerName = "biblecom"
+
+// Selectors the scraper cannot work without. When one of them matches nothing
+// on a page that was served successfully, the scraper returns a
+// *bible.MarkupError instead of "not found".
+var (
+	verseSelector       = bible.Selector{Name: "verse", Query: "span[data-usfm]"}
+	versionLinkSelector = bible.Selector{Name: "version link", Query: "a[href^='/versions/']"}
+	audioSelector       = bible.Selector{Name: "audio", Query: "audio[src], audio source[src]"}
+)
+
+// vocabulary maps the USFM character styles Bible.com renders as classes,
+// plain or hashed, into the passage text vocabulary. Verse labels and notes
+// are left out.
+var vocabulary = markup.Vocabulary{
+	Classes: map[string]string{
+		"wj":                bible.ClassWordsOfJesus,
+		"nd":                bible.ClassDivineName,
+		"qs":                bible.ClassSelah,
+		"ChapterContent_wj": bible.ClassWordsOfJesus,
+		"ChapterContent_nd": bible.ClassDivineName,
+		"ChapterContent_qs": bible.ClassSelah,
+	},
+	Skip: ".label, .note, [class*='ChapterContent_label'], [class*='ChapterContent_note']",
+}
+
+// CanaryChecks returns pages with known content that exercise every selector.
+func (s *Scraper) CanaryChecks() []bible.CanaryCheck {
+	return []bible.CanaryCheck{
+		{Name: "chapter John 3", URL: s.chapterURL("111", "JHN", 3), Selectors: []bible.Selector{verseSelector}},
+		{Name: "versions", URL: s.baseURL + "/versions", Selectors: []bible.Selector{versionLinkSelector}},
+		{Name: "audio John 3", URL: s.audioURL("111", "JHN", 3), Selectors: []bible.Selector{audioSelector}},
+	}
+}
@@ -0,0 +1,36 @@
+package biblecom
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"bible-api-service/internal/bible"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScraper_MarkupChanged(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<html><body><div class="reader"><span class="verse" data-ref="JHN.3.16">For God so loved the world</span><a href="/bibles/111">NIV</a></div></body></html>`))
+	}))
+	defer server.Close()
+
+	scraper := NewScraper()
+	scraper.baseURL = server.URL
+
+	var markupErr *bible.MarkupError
+
+	_, err := scraper.GetVerse("John", "3", "16", "111")
+	assert.True(t, errors.Is(err, bible.ErrMarkupChanged))
+	if assert.True(t, errors.As(err, &markupErr)) {
+		assert.Equal(t, verseSelector, markupErr.Selector)
+		assert.Equal(t, server.URL+"/bible/111/JHN.3", markupErr.URL)
+	}
+
+	_, err = scraper.GetVersions()
+	if assert.True(t, errors.As(err, &markupErr)) {
+		assert.Equal(t, versionLinkSelector, markupErr.Selector)
+	}
+}
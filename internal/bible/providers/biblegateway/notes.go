@@ -0,0 +1,85 @@
+package biblegateway
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"bible-api-service/internal/bible"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// parseFootnotes returns the footnotes listed in doc. Each list item starts
+// with a link to its verse and is referenced from the text by a
+// sup.footnote whose data-fn attribute holds the item's id.
+func parseFootnotes(doc *goquery.Selection) []bible.Footnote {
+	footnotes := []bible.Footnote{}
+	doc.Find(footnoteSelector.Query).Each(func(_ int, li *goquery.Selection) {
+		id, _ := li.Attr("id")
+		footnotes = append(footnotes, bible.Footnote{
+			Verse:  noteVerse(li),
+			Marker: noteMarker(doc, "sup.footnote", "data-fn", id),
+			Text:   collapseSpace(li.Find(".footnote-text").Text()),
+		})
+	})
+	return footnotes
+}
+
+// parseCrossReferences returns the cross-references listed in doc. They are
+// laid out like footnotes, with sup.crossreference markers pointing at them
+// through data-cr.
+func parseCrossReferences(doc *goquery.Selection) []bible.CrossReference {
+	crossRefs := []bible.CrossReference{}
+	doc.Find(crossRefSelector.Query).Each(func(_ int, li *goquery.Selection) {
+		id, _ := li.Attr("id")
+		targets := []string{}
+		li.Find("a.crossref-link").Each(func(_ int, a *goquery.Selection) {
+			target, ok := a.Attr("data-bibleref")
+			if !ok || strings.TrimSpace(target) == "" {
+				target = a.Text()
+			}
+			targets = append(targets, collapseSpace(target))
+		})
+		crossRefs = append(crossRefs, bible.CrossReference{
+			Verse:   noteVerse(li),
+			Marker:  noteMarker(doc, "sup.crossreference", "data-cr", id),
+			Targets: targets,
+		})
+	})
+	return crossRefs
+}
+
+// noteVerse returns the reference of the verse a note belongs to, e.g. "John 3:16".
+func noteVerse(li *goquery.Selection) string {
+	return collapseSpace(li.Find("a").First().Text())
+}
+
+// noteMarker returns the letter of the marker pointing at the note with the given id.
+func noteMarker(doc *goquery.Selection, marker, attr, id string) string {
+	if id == "" {
+		return ""
+	}
+	sup := doc.Find(fmt.Sprintf("%s[%s='#%s']", marker, attr, id)).First()
+	return strings.Trim(strings.TrimSpace(sup.Text()), "[]()")
+}
+
+// noteVerseNumber returns the verse number of a reference such as
+// "John 3:16", or 0 if it has none.
+func noteVerseNumber(ref string) int {
+	i := strings.LastIndex(ref, ":")
+	if i < 0 {
+		return 0
+	}
+	n, err := strconv.Atoi(ref[i+1:])
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// collapseSpace trims s and collapses runs of whitespace, including
+// non-breaking spaces, to single spaces.
+func collapseSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
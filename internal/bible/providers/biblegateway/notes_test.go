@@ -0,0 +1,123 @@
+package biblegateway
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"bible-api-service/internal/bible"
+)
+
+func TestGetPassage_Notes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		html, err := os.ReadFile("testdata/get_verse_notes.html")
+		if err != nil {
+			t.Fatalf("failed to read mock html file: %v", err)
+		}
+		fmt.Fprintln(w, string(html))
+	}))
+	defer server.Close()
+
+	scraper := &Scraper{client: server.Client(), baseURL: server.URL}
+
+	passage, err := scraper.GetPassage("John", "3", "16-17", "ESV", bible.VerseOptions{Footnotes: true, CrossReferences: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantFootnotes := []bible.Footnote{
+		{Verse: "John 3:16", Marker: "a", Text: "Or For this is how God loved the world"},
+	}
+	if !reflect.DeepEqual(passage.Footnotes, wantFootnotes) {
+		t.Errorf("expected footnotes %+v, got %+v", wantFootnotes, passage.Footnotes)
+	}
+
+	wantCrossRefs := []bible.CrossReference{
+		{Verse: "John 3:16", Marker: "A", Targets: []string{"Romans 5:8", "1 John 4:9"}},
+		{Verse: "John 3:17", Marker: "B", Targets: []string{"John 12:47"}},
+	}
+	if !reflect.DeepEqual(passage.CrossReferences, wantCrossRefs) {
+		t.Errorf("expected cross-references %+v, got %+v", wantCrossRefs, passage.CrossReferences)
+	}
+
+	// The notes are still stripped from the text
+	for _, s := range []string{"Footnotes", "Rom. 5:8", "[a]", "(A)"} {
+		if strings.Contains(passage.Text, s) {
+			t.Errorf("expected %q to be removed from the text, got %s", s, passage.Text)
+		}
+	}
+	if !strings.Contains(passage.Text, "For God so loved the world") {
+		t.Errorf("expected the verse text, got %s", passage.Text)
+	}
+}
+
+func TestGetPassage_NotesNotRequested(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		html, err := os.ReadFile("testdata/get_verse_notes.html")
+		if err != nil {
+			t.Fatalf("failed to read mock html file: %v", err)
+		}
+		fmt.Fprintln(w, string(html))
+	}))
+	defer server.Close()
+
+	scraper := &Scraper{client: server.Client(), baseURL: server.URL}
+
+	passage, err := scraper.GetPassage("John", "3", "16-17", "ESV", bible.VerseOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if passage.Footnotes != nil || passage.CrossReferences != nil {
+		t.Errorf("expected no notes, got %+v and %+v", passage.Footnotes, passage.CrossReferences)
+	}
+}
+
+func TestGetPassage_CrossChapterNotes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var html string
+		switch r.URL.Query().Get("search") {
+		case "John 3":
+			html = `<div class="passage-text">
+				<p class="verse"><span><sup class="versenum">35</sup>Verse 35<sup class="footnote" data-fn="#fen-ESV-1a">[<a>a</a>]</sup></span></p>
+				<p class="verse"><span><sup class="versenum">36</sup>Verse 36<sup class="footnote" data-fn="#fen-ESV-2b">[<a>b</a>]</sup></span></p>
+				<div class="footnotes"><ol>
+					<li id="fen-ESV-1a"><a>John 3:35</a> <span class="footnote-text">Note 35</span></li>
+					<li id="fen-ESV-2b"><a>John 3:36</a> <span class="footnote-text">Note 36</span></li>
+				</ol></div>
+			</div>`
+		case "John 4":
+			html = `<div class="passage-text">
+				<p class="verse"><span><sup class="versenum">1</sup>Verse 1<sup class="footnote" data-fn="#fen-ESV-3c">[<a>c</a>]</sup></span></p>
+				<p class="verse"><span><sup class="versenum">2</sup>Verse 2<sup class="footnote" data-fn="#fen-ESV-4d">[<a>d</a>]</sup></span></p>
+				<div class="footnotes"><ol>
+					<li id="fen-ESV-3c"><a>John 4:1</a> <span class="footnote-text">Note 1</span></li>
+					<li id="fen-ESV-4d"><a>John 4:2</a> <span class="footnote-text">Note 2</span></li>
+				</ol></div>
+			</div>`
+		}
+		fmt.Fprintln(w, html)
+	}))
+	defer server.Close()
+
+	scraper := &Scraper{client: server.Client(), baseURL: server.URL}
+
+	passage, err := scraper.GetPassage("John", "3", "36-4:1", "ESV", bible.VerseOptions{Footnotes: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []bible.Footnote{
+		{Verse: "John 3:36", Marker: "b", Text: "Note 36"},
+		{Verse: "John 4:1", Marker: "c", Text: "Note 1"},
+	}
+	if !reflect.DeepEqual(passage.Footnotes, want) {
+		t.Errorf("expected footnotes %+v, got %+v", want, passage.Footnotes)
+	}
+	if passage.Text != "<sup>36 </sup>Verse 36\n<sup>1 </sup>Verse 1" {
+		t.Errorf("unexpected text %q", passage.Text)
+	}
+}
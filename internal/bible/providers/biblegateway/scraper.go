@@ -2,7 +2,7 @@ package biblegateway
 
 import (
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -10,6 +10,8 @@ import (
 	"sync"
 
 	"bible-api-service/internal/bible"
+	"bible-api-service/internal/bible/providers/httpx"
+	"bible-api-service/internal/bible/providers/markup"
 	"bible-api-service/internal/util"
 
 	"github.com/PuerkitoBio/goquery"
@@ -76,6 +78,8 @@ func strictSanitize(s *goquery.Selection) {
 }
 
 func removeUnwantedElements(s *goquery.Selection) {
+	// The chapter number stands in for the number of verse 1
+	s.Find(".chapternum").ReplaceWithHtml(`<sup class="versenum">1 </sup>`)
 	s.Find(".footnote, .footnotes, .chapternum, .crossreference, .crossrefs, .publisher-info-bottom, .dropdown-version-switcher, .passage-scroller, .full-chap-link, .other-translations").Remove()
 	s.Find("sup:not(.versenum)").Remove()
 	s.Find("a").FilterFunction(func(i int, sel *goquery.Selection) bool {
@@ -83,28 +87,50 @@ func removeUnwantedElements(s *goquery.Selection) {
 	}).Remove()
 }
 
-func unwrapSmallCaps(s *goquery.Selection) {
-	s.Find(".small-caps").Each(func(i int, sel *goquery.Selection) {
-		sel.ReplaceWithHtml(sel.Text())
-	})
-}
-
-func unwrapWoj(s *goquery.Selection) {
-	s.Find(".woj").Each(func(i int, sel *goquery.Selection) {
-		sel.ReplaceWithHtml(sel.Text())
-	})
+// markSemantics maps Bible Gateway's classes into the passage text
+// vocabulary. Words of Jesus and Selah already use the vocabulary's names.
+func markSemantics(s *goquery.Selection) {
+	s.Find(".small-caps").AddClass(bible.ClassDivineName)
+	// Each child span of a poetry paragraph is a line; indented lines keep
+	// their indent-N class and lose the spaces that render the indent
+	s.Find("p.line").ChildrenFiltered("span").AddClass(bible.ClassLine)
+	s.Find("span[class*='-breaks']").Remove()
 }
 
-func removeAllAttributes(s *goquery.Selection) {
+// keepSemanticClasses removes every attribute but the vocabulary's classes,
+// which only spans carry.
+func keepSemanticClasses(s *goquery.Selection) {
 	s.Find("*").Each(func(_ int, sel *goquery.Selection) {
+		var classes []string
+		for _, class := range strings.Fields(sel.AttrOr("class", "")) {
+			if sel.Is("span") && bible.IsSemanticClass(class) {
+				classes = append(classes, class)
+			}
+		}
 		sel.Get(0).Attr = []html.Attribute{}
+		if len(classes) > 0 {
+			sel.SetAttr("class", strings.Join(classes, " "))
+		}
 	})
 }
 
+// hasSemanticClass reports whether sel carries one of the vocabulary's classes.
+func hasSemanticClass(sel *goquery.Selection) bool {
+	for _, class := range strings.Fields(sel.AttrOr("class", "")) {
+		if bible.IsSemanticClass(class) {
+			return true
+		}
+	}
+	return false
+}
+
 func unwrapRedundantSpans(s *goquery.Selection) {
 	for {
 		unwrapped := false
 		s.Find("span").Each(func(i int, sel *goquery.Selection) {
+			if hasSemanticClass(sel) {
+				return
+			}
 			isRedundant := true
 			sel.Contents().EachWithBreak(func(j int, content *goquery.Selection) bool {
 				if goquery.NodeName(content) == "#text" {
@@ -149,13 +175,59 @@ type Scraper struct {
 // NewScraper creates a new Scraper with a default HTTP client and base URL.
 func NewScraper() *Scraper {
 	return &Scraper{
-		client:  &http.Client{},
+		client:  httpx.NewClient(),
 		baseURL: "https://classic.biblegateway.com",
 	}
 }
 
+// passageURL returns the printable passage page for a reference.
+func (s *Scraper) passageURL(reference, version string) (string, error) {
+	params := url.Values{}
+	params.Add("search", reference)
+	params.Add("version", version)
+	params.Add("interface", "print")
+
+	parsedURL, err := url.Parse(s.baseURL)
+	if err != nil {
+		return "", err
+	}
+	parsedURL.Path = "/passage/"
+	parsedURL.RawQuery = params.Encode()
+	return parsedURL.String(), nil
+}
+
+// isNoResults reports whether doc is the page Bible Gateway serves for a
+// reference it has no text for. The message may come without a passage, so it
+// is recognised before the passage is required.
+func isNoResults(doc *goquery.Selection) bool {
+	return strings.Contains(doc.Find("body").Text(), "No results found")
+}
+
+// searchURL returns the printable quick search page for a query.
+func (s *Scraper) searchURL(query, version string) (string, error) {
+	params := url.Values{}
+	params.Add("quicksearch", query)
+	params.Add("version", version)
+	params.Add("interface", "print")
+
+	parsedURL, err := url.Parse(s.baseURL)
+	if err != nil {
+		return "", err
+	}
+	parsedURL.Path = "/quicksearch/"
+	parsedURL.RawQuery = params.Encode()
+	return parsedURL.String(), nil
+}
+
 // GetVerse fetches a single Bible verse by reference and returns it as sanitized HTML.
 func (s *Scraper) GetVerse(book, chapter, verse, version string) (string, error) {
+	passage, err := s.GetPassage(book, chapter, verse, version, bible.VerseOptions{})
+	return passage.Text, err
+}
+
+// GetPassage fetches a passage like GetVerse and, as selected by opts, the
+// footnotes and cross-references Bible Gateway prints below it.
+func (s *Scraper) GetPassage(book, chapter, verse, version string, opts bible.VerseOptions) (bible.Passage, error) {
 	// Parse verse range
 	startVerse := 1
 	endVerse := 999
@@ -163,13 +235,13 @@ func (s *Scraper) GetVerse(book, chapter, verse, version string) (string, error)
 
 	startChapterVal, err := strconv.Atoi(chapter)
 	if err != nil {
-		return "", fmt.Errorf("invalid chapter format: %v", err)
+		return bible.Passage{}, fmt.Errorf("%w: invalid chapter format: %v", bible.ErrInvalidReference, err)
 	}
 
 	if verse != "" {
 		parsed, err := util.ParseVerseRange(verse)
 		if err != nil {
-			return "", fmt.Errorf("invalid verse range: %v", err)
+			return bible.Passage{}, fmt.Errorf("%w: invalid verse range: %v", bible.ErrInvalidReference, err)
 		}
 		startVerse = parsed.StartVerse
 		endVerse = parsed.EndVerse
@@ -185,10 +257,10 @@ func (s *Scraper) GetVerse(book, chapter, verse, version string) (string, error)
 	// If cross-chapter range, iterate through chapters
 	if startChapterVal != endChapter {
 		if endChapter < startChapterVal {
-			return "", nil
+			return bible.Passage{}, nil
 		}
 		numChapters := endChapter - startChapterVal + 1
-		chapterTexts := make([]string, numChapters)
+		chapterPassages := make([]bible.Passage, numChapters)
 		errChan := make(chan error, numChapters)
 		var wg sync.WaitGroup
 
@@ -207,12 +279,12 @@ func (s *Scraper) GetVerse(book, chapter, verse, version string) (string, error)
 					currentEndV = endVerse
 				}
 
-				text, err := s.getVersesFromChapter(book, strconv.Itoa(currentChap), currentStartV, currentEndV, version)
+				passage, err := s.getVersesFromChapter(book, strconv.Itoa(currentChap), currentStartV, currentEndV, version, opts)
 				if err != nil {
-					errChan <- fmt.Errorf("failed to fetch chapter %d: %v", currentChap, err)
+					errChan <- fmt.Errorf("failed to fetch chapter %d: %w", currentChap, err)
 					return
 				}
-				chapterTexts[i] = text
+				chapterPassages[i] = passage
 			}(i)
 		}
 
@@ -220,17 +292,21 @@ func (s *Scraper) GetVerse(book, chapter, verse, version string) (string, error)
 		close(errChan)
 
 		if len(errChan) > 0 {
-			return "", <-errChan
+			return bible.Passage{}, <-errChan
 		}
 
 		var allTextBuilder strings.Builder
-		for _, text := range chapterTexts {
-			if allTextBuilder.Len() > 0 && text != "" {
+		var result bible.Passage
+		for _, passage := range chapterPassages {
+			if allTextBuilder.Len() > 0 && passage.Text != "" {
 				allTextBuilder.WriteString("\n")
 			}
-			allTextBuilder.WriteString(text)
+			allTextBuilder.WriteString(passage.Text)
+			result.Footnotes = append(result.Footnotes, passage.Footnotes...)
+			result.CrossReferences = append(result.CrossReferences, passage.CrossReferences...)
 		}
-		return strings.TrimSpace(allTextBuilder.String()), nil
+		result.Text = strings.TrimSpace(allTextBuilder.String())
+		return result, nil
 	}
 
 	// Single chapter range (including whole chapter)
@@ -239,86 +315,111 @@ func (s *Scraper) GetVerse(book, chapter, verse, version string) (string, error)
 	if verse == "" {
 		reference = fmt.Sprintf("%s %s", book, chapter)
 	}
-	params := url.Values{}
-	params.Add("search", reference)
-	params.Add("version", version)
-	params.Add("interface", "print")
-
-	parsedURL, err := url.Parse(s.baseURL)
+	fullURL, err := s.passageURL(reference, version)
 	if err != nil {
-		return "", err
+		return bible.Passage{}, err
 	}
-	parsedURL.Path = "/passage/"
-	parsedURL.RawQuery = params.Encode()
-	fullURL := parsedURL.String()
 
 	req, err := http.NewRequest("GET", fullURL, nil)
 	if err != nil {
-		return "", err
+		return bible.Passage{}, err
 	}
 
 	res, err := s.client.Do(req)
 	if err != nil {
-		return "", err
+		return bible.Passage{}, fmt.Errorf("%w: %w", bible.ErrUpstreamUnavailable, err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode != 200 {
-		return "", fmt.Errorf("failed to fetch verse, status code: %d", res.StatusCode)
+		return bible.Passage{}, fmt.Errorf("failed to fetch verse: %w", bible.NewStatusError(providerName, res))
 	}
 
 	doc, err := goquery.NewDocumentFromReader(res.Body)
 	if err != nil {
-		return "", err
+		return bible.Passage{}, err
 	}
 
-	passageSelection := doc.Find(".passage-text")
-	if passageSelection.Length() == 0 || strings.Contains(passageSelection.Text(), "No results found") {
-		return "", fmt.Errorf("verse not found")
+	if isNoResults(doc.Selection) {
+		return bible.Passage{}, fmt.Errorf("verse %w", bible.ErrNotFound)
 	}
+	if err := markup.Require(doc.Selection, providerName, fullURL, passageSelector); err != nil {
+		return bible.Passage{}, err
+	}
+	passageSelection := doc.Find(passageSelector.Query)
 
-	return sanitizeSelection(passageSelection)
+	// Notes are parsed before sanitizing, which removes them and their markers
+	var passage bible.Passage
+	if opts.Footnotes {
+		passage.Footnotes = parseFootnotes(doc.Selection)
+	}
+	if opts.CrossReferences {
+		passage.CrossReferences = parseCrossReferences(doc.Selection)
+	}
+	passage.Text, err = sanitizeSelection(passageSelection)
+	if err != nil {
+		return bible.Passage{}, err
+	}
+	return passage, nil
 }
 
-func (s *Scraper) getVersesFromChapter(book, chapter string, startVerse, endVerse int, version string) (string, error) {
+func (s *Scraper) getVersesFromChapter(book, chapter string, startVerse, endVerse int, version string, opts bible.VerseOptions) (bible.Passage, error) {
 	// Fetch whole chapter
-	reference := fmt.Sprintf("%s %s", book, chapter)
-	params := url.Values{}
-	params.Add("search", reference)
-	params.Add("version", version)
-	params.Add("interface", "print")
-
-	parsedURL, err := url.Parse(s.baseURL)
+	fullURL, err := s.passageURL(fmt.Sprintf("%s %s", book, chapter), version)
 	if err != nil {
-		return "", err
+		return bible.Passage{}, err
 	}
-	parsedURL.Path = "/passage/"
-	parsedURL.RawQuery = params.Encode()
-	fullURL := parsedURL.String()
 
 	req, err := http.NewRequest("GET", fullURL, nil)
 	if err != nil {
-		return "", err
+		return bible.Passage{}, err
 	}
 
 	res, err := s.client.Do(req)
 	if err != nil {
-		return "", err
+		return bible.Passage{}, fmt.Errorf("%w: %w", bible.ErrUpstreamUnavailable, err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode != 200 {
-		return "", fmt.Errorf("failed to fetch chapter, status code: %d", res.StatusCode)
+		return bible.Passage{}, fmt.Errorf("failed to fetch chapter: %w", bible.NewStatusError(providerName, res))
 	}
 
 	doc, err := goquery.NewDocumentFromReader(res.Body)
 	if err != nil {
-		return "", err
+		return bible.Passage{}, err
+	}
+
+	if isNoResults(doc.Selection) {
+		return bible.Passage{}, fmt.Errorf("chapter %w", bible.ErrNotFound)
+	}
+	if err := markup.Require(doc.Selection, providerName, fullURL, passageSelector); err != nil {
+		return bible.Passage{}, err
+	}
+	passageSelection := doc.Find(passageSelector.Query)
+	if err := markup.Require(passageSelection, providerName, fullURL, verseNumSelector); err != nil {
+		return bible.Passage{}, err
 	}
 
-	passageSelection := doc.Find(".passage-text")
-	if passageSelection.Length() == 0 || strings.Contains(passageSelection.Text(), "No results found") {
-		return "", fmt.Errorf("chapter not found")
+	// Notes are parsed before their markers are removed below
+	inRange := func(ref string) bool {
+		v := noteVerseNumber(ref)
+		return v >= startVerse && v <= endVerse
+	}
+	var passage bible.Passage
+	if opts.Footnotes {
+		for _, note := range parseFootnotes(doc.Selection) {
+			if inRange(note.Verse) {
+				passage.Footnotes = append(passage.Footnotes, note)
+			}
+		}
+	}
+	if opts.CrossReferences {
+		for _, ref := range parseCrossReferences(doc.Selection) {
+			if inRange(ref.Verse) {
+				passage.CrossReferences = append(passage.CrossReferences, ref)
+			}
+		}
 	}
 
 	// Extract verses within range
@@ -334,17 +435,17 @@ func (s *Scraper) getVersesFromChapter(book, chapter string, startVerse, endVers
 				// Remove the chapter number span itself
 				chapSel.Remove()
 				// Get text
-				verseText := strings.TrimSpace(verseContainer.Text())
+				verseText := strings.TrimSpace(vocabulary.HTML(verseContainer.Contents()))
 				if textBuilder.Len() > 0 {
 					textBuilder.WriteString(" ")
 				}
-				textBuilder.WriteString(verseText)
+				textBuilder.WriteString("<sup>1 </sup>" + verseText)
 			}
 			// Only process first chapternum (should be only one)
 			return
 		})
 	}
-	passageSelection.Find("sup.versenum").Each(func(i int, supSel *goquery.Selection) {
+	passageSelection.Find(verseNumSelector.Query).Each(func(i int, supSel *goquery.Selection) {
 		verseNumText := strings.TrimSpace(supSel.Text())
 		verseNumText = strings.TrimRight(verseNumText, "\u00a0")
 		verseNumText = strings.TrimSpace(verseNumText)
@@ -366,31 +467,30 @@ func (s *Scraper) getVersesFromChapter(book, chapter string, startVerse, endVers
 			// Remove the verse number superscript
 			supSel.Remove()
 			// Get text excluding the superscript verse number
-			verseText := strings.TrimSpace(verseContainer.Text())
+			verseText := strings.TrimSpace(vocabulary.HTML(verseContainer.Contents()))
 			if textBuilder.Len() > 0 {
 				textBuilder.WriteString(" ")
 			}
-			textBuilder.WriteString(verseText)
+			fmt.Fprintf(&textBuilder, "<sup>%d </sup>%s", verseNum, verseText)
 		}
 	})
 
-	result := strings.TrimSpace(textBuilder.String())
-	if result == "" {
-		return "", fmt.Errorf("no verses found in range %d-%d", startVerse, endVerse)
+	passage.Text = strings.TrimSpace(textBuilder.String())
+	if passage.Text == "" {
+		return bible.Passage{}, fmt.Errorf("verses %d-%d %w", startVerse, endVerse, bible.ErrNotFound)
 	}
-	return result, nil
+	return passage, nil
 }
 
 func sanitizeSelection(s *goquery.Selection) (string, error) {
 	isPoetry := s.Find("div.poetry").Length() > 0
 
 	removeUnwantedElements(s)
-	unwrapSmallCaps(s)
-	unwrapWoj(s)
+	markSemantics(s)
 
 	if isPoetry {
 		s.Find("p.top-1").ReplaceWithHtml("<br/>")
-		s.Find("div.poetry, p.line, span.indent-1").Each(func(i int, sel *goquery.Selection) {
+		s.Find("div.poetry, p.line").Each(func(i int, sel *goquery.Selection) {
 			html, _ := sel.Html()
 			sel.ReplaceWithHtml(html)
 		})
@@ -398,7 +498,7 @@ func sanitizeSelection(s *goquery.Selection) (string, error) {
 
 	strictSanitize(s)
 	unwrapRedundantSpans(s)
-	removeAllAttributes(s)
+	keepSemanticClasses(s)
 	removeEmptyParagraphs(s)
 
 	html, err := s.Html()
@@ -419,20 +519,10 @@ func sanitizeSnippet(s *goquery.Selection) (string, error) {
 
 // SearchWords searches for a word or phrase and returns a list of relevant verses.
 func (s *Scraper) SearchWords(query, version string) ([]bible.SearchResult, error) {
-	params := url.Values{}
-	params.Add("quicksearch", query)
-	params.Add("version", version)
-	params.Add("interface", "print")
-
-	parsedURL, err := url.Parse(s.baseURL)
+	fullURL, err := s.searchURL(query, version)
 	if err != nil {
 		return nil, err
 	}
-	parsedURL.Path = "/quicksearch/"
-	parsedURL.RawQuery = params.Encode()
-	fullURL := parsedURL.String()
-
-	log.Printf("Scraping URL: %s", fullURL)
 
 	req, err := http.NewRequest("GET", fullURL, nil)
 	if err != nil {
@@ -441,13 +531,13 @@ func (s *Scraper) SearchWords(query, version string) ([]bible.SearchResult, erro
 
 	res, err := s.client.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %w", bible.ErrUpstreamUnavailable, err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode != 200 {
-		log.Printf("Failed to search, status code: %d", res.StatusCode)
-		return nil, fmt.Errorf("failed to search, status code: %d", res.StatusCode)
+		slog.Warn("Search request failed", "provider", "biblegateway", "status", res.StatusCode)
+		return nil, fmt.Errorf("failed to search: %w", bible.NewStatusError(providerName, res))
 	}
 
 	doc, err := goquery.NewDocumentFromReader(res.Body)
@@ -455,12 +545,22 @@ func (s *Scraper) SearchWords(query, version string) ([]bible.SearchResult, erro
 		return nil, err
 	}
 
+	// The list is present, if empty, when nothing matches
+	if err := markup.Require(doc.Selection, providerName, fullURL, searchResultsSelector); err != nil {
+		return nil, err
+	}
+
 	results := []bible.SearchResult{}
-	selection := doc.Find(".search-result-list .bible-item")
-	log.Printf("Found %d search results for query '%s'", selection.Length(), query)
+	selection := doc.Find(searchItemSelector.Query)
+	slog.Debug("Parsed search results", "provider", "biblegateway", "results", selection.Length())
+	if selection.Length() > 0 {
+		if err := markup.Require(selection, providerName, fullURL, searchTitleSelector); err != nil {
+			return nil, err
+		}
+	}
 
 	selection.Each(func(i int, sel *goquery.Selection) {
-		titleLink := sel.Find(".bible-item-title")
+		titleLink := sel.Find(searchTitleSelector.Query)
 		verse := titleLink.Text()
 		url, _ := titleLink.Attr("href")
 
@@ -1,6 +1,7 @@
 package biblegateway
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -52,7 +53,7 @@ func TestGetVerse(t *testing.T) {
 			verse:    "5-6",
 			version:  "ESV",
 			htmlFile: "testdata/get_verse_proverbs.html",
-			expected: `<p><span><sup>5 </sup>Trust in the Lord with all your heart,</span><br/><span>and do not lean on your own understanding.</span></p> <p><span><sup>6 </sup>In all your ways acknowledge him,</span><br/><span>and he will make straight your paths.</span></p>`,
+			expected: `<p><span class="line"><sup>5 </sup>Trust in the Lord with all your heart,</span><br/><span class="line">and do not lean on your own understanding.</span></p> <p><span class="line"><sup>6 </sup>In all your ways acknowledge him,</span><br/><span class="line">and he will make straight your paths.</span></p>`,
 		},
 		{
 			name:     "Bug Reproduction (John 3:16 with extras)",
@@ -70,7 +71,16 @@ func TestGetVerse(t *testing.T) {
 			verse:    "",
 			version:  "NIV",
 			htmlFile: "testdata/get_verse_psalm_121.html",
-			expected: `<h3>My Help Comes from the Lord</h3> <h4>A Song of Ascents.</h4> <p><span><sup>1 </sup>I lift up my eyes to the hills.</span><br/><span>From where does my help come?</span></p> <p><span><sup>2 </sup>My help comes from the Lord,</span><br/><span>who made heaven and earth.</span></p> <br/> <p><span><sup>3 </sup>He will not let your foot be moved;</span><br/><span>he who keeps you will not slumber.</span></p> <p><span><sup>4 </sup>Behold, he who keeps Israel</span><br/><span>will neither slumber nor sleep.</span></p> <br/> <p><span><sup>5 </sup>The Lord is your keeper;</span><br/><span>the Lord is your shade on your right hand.</span></p> <p><span><sup>6 </sup>The sun shall not strike you by day,</span><br/><span>nor the moon by night.</span></p> <br/> <p><span><sup>7 </sup>The Lord will keep you from all evil;</span><br/><span>he will keep your life.</span></p> <p><span><sup>8 </sup>The Lord will keep</span><br/><span>your going out and your coming in</span><br/><span>from this time forth and forevermore.</span></p>`,
+			expected: `<h3>My Help Comes from the Lord</h3> <h4>A Song of Ascents.</h4> <p><span class="line"><sup>1 </sup>I lift up my eyes to the hills.</span><br/><span class="line">From where does my help come?</span></p> <p><span class="line"><sup>2 </sup>My help comes from the Lord,</span><br/><span class="line">who made heaven and earth.</span></p> <br/> <p><span class="line"><sup>3 </sup>He will not let your foot be moved;</span><br/><span class="line">he who keeps you will not slumber.</span></p> <p><span class="line"><sup>4 </sup>Behold, he who keeps Israel</span><br/><span class="line">will neither slumber nor sleep.</span></p> <br/> <p><span class="line"><sup>5 </sup>The Lord is your keeper;</span><br/><span class="line">the Lord is your shade on your right hand.</span></p> <p><span class="line"><sup>6 </sup>The sun shall not strike you by day,</span><br/><span class="line">nor the moon by night.</span></p> <br/> <p><span class="line"><sup>7 </sup>The Lord will keep you from all evil;</span><br/><span class="line">he will keep your life.</span></p> <p><span class="line"><sup>8 </sup>The Lord will keep</span><br/><span class="line">your going out and your coming in</span><br/><span class="line">from this time forth and forevermore.</span></p>`,
+		},
+		{
+			name:     "semantic markup",
+			book:     "Psalm",
+			chapter:  "3",
+			verse:    "1-2",
+			version:  "ESV",
+			htmlFile: "testdata/get_verse_semantic.html",
+			expected: `<h3><span>Save Me, O My God</span></h3> <h4><span>A Psalm of David, when he fled from Absalom his son.</span></h4> <p><span class="line"><sup>1 </sup>O <span class="divine-name">Lord</span>, how many are my foes!</span><br/><span class="indent-1 line"><span>Many are rising against me;</span></span><br/><span class="line"><sup>2 </sup>many are saying of my soul,</span><br/><span class="indent-1 line"><span>“There is no salvation for him in God.” <span class="selah">Selah</span></span></span></p> <p><span><sup>3 </sup><span class="woj">“Blessed are the poor in spirit, for theirs is the kingdom of heaven.</span></span></p>`,
 		},
 		{
 			name:       "verse not found",
@@ -81,6 +91,24 @@ func TestGetVerse(t *testing.T) {
 			htmlFile:   "testdata/get_verse_not_found.html",
 			expectFail: true,
 		},
+		{
+			name:       "no results without passage",
+			book:       "Invalid",
+			chapter:    "1",
+			verse:      "1",
+			version:    "ESV",
+			htmlFile:   "testdata/get_verse_no_passage.html",
+			expectFail: true,
+		},
+		{
+			name:       "no results across chapters",
+			book:       "Invalid",
+			chapter:    "1",
+			verse:      "1-2:1",
+			version:    "ESV",
+			htmlFile:   "testdata/get_verse_no_passage.html",
+			expectFail: true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -98,8 +126,8 @@ func TestGetVerse(t *testing.T) {
 
 			verse, err := scraper.GetVerse(tc.book, tc.chapter, tc.verse, tc.version)
 			if tc.expectFail {
-				if err == nil {
-					t.Fatal("expected an error, but got nil")
+				if !errors.Is(err, bible.ErrNotFound) || errors.Is(err, bible.ErrMarkupChanged) {
+					t.Fatalf("expected a not found error, got %v", err)
 				}
 				return
 			}
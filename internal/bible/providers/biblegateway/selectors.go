@@ -0,0 +1,54 @@
+package biblegateway
+
+import (
+	"bible-api-service/internal/bible"
+	"bible-api-service/internal/bible/providers/markup"
+)
+
+const providerName = "biblegateway"
+
+// Selectors the scraper cannot work without. When one of them matches nothing
+// on a page that was served successfully, the scraper returns a
+// *bible.MarkupError instead of "not found".
+var (
+	passageSelector        = bible.Selector{Name: "passage", Query: ".passage-text"}
+	verseNumSelector       = bible.Selector{Name: "verse number", Query: "sup.versenum"}
+	searchResultsSelector  = bible.Selector{Name: "search results", Query: ".search-result-list"}
+	searchItemSelector     = bible.Selector{Name: "search result", Query: ".search-result-list .bible-item"}
+	searchTitleSelector    = bible.Selector{Name: "search result title", Query: ".bible-item-title"}
+	versionOptionsSelector = bible.Selector{Name: "version options", Query: "select.search-dropdown[name='version'] option"}
+)
+
+// Selectors for topical and dictionary resources. Entries without references
+// are looked up as not found.
+var (
+	resourceContentSelector = bible.Selector{Name: "resource content", Query: ".resource-content"}
+	topicRefSelector        = bible.Selector{Name: "topic reference", Query: "a.bibleref"}
+)
+
+// Selectors for the notes below a passage. Many passages have none, so they
+// are not required.
+var (
+	footnoteSelector = bible.Selector{Name: "footnote", Query: ".footnotes li[id^='fen-']"}
+	crossRefSelector = bible.Selector{Name: "cross-reference", Query: ".crossrefs li[id^='cen-']"}
+)
+
+// vocabulary maps the classes of verse text into the passage text
+// vocabulary. Full passages are mapped in place by markSemantics.
+var vocabulary = markup.Vocabulary{Classes: map[string]string{
+	"woj":        bible.ClassWordsOfJesus,
+	"small-caps": bible.ClassDivineName,
+	"selah":      bible.ClassSelah,
+}}
+
+// CanaryChecks returns pages with known content that exercise every selector.
+func (s *Scraper) CanaryChecks() []bible.CanaryCheck {
+	passage, _ := s.passageURL("John 3", "ESV")
+	search, _ := s.searchURL("love", "ESV")
+	return []bible.CanaryCheck{
+		{Name: "chapter John 3", URL: passage, Selectors: []bible.Selector{passageSelector, verseNumSelector}},
+		{Name: "search love", URL: search, Selectors: []bible.Selector{searchResultsSelector, searchItemSelector, searchTitleSelector}},
+		{Name: "versions", URL: s.baseURL + "/versions/", Selectors: []bible.Selector{versionOptionsSelector}},
+		{Name: "topic Forgiveness", URL: s.topicURL("Forgiveness"), Selectors: []bible.Selector{resourceContentSelector, topicRefSelector}},
+	}
+}
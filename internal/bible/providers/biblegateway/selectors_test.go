@@ -0,0 +1,71 @@
+package biblegateway
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"bible-api-service/internal/bible"
+)
+
+// redesignedPage is served in place of every page, as after a site redesign.
+const redesignedPage = `<html><body><main class="reader"><p>For God so loved the world</p></main></body></html>`
+
+func TestScraper_MarkupChanged(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, redesignedPage)
+	}))
+	defer server.Close()
+
+	scraper := &Scraper{client: server.Client(), baseURL: server.URL}
+
+	tests := []struct {
+		name     string
+		call     func() error
+		selector string
+	}{
+		{"GetVerse", func() error { _, err := scraper.GetVerse("John", "3", "16", "ESV"); return err }, "passage"},
+		{"GetVerse cross-chapter", func() error { _, err := scraper.GetVerse("John", "3", "16-4:2", "ESV"); return err }, "passage"},
+		{"SearchWords", func() error { _, err := scraper.SearchWords("love", "ESV"); return err }, "search results"},
+		{"GetVersions", func() error { _, err := scraper.GetVersions(); return err }, "version options"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			var markupErr *bible.MarkupError
+			if !errors.As(err, &markupErr) || !errors.Is(err, bible.ErrMarkupChanged) {
+				t.Fatalf("expected a markup error, got %v", err)
+			}
+			if markupErr.Selector.Name != tt.selector {
+				t.Errorf("expected selector %q, got %q", tt.selector, markupErr.Selector.Name)
+			}
+		})
+	}
+}
+
+func TestScraper_MarkupChanged_VerseNumbers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<div class="passage-text"><p><span class="v">16</span>For God so loved the world</p></div>`)
+	}))
+	defer server.Close()
+
+	scraper := &Scraper{client: server.Client(), baseURL: server.URL}
+	_, err := scraper.GetVerse("John", "3", "16-4:2", "ESV")
+	var markupErr *bible.MarkupError
+	if !errors.As(err, &markupErr) || markupErr.Selector != verseNumSelector {
+		t.Errorf("expected the verse number selector to break, got %v", err)
+	}
+}
+
+func TestScraper_CanaryChecks(t *testing.T) {
+	scraper := &Scraper{baseURL: "https://example.com"}
+	for _, check := range scraper.CanaryChecks() {
+		if !strings.HasPrefix(check.URL, "https://example.com/") || len(check.Selectors) == 0 {
+			t.Errorf("invalid canary check %+v", check)
+		}
+	}
+}
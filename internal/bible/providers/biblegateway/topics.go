@@ -0,0 +1,120 @@
+package biblegateway
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"unicode"
+
+	"bible-api-service/internal/bible"
+	"bible-api-service/internal/bible/providers/markup"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// topicSlug returns the name of a topic in resource URLs, e.g. "Holy-Spirit".
+func topicSlug(topic string) string {
+	words := strings.Fields(topic)
+	for i, word := range words {
+		r := []rune(strings.ToLower(word))
+		r[0] = unicode.ToUpper(r[0])
+		words[i] = url.PathEscape(string(r))
+	}
+	return strings.Join(words, "-")
+}
+
+// topicURL returns the entry of a topic in Nave's Topical Bible.
+func (s *Scraper) topicURL(topic string) string {
+	return fmt.Sprintf("%s/resources/naves-topical-bible/%s", s.baseURL, topicSlug(topic))
+}
+
+// dictionaryURL returns the entry of a topic in the Encyclopedia of the Bible.
+func (s *Scraper) dictionaryURL(topic string) string {
+	return fmt.Sprintf("%s/resources/encyclopedia-of-the-bible/%s", s.baseURL, topicSlug(topic))
+}
+
+// fetchResource fetches a resource page and returns its content.
+func (s *Scraper) fetchResource(pageURL string) (*goquery.Selection, error) {
+	req, err := http.NewRequest("GET", pageURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", bible.ErrUpstreamUnavailable, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != 200 {
+		return nil, bible.NewStatusError(providerName, res)
+	}
+	doc, err := goquery.NewDocumentFromReader(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if err := markup.Require(doc.Selection, providerName, pageURL, resourceContentSelector); err != nil {
+		return nil, err
+	}
+	return doc.Find(resourceContentSelector.Query).First(), nil
+}
+
+// LookupTopic looks a topic up in Nave's Topical Bible. Verses listed under
+// several headings of the entry rank first; the rest keep the entry's order.
+// The definition comes from the Encyclopedia of the Bible, if it has the topic.
+func (s *Scraper) LookupTopic(topic string) (bible.Topic, error) {
+	content, err := s.fetchResource(s.topicURL(topic))
+	if err != nil {
+		return bible.Topic{}, fmt.Errorf("failed to look up topic %s: %w", topic, err)
+	}
+
+	var verses []bible.TopicVerse
+	counts := make(map[string]int)
+	content.Find(topicRefSelector.Query).Each(func(i int, link *goquery.Selection) {
+		ref := bible.NormalizeReference(strings.TrimRight(link.Text(), ".,;"))
+		if ref == "" {
+			return
+		}
+		if counts[ref] == 0 {
+			verses = append(verses, bible.TopicVerse{Reference: ref, Note: topicNote(link)})
+		}
+		counts[ref]++
+	})
+	if len(verses) == 0 {
+		return bible.Topic{}, fmt.Errorf("topic %s: %w", topic, bible.ErrNotFound)
+	}
+	sort.SliceStable(verses, func(i, j int) bool {
+		return counts[verses[i].Reference] > counts[verses[j].Reference]
+	})
+	for i := range verses {
+		verses[i].Rank = i + 1
+	}
+
+	t := bible.Topic{Topic: topic, Source: bible.TopicSourceIndex, Verses: verses}
+	definition, err := s.fetchResource(s.dictionaryURL(topic))
+	switch {
+	case err == nil:
+		t.Definition = strings.Join(strings.Fields(definition.Find("p").First().Text()), " ")
+	case !errors.Is(err, bible.ErrNotFound):
+		return bible.Topic{}, fmt.Errorf("failed to look up definition of %s: %w", topic, err)
+	}
+	return t, nil
+}
+
+// topicNote returns the heading a reference is listed under: the text of its
+// item before the first reference, e.g. "Of injuries" in
+// <li>Of injuries: <a class="bibleref">Gen 50:17-21</a></li>.
+func topicNote(link *goquery.Selection) string {
+	var note strings.Builder
+	item := link.Closest("li, p")
+	item.Contents().EachWithBreak(func(i int, node *goquery.Selection) bool {
+		if node.Is(topicRefSelector.Query) || node.Find(topicRefSelector.Query).Length() > 0 {
+			return false
+		}
+		note.WriteString(node.Text())
+		return true
+	})
+	return strings.TrimRight(strings.Join(strings.Fields(note.String()), " "), " :;,.-")
+}
@@ -0,0 +1,88 @@
+package biblegateway
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+
+	"bible-api-service/internal/bible"
+)
+
+func TestLookupTopic(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/resources/naves-topical-bible/Forgiveness":
+			html, err := os.ReadFile("testdata/topic_forgiveness.html")
+			if err != nil {
+				t.Fatalf("failed to read mock html file: %v", err)
+			}
+			fmt.Fprintln(w, string(html))
+		case "/resources/encyclopedia-of-the-bible/Forgiveness":
+			fmt.Fprint(w, `<div class="resource-content"><h2>FORGIVENESS</h2><p>The pardon of an offense,
+the remission of a debt.</p><p>In the OT...</p></div>`)
+		case "/resources/naves-topical-bible/Holy-Spirit":
+			fmt.Fprint(w, `<div class="resource-content"><ul><li><a class="bibleref">John 14:26</a></li></ul></div>`)
+		case "/resources/naves-topical-bible/Empty":
+			fmt.Fprint(w, `<div class="resource-content"><p>See <a href="/resources/naves-topical-bible/Sin">Sin</a>.</p></div>`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	scraper := &Scraper{client: server.Client(), baseURL: server.URL}
+
+	topic, err := scraper.LookupTopic("forgiveness")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := bible.Topic{
+		Topic:      "forgiveness",
+		Definition: "The pardon of an offense, the remission of a debt.",
+		Source:     bible.TopicSourceIndex,
+		Verses: []bible.TopicVerse{
+			{Reference: "Matthew 6:14", Note: "Of injuries", Rank: 1},
+			{Reference: "Genesis 50:17-21", Note: "Of injuries", Rank: 2},
+			{Reference: "Ephesians 4:32", Note: "Of injuries", Rank: 3},
+			{Reference: "Luke 6:37", Note: "Of enemies", Rank: 4},
+			{Reference: "Colossians 3:13", Note: "Commanded", Rank: 5},
+		},
+	}
+	if !reflect.DeepEqual(topic, want) {
+		t.Errorf("expected %+v, got %+v", want, topic)
+	}
+
+	// Topics without a dictionary entry have no definition
+	topic, err = scraper.LookupTopic("holy  spirit")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if topic.Definition != "" || len(topic.Verses) != 1 || topic.Verses[0].Reference != "John 14:26" {
+		t.Errorf("unexpected topic %+v", topic)
+	}
+
+	for _, name := range []string{"Unknown", "Empty"} {
+		if _, err := scraper.LookupTopic(name); !errors.Is(err, bible.ErrNotFound) {
+			t.Errorf("LookupTopic(%s): expected ErrNotFound, got %v", name, err)
+		}
+	}
+}
+
+func TestLookupTopic_MarkupChanged(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<html><body><div class="article"><a class="bibleref">Matt 6:14</a></div></body></html>`)
+	}))
+	defer server.Close()
+
+	scraper := &Scraper{client: server.Client(), baseURL: server.URL}
+
+	var markupErr *bible.MarkupError
+	_, err := scraper.LookupTopic("forgiveness")
+	if !errors.As(err, &markupErr) || markupErr.Selector != resourceContentSelector {
+		t.Errorf("expected a markup error for the resource content, got %v", err)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"bible-api-service/internal/bible"
+	"bible-api-service/internal/bible/providers/markup"
 
 	"github.com/PuerkitoBio/goquery"
 )
@@ -20,12 +21,12 @@ func (s *Scraper) GetVersions() ([]bible.ProviderVersion, error) {
 
 	res, err := s.client.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %w", bible.ErrUpstreamUnavailable, err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("failed to fetch versions, status code: %d", res.StatusCode)
+		return nil, fmt.Errorf("failed to fetch versions: %w", bible.NewStatusError(providerName, res))
 	}
 
 	doc, err := goquery.NewDocumentFromReader(res.Body)
@@ -33,6 +34,10 @@ func (s *Scraper) GetVersions() ([]bible.ProviderVersion, error) {
 		return nil, err
 	}
 
+	if err := markup.Require(doc.Selection, providerName, url, versionOptionsSelector); err != nil {
+		return nil, err
+	}
+
 	var versions []bible.ProviderVersion
 	// Find the select element with class "search-dropdown" and name "version"
 	sel := doc.Find("select.search-dropdown[name='version']")
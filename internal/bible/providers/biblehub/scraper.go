@@ -8,6 +8,8 @@ import (
 	"strings"
 
 	"bible-api-service/internal/bible"
+	"bible-api-service/internal/bible/providers/httpx"
+	"bible-api-service/internal/bible/providers/markup"
 	"bible-api-service/internal/util"
 
 	"github.com/PuerkitoBio/goquery"
@@ -22,19 +24,57 @@ type Scraper struct {
 // NewScraper creates a new Scraper.
 func NewScraper() *Scraper {
 	return &Scraper{
-		client:  &http.Client{},
+		client:  httpx.NewClient(),
 		baseURL: "https://biblehub.com",
 	}
 }
 
-// GetVerse fetches a verse or range of verses from BibleHub.
+// chapterURL returns the page of a chapter in a version.
+func (s *Scraper) chapterURL(version, bookSlug string, chapter int) string {
+	return fmt.Sprintf("%s/%s/%s/%d.htm", s.baseURL, version, bookSlug, chapter)
+}
+
+// bookSlug returns the name of a book in BibleHub URLs, e.g. "1_samuel".
+// BibleHub has no deuterocanonical books, so asking for one is
+// ErrUnsupportedVersion, as with VersionManager.CheckBook.
+func bookSlug(book string) (string, error) {
+	b, ok := bible.LookupBook(book)
+	if !ok {
+		return strings.ToLower(strings.ReplaceAll(book, " ", "_")), nil
+	}
+	if b.Canon != bible.CanonProtestant {
+		return "", fmt.Errorf("%w: %s is not on BibleHub", bible.ErrUnsupportedVersion, b.Name)
+	}
+	if b.USFM == "SNG" {
+		return "songs", nil
+	}
+	return strings.ToLower(strings.ReplaceAll(b.Name, " ", "_")), nil
+}
+
+// searchURL returns the search page for a query.
+func (s *Scraper) searchURL(query string) string {
+	return fmt.Sprintf("%s/search.php?q=%s", s.baseURL, url.QueryEscape(query))
+}
+
 // GetVerse fetches a verse or range of verses from BibleHub.
 func (s *Scraper) GetVerse(book, chapter, verse, version string) (string, error) {
+	return s.getVerse(book, chapter, verse, version, false)
+}
+
+// GetNumberedVerse is GetVerse with each verse preceded by its number.
+func (s *Scraper) GetNumberedVerse(book, chapter, verse, version string) (string, error) {
+	return s.getVerse(book, chapter, verse, version, true)
+}
+
+func (s *Scraper) getVerse(book, chapter, verse, version string, numbered bool) (string, error) {
 	if version == "" {
 		version = "esv"
 	}
 	version = strings.ToLower(version)
-	bookSlug := strings.ToLower(strings.ReplaceAll(book, " ", "_"))
+	bookSlug, err := bookSlug(book)
+	if err != nil {
+		return "", err
+	}
 
 	// Default to full chapter if verse is empty
 	startVerse := 1
@@ -42,13 +82,13 @@ func (s *Scraper) GetVerse(book, chapter, verse, version string) (string, error)
 	endChapter := 0
 	startChapterVal, err := strconv.Atoi(chapter)
 	if err != nil {
-		return "", fmt.Errorf("invalid chapter format: %v", err)
+		return "", fmt.Errorf("%w: invalid chapter format: %v", bible.ErrInvalidReference, err)
 	}
 
 	if verse != "" {
 		parsed, err := util.ParseVerseRange(verse)
 		if err != nil {
-			return "", fmt.Errorf("invalid verse range: %v", err)
+			return "", fmt.Errorf("%w: invalid verse range: %v", bible.ErrInvalidReference, err)
 		}
 		startVerse = parsed.StartVerse
 		endVerse = parsed.EndVerse
@@ -75,22 +115,21 @@ func (s *Scraper) GetVerse(book, chapter, verse, version string) (string, error)
 			currentEndV = endVerse
 		}
 
-		url := fmt.Sprintf("%s/%s/%s/%d.htm", s.baseURL, version, bookSlug, currentChap)
+		url := s.chapterURL(version, bookSlug, currentChap)
 
 		req, err := http.NewRequest("GET", url, nil)
 		if err != nil {
 			return "", err
 		}
-		req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; BibleAIAPI/1.0)")
 
 		res, err := s.client.Do(req)
 		if err != nil {
-			return "", fmt.Errorf("failed to fetch chapter %d: %v", currentChap, err)
+			return "", fmt.Errorf("failed to fetch chapter %d: %w: %w", currentChap, bible.ErrUpstreamUnavailable, err)
 		}
 		defer res.Body.Close()
 
 		if res.StatusCode != 200 {
-			return "", fmt.Errorf("failed to fetch chapter %d, status code: %d", currentChap, res.StatusCode)
+			return "", fmt.Errorf("failed to fetch chapter %d: %w", currentChap, bible.NewStatusError(providerName, res))
 		}
 
 		doc, err := goquery.NewDocumentFromReader(res.Body)
@@ -98,10 +137,14 @@ func (s *Scraper) GetVerse(book, chapter, verse, version string) (string, error)
 			return "", err
 		}
 
+		if err := markup.Require(doc.Selection, providerName, url, paragraphSelector, verseNumSelector); err != nil {
+			return "", err
+		}
+
 		var chapterTextBuilder strings.Builder
 		var inRange bool
 
-		doc.Find("p.regular, p.text").Each(func(i int, s *goquery.Selection) {
+		doc.Find(paragraphSelector.Query).Each(func(i int, s *goquery.Selection) {
 			s.Contents().Each(func(j int, node *goquery.Selection) {
 				if node.HasClass("reftext") {
 					vNumStr := strings.TrimSpace(node.Text())
@@ -111,6 +154,9 @@ func (s *Scraper) GetVerse(book, chapter, verse, version string) (string, error)
 					if err == nil {
 						if vNum >= currentStartV && vNum <= currentEndV {
 							inRange = true
+							if numbered {
+								fmt.Fprintf(&chapterTextBuilder, "<sup>%d </sup>", vNum)
+							}
 						} else {
 							inRange = false
 						}
@@ -122,15 +168,8 @@ func (s *Scraper) GetVerse(book, chapter, verse, version string) (string, error)
 						return
 					}
 
-					// Handle text nodes and other elements (like .woc)
-					text := ""
-					if goquery.NodeName(node) == "#text" {
-						text = node.Text()
-					} else {
-						text = node.Text()
-					}
-
-					chapterTextBuilder.WriteString(text)
+					// Text nodes and other elements (like .woc)
+					chapterTextBuilder.WriteString(vocabulary.HTML(node))
 				}
 			})
 		})
@@ -141,7 +180,11 @@ func (s *Scraper) GetVerse(book, chapter, verse, version string) (string, error)
 		allTextBuilder.WriteString(strings.TrimSpace(chapterTextBuilder.String()))
 	}
 
-	return strings.TrimSpace(allTextBuilder.String()), nil
+	finalResult := strings.TrimSpace(allTextBuilder.String())
+	if finalResult == "" {
+		return "", fmt.Errorf("verses %w", bible.ErrNotFound)
+	}
+	return finalResult, nil
 }
 
 // SearchWords searches for a word or phrase and returns a list of relevant verses.
@@ -150,22 +193,21 @@ func (s *Scraper) SearchWords(query, version string) ([]bible.SearchResult, erro
 		version = "esv"
 	}
 
-	searchURL := fmt.Sprintf("%s/search.php?q=%s", s.baseURL, url.QueryEscape(query))
+	searchURL := s.searchURL(query)
 
 	req, err := http.NewRequest("GET", searchURL, nil)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; BibleAIAPI/1.0)")
 
 	res, err := s.client.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %w", bible.ErrUpstreamUnavailable, err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("failed to search, status code: %d", res.StatusCode)
+		return nil, fmt.Errorf("failed to search: %w", bible.NewStatusError(providerName, res))
 	}
 
 	doc, err := goquery.NewDocumentFromReader(res.Body)
@@ -173,10 +215,19 @@ func (s *Scraper) SearchWords(query, version string) ([]bible.SearchResult, erro
 		return nil, err
 	}
 
+	// An empty result list is a valid answer, so only the titles of the
+	// results found are required; the canary checks the result blocks.
+	blocks := doc.Find(resultSelector.Query)
+	if blocks.Length() > 0 {
+		if err := markup.Require(blocks, providerName, searchURL, resultTitleSelector); err != nil {
+			return nil, err
+		}
+	}
+
 	var results []bible.SearchResult
 
-	doc.Find(".result_block, .result_altblock").Each(func(i int, sel *goquery.Selection) {
-		titleSel := sel.Find(".result_title a")
+	blocks.Each(func(i int, sel *goquery.Selection) {
+		titleSel := sel.Find(resultTitleSelector.Query)
 		verseRef := titleSel.Text()
 		verseURL := titleSel.AttrOr("href", "")
 
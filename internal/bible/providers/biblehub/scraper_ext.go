@@ -1,6 +1,13 @@
 package biblehub
 
+import "net/http"
+
 // SetBaseURL sets the base URL for the scraper. This is useful for testing.
 func (s *Scraper) SetBaseURL(url string) {
 	s.baseURL = url
 }
+
+// SetHTTPClient replaces the HTTP client, e.g. with one that replays recorded responses.
+func (s *Scraper) SetHTTPClient(client *http.Client) {
+	s.client = client
+}
@@ -6,6 +6,8 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"bible-api-service/internal/bible"
+
 	"github.com/stretchr/testify/assert"
 )
 
@@ -108,3 +110,33 @@ func TestSearchWords(t *testing.T) {
 
 	assert.Equal(t, "1 John 4:8", results[1].Verse)
 }
+
+func TestGetVerse_SemanticMarkup(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `<html><body><p class="regular"><span class="reftext"><a href="..."><b>3</b></a></span><span class="woc">“Blessed are the poor in spirit, for theirs is the kingdom of heaven.</span> <span class="reftext"><a href="..."><b>4</b></a></span><span class="woc">“Blessed are those who mourn, for they shall be comforted.</span></p></body></html>`)
+	}))
+	defer ts.Close()
+
+	scraper := NewScraper()
+	scraper.baseURL = ts.URL
+	scraper.client = ts.Client()
+
+	verse, err := scraper.GetVerse("Matthew", "5", "3", "esv")
+	assert.NoError(t, err)
+	assert.Equal(t, `<span class="woj">“Blessed are the poor in spirit, for theirs is the kingdom of heaven.</span>`, verse)
+
+	verse, err = scraper.GetNumberedVerse("Matthew", "5", "3", "esv")
+	assert.NoError(t, err)
+	assert.Equal(t, `<sup>3 </sup><span class="woj">“Blessed are the poor in spirit, for theirs is the kingdom of heaven.</span>`, verse)
+}
+
+func TestBookSlug(t *testing.T) {
+	for book, want := range map[string]string{"John": "john", "1 Sam": "1_samuel", "Song of Songs": "songs", "Psalm": "psalms"} {
+		slug, err := bookSlug(book)
+		assert.NoError(t, err)
+		assert.Equal(t, want, slug, book)
+	}
+
+	_, err := bookSlug("Sirach")
+	assert.ErrorIs(t, err, bible.ErrUnsupportedVersion)
+}
@@ -0,0 +1,40 @@
+package biblehub
+
+import (
+	"bible-api-service/internal/bible"
+	"bible-api-service/internal/bible/providers/markup"
+)
+
+const providerName = "biblehub"
+
+// Selectors the scraper cannot work without. When one of them matches nothing
+// on a page that was served successfully, the scraper returns a
+// *bible.MarkupError instead of an empty result.
+var (
+	paragraphSelector   = bible.Selector{Name: "verse paragraph", Query: "p.regular, p.text"}
+	verseNumSelector    = bible.Selector{Name: "verse number", Query: ".reftext"}
+	resultSelector      = bible.Selector{Name: "search result", Query: ".result_block, .result_altblock"}
+	resultTitleSelector = bible.Selector{Name: "search result title", Query: ".result_title a"}
+	versionLinkSelector = bible.Selector{Name: "version link", Query: "a[href$='/genesis/1.htm']"}
+
+	interlinearVerseSelector = bible.Selector{Name: "interlinear verse number", Query: ".reftop"}
+	interlinearWordSelector  = bible.Selector{Name: "interlinear word", Query: "table.tablefloat, table.tablefloatheb"}
+	lexiconHeadingSelector   = bible.Selector{Name: "lexicon heading", Query: ".tophdg"}
+)
+
+// vocabulary maps the classes of verse text into the passage text vocabulary.
+var vocabulary = markup.Vocabulary{Classes: map[string]string{
+	"woc":   bible.ClassWordsOfJesus,
+	"selah": bible.ClassSelah,
+}}
+
+// CanaryChecks returns pages with known content that exercise every selector.
+func (s *Scraper) CanaryChecks() []bible.CanaryCheck {
+	return []bible.CanaryCheck{
+		{Name: "chapter John 3", URL: s.chapterURL("esv", "john", 3), Selectors: []bible.Selector{paragraphSelector, verseNumSelector}},
+		{Name: "search love", URL: s.searchURL("love"), Selectors: []bible.Selector{resultSelector, resultTitleSelector}},
+		{Name: "versions", URL: s.baseURL + "/genesis/1-1.htm", Selectors: []bible.Selector{versionLinkSelector}},
+		{Name: "interlinear John 3", URL: s.interlinearURL("john", 3), Selectors: []bible.Selector{interlinearVerseSelector, interlinearWordSelector}},
+		{Name: "lexicon G25", URL: s.lexiconURL("G25"), Selectors: []bible.Selector{lexiconHeadingSelector}},
+	}
+}
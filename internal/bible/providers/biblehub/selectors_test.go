@@ -0,0 +1,73 @@
+package biblehub
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"bible-api-service/internal/bible"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScraper_MarkupChanged(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/search.php":
+			// Result blocks whose titles moved elsewhere
+			fmt.Fprint(w, `<div class="result_block"><h3><a href="/john/3-16.htm">John 3:16</a></h3></div>`)
+		default:
+			fmt.Fprint(w, `<html><body><div class="chap"><b>16</b>For God so loved the world</div></body></html>`)
+		}
+	}))
+	defer ts.Close()
+
+	scraper := NewScraper()
+	scraper.baseURL = ts.URL
+	scraper.client = ts.Client()
+
+	var markupErr *bible.MarkupError
+
+	_, err := scraper.GetVerse("John", "3", "16", "esv")
+	assert.True(t, errors.Is(err, bible.ErrMarkupChanged))
+	if assert.True(t, errors.As(err, &markupErr)) {
+		assert.Equal(t, paragraphSelector, markupErr.Selector)
+		assert.Equal(t, ts.URL+"/esv/john/3.htm", markupErr.URL)
+	}
+
+	_, err = scraper.SearchWords("love", "esv")
+	if assert.True(t, errors.As(err, &markupErr)) {
+		assert.Equal(t, resultTitleSelector, markupErr.Selector)
+	}
+
+	_, err = scraper.GetVersions()
+	if assert.True(t, errors.As(err, &markupErr)) {
+		assert.Equal(t, versionLinkSelector, markupErr.Selector)
+	}
+}
+
+func TestScraper_SearchWords_NoResults(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<html><body><p>No results</p></body></html>`)
+	}))
+	defer ts.Close()
+
+	scraper := NewScraper()
+	scraper.baseURL = ts.URL
+	scraper.client = ts.Client()
+
+	results, err := scraper.SearchWords("qwertyuiop", "esv")
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+}
+
+func TestScraper_CanaryChecks(t *testing.T) {
+	scraper := NewScraper()
+	for _, check := range scraper.CanaryChecks() {
+		assert.True(t, strings.HasPrefix(check.URL, "https://biblehub.com/"), check.URL)
+		assert.NotEmpty(t, check.Selectors, check.Name)
+	}
+}
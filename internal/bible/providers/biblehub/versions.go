@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"bible-api-service/internal/bible"
+	"bible-api-service/internal/bible/providers/markup"
 
 	"github.com/PuerkitoBio/goquery"
 )
@@ -20,16 +21,15 @@ func (s *Scraper) GetVersions() ([]bible.ProviderVersion, error) {
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; BibleAIAPI/1.0)")
 
 	res, err := s.client.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %w", bible.ErrUpstreamUnavailable, err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("failed to fetch versions, status code: %d", res.StatusCode)
+		return nil, fmt.Errorf("failed to fetch versions: %w", bible.NewStatusError(providerName, res))
 	}
 
 	doc, err := goquery.NewDocumentFromReader(res.Body)
@@ -37,6 +37,10 @@ func (s *Scraper) GetVersions() ([]bible.ProviderVersion, error) {
 		return nil, err
 	}
 
+	if err := markup.Require(doc.Selection, providerName, url, versionLinkSelector); err != nil {
+		return nil, err
+	}
+
 	var versions []bible.ProviderVersion
 	seen := make(map[string]bool)
 
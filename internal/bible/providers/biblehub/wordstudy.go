@@ -0,0 +1,196 @@
+package biblehub
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"bible-api-service/internal/bible"
+	"bible-api-service/internal/bible/providers/markup"
+	"bible-api-service/internal/util"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// interlinearURL returns the interlinear page of a chapter.
+func (s *Scraper) interlinearURL(bookSlug string, chapter int) string {
+	return fmt.Sprintf("%s/interlinear/%s/%d.htm", s.baseURL, bookSlug, chapter)
+}
+
+// lexiconURL returns the Strong's lexicon page of a normalized Strong's
+// number, e.g. /greek/25.htm for G25.
+func (s *Scraper) lexiconURL(strongs string) string {
+	language := "greek"
+	if strings.HasPrefix(strongs, "H") {
+		language = "hebrew"
+	}
+	return fmt.Sprintf("%s/%s/%s.htm", s.baseURL, language, strongs[1:])
+}
+
+// fetch fetches a page, failing on markup without the selectors.
+func (s *Scraper) fetch(pageURL string, selectors ...bible.Selector) (*goquery.Document, error) {
+	req, err := http.NewRequest("GET", pageURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", bible.ErrUpstreamUnavailable, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != 200 {
+		return nil, bible.NewStatusError(providerName, res)
+	}
+	doc, err := goquery.NewDocumentFromReader(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if err := markup.Require(doc.Selection, providerName, pageURL, selectors...); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// GetInterlinear fetches the original text of a verse or range of verses,
+// word by word, from BibleHub's interlinear pages.
+func (s *Scraper) GetInterlinear(book, chapter, verse string) ([]bible.InterlinearVerse, error) {
+	bookSlug, err := bookSlug(book)
+	if err != nil {
+		return nil, err
+	}
+	bookName := book
+	if b, ok := bible.LookupBook(book); ok {
+		bookName = b.Name
+	}
+
+	startChapter, err := strconv.Atoi(chapter)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid chapter format: %v", bible.ErrInvalidReference, err)
+	}
+	startVerse, endVerse, endChapter := 1, 999, startChapter
+	if verse != "" {
+		parsed, err := util.ParseVerseRange(verse)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid verse range: %v", bible.ErrInvalidReference, err)
+		}
+		startVerse, endVerse = parsed.StartVerse, parsed.EndVerse
+		if parsed.IsCrossChapter {
+			endChapter = parsed.EndChapter
+		}
+	}
+
+	var verses []bible.InterlinearVerse
+	for currentChapter := startChapter; currentChapter <= endChapter; currentChapter++ {
+		first, last := 1, 999
+		if currentChapter == startChapter {
+			first = startVerse
+		}
+		if currentChapter == endChapter {
+			last = endVerse
+		}
+
+		doc, err := s.fetch(s.interlinearURL(bookSlug, currentChapter), interlinearVerseSelector, interlinearWordSelector)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch interlinear chapter %d: %w", currentChapter, err)
+		}
+
+		// Verse numbers and words come in reading order
+		var current *bible.InterlinearVerse
+		doc.Find(interlinearVerseSelector.Query + ", " + interlinearWordSelector.Query).Each(func(i int, sel *goquery.Selection) {
+			if sel.Is(interlinearVerseSelector.Query) {
+				current = nil
+				n, err := strconv.Atoi(strings.TrimRight(strings.TrimSpace(sel.Text()), "."))
+				if err != nil || n < first || n > last {
+					return
+				}
+				verses = append(verses, bible.InterlinearVerse{
+					Reference: fmt.Sprintf("%s %d:%d", bookName, currentChapter, n),
+					Words:     []bible.InterlinearWord{},
+				})
+				current = &verses[len(verses)-1]
+				return
+			}
+			if current != nil {
+				current.Words = append(current.Words, interlinearWord(sel))
+			}
+		})
+	}
+
+	if len(verses) == 0 {
+		return nil, fmt.Errorf("interlinear verses %w", bible.ErrNotFound)
+	}
+	return verses, nil
+}
+
+// interlinearWord reads a word from its table on an interlinear page.
+func interlinearWord(sel *goquery.Selection) bible.InterlinearWord {
+	word := bible.InterlinearWord{
+		Original:        strings.TrimSpace(sel.Find(".greek, .hebrew").First().Text()),
+		Transliteration: strings.TrimSpace(sel.Find(".translit").First().Text()),
+		Parsing:         strings.TrimSpace(sel.Find(".strongsnt, .strongs").First().Text()),
+		Gloss:           strings.TrimSpace(sel.Find(".eng").First().Text()),
+	}
+	// The number links to the lexicon, /greek/3779.htm or /hebrew/7225.htm
+	link := sel.Find(".pos a").First()
+	prefix := "G"
+	if strings.HasPrefix(link.AttrOr("href", ""), "/hebrew/") || sel.Is(".tablefloatheb") {
+		prefix = "H"
+	}
+	if strongs, err := bible.ParseStrongs(prefix + strings.TrimSpace(link.Text())); err == nil {
+		word.Strongs = strongs
+	}
+	return word
+}
+
+// GetLexiconEntry fetches the Strong's lexicon entry of a Greek or Hebrew word.
+func (s *Scraper) GetLexiconEntry(strongs string) (bible.LexiconEntry, error) {
+	strongs, err := bible.ParseStrongs(strongs)
+	if err != nil {
+		return bible.LexiconEntry{}, err
+	}
+	doc, err := s.fetch(s.lexiconURL(strongs), lexiconHeadingSelector)
+	if err != nil {
+		return bible.LexiconEntry{}, fmt.Errorf("failed to fetch lexicon entry %s: %w", strongs, err)
+	}
+
+	// Each field is a heading followed by its value up to the next line break,
+	// e.g. <span class="tophdg">Definition: </span>to love<br>
+	fields := make(map[string]string)
+	doc.Find(lexiconHeadingSelector.Query).Parent().Each(func(i int, parent *goquery.Selection) {
+		var label string
+		var value strings.Builder
+		flush := func() {
+			if label != "" {
+				if _, seen := fields[label]; !seen {
+					fields[label] = strings.Join(strings.Fields(value.String()), " ")
+				}
+			}
+			label = ""
+			value.Reset()
+		}
+		parent.Contents().Each(func(j int, node *goquery.Selection) {
+			switch {
+			case node.Is(lexiconHeadingSelector.Query):
+				flush()
+				label = strings.TrimSuffix(strings.TrimSpace(node.Text()), ":")
+			case node.Is("br"):
+				flush()
+			case label != "":
+				value.WriteString(node.Text())
+			}
+		})
+		flush()
+	})
+
+	return bible.LexiconEntry{
+		Strongs:         strongs,
+		Lemma:           fields["Original Word"],
+		Transliteration: fields["Transliteration"],
+		Pronunciation:   fields["Phonetic Spelling"],
+		PartOfSpeech:    fields["Part of Speech"],
+		Definition:      fields["Definition"],
+		Usage:           fields["Usage"],
+	}, nil
+}
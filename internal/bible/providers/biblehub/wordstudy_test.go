@@ -0,0 +1,130 @@
+package biblehub
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"bible-api-service/internal/bible"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetInterlinear(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/interlinear/john/3.htm":
+			fmt.Fprint(w, `<html><body>
+<span class="reftop3"><a href="/john/3-16.htm"><span class="reftop">16</span></a></span>
+<table class="tablefloat"><tr><td><span class="pos"><a href="/greek/3779.htm">3779</a> [e]</span><br><span class="translit">Houtōs</span><br><span class="greek">Οὕτως</span><br><span class="eng">so</span><br><span class="strongsnt"><a href="/grammar/greek.htm#adv">Adv</a></span></td></tr></table>
+<table class="tablefloat"><tr><td><span class="pos"><a href="/greek/1063.htm">1063</a></span><br><span class="translit">gar</span><br><span class="greek">γὰρ</span><br><span class="eng">for</span><br><span class="strongsnt">Conj</span></td></tr></table>
+<span class="reftop3"><a href="/john/3-17.htm"><span class="reftop">17</span></a></span>
+<table class="tablefloat"><tr><td><span class="pos"><a href="/greek/3756.htm">3756</a></span><br><span class="translit">ou</span><br><span class="greek">οὐ</span><br><span class="eng">not</span><br><span class="strongsnt">Adv</span></td></tr></table>
+</body></html>`)
+		case "/interlinear/genesis/1.htm":
+			fmt.Fprint(w, `<html><body>
+<span class="reftop">1</span>
+<table class="tablefloatheb"><tr><td><span class="pos"><a href="/hebrew/7225.htm">7225</a></span><br><span class="translit">bə·rê·šîṯ</span><br><span class="hebrew">בְּרֵאשִׁ֖ית</span><br><span class="eng">In the beginning</span><br><span class="strongs">Prep-b | N-fs</span></td></tr></table>
+</body></html>`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	scraper := NewScraper()
+	scraper.baseURL = ts.URL
+	scraper.client = ts.Client()
+
+	verses, err := scraper.GetInterlinear("John", "3", "16")
+	assert.NoError(t, err)
+	if assert.Len(t, verses, 1) {
+		assert.Equal(t, "John 3:16", verses[0].Reference)
+		assert.Equal(t, []bible.InterlinearWord{
+			{Original: "Οὕτως", Transliteration: "Houtōs", Strongs: "G3779", Parsing: "Adv", Gloss: "so"},
+			{Original: "γὰρ", Transliteration: "gar", Strongs: "G1063", Parsing: "Conj", Gloss: "for"},
+		}, verses[0].Words)
+	}
+
+	verses, err = scraper.GetInterlinear("John", "3", "")
+	assert.NoError(t, err)
+	assert.Len(t, verses, 2)
+
+	verses, err = scraper.GetInterlinear("Gen", "1", "1")
+	assert.NoError(t, err)
+	if assert.Len(t, verses, 1) {
+		assert.Equal(t, "Genesis 1:1", verses[0].Reference)
+		assert.Equal(t, "H7225", verses[0].Words[0].Strongs)
+		assert.Equal(t, "Prep-b | N-fs", verses[0].Words[0].Parsing)
+	}
+
+	_, err = scraper.GetInterlinear("John", "3", "30")
+	assert.ErrorIs(t, err, bible.ErrNotFound)
+
+	_, err = scraper.GetInterlinear("Tobit", "1", "1")
+	assert.ErrorIs(t, err, bible.ErrUnsupportedVersion)
+}
+
+func TestGetLexiconEntry(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/greek/25.htm" {
+			fmt.Fprint(w, `<html><body><div class="vheading">Strong's Concordance</div><div>
+<span class="tophdg">Original Word: </span><span class="greek2">ἀγαπάω</span><br>
+<span class="tophdg">Part of Speech: </span>Verb<br>
+<span class="tophdg">Transliteration: </span>agapaó<br>
+<span class="tophdg">Phonetic Spelling: </span>(ag-ap-ah'-o)<br>
+<span class="tophdg">Definition: </span>to love<br>
+<span class="tophdg">Usage: </span>I love, wish well to,
+take pleasure in, long for.<br>
+</div></body></html>`)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	scraper := NewScraper()
+	scraper.baseURL = ts.URL
+	scraper.client = ts.Client()
+
+	entry, err := scraper.GetLexiconEntry("g0025")
+	assert.NoError(t, err)
+	assert.Equal(t, bible.LexiconEntry{
+		Strongs:         "G25",
+		Lemma:           "ἀγαπάω",
+		Transliteration: "agapaó",
+		Pronunciation:   "(ag-ap-ah'-o)",
+		PartOfSpeech:    "Verb",
+		Definition:      "to love",
+		Usage:           "I love, wish well to, take pleasure in, long for.",
+	}, entry)
+
+	_, err = scraper.GetLexiconEntry("H157")
+	assert.ErrorIs(t, err, bible.ErrNotFound)
+
+	_, err = scraper.GetLexiconEntry("love")
+	assert.ErrorIs(t, err, bible.ErrInvalidReference)
+}
+
+func TestWordStudy_MarkupChanged(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<html><body><p>Οὕτως γὰρ</p></body></html>`)
+	}))
+	defer ts.Close()
+
+	scraper := NewScraper()
+	scraper.baseURL = ts.URL
+	scraper.client = ts.Client()
+
+	var markupErr *bible.MarkupError
+	_, err := scraper.GetInterlinear("John", "3", "16")
+	if assert.True(t, errors.As(err, &markupErr)) {
+		assert.Equal(t, interlinearVerseSelector, markupErr.Selector)
+	}
+	_, err = scraper.GetLexiconEntry("G25")
+	if assert.True(t, errors.As(err, &markupErr)) {
+		assert.Equal(t, lexiconHeadingSelector, markupErr.Selector)
+	}
+}
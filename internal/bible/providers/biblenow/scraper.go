@@ -7,6 +7,8 @@ import (
 	"strings"
 
 	"bible-api-service/internal/bible"
+	"bible-api-service/internal/bible/providers/httpx"
+	"bible-api-service/internal/bible/providers/markup"
 	"bible-api-service/internal/util"
 
 	"github.com/PuerkitoBio/goquery"
@@ -21,7 +23,7 @@ type Scraper struct {
 // NewScraper creates a new Scraper.
 func NewScraper() *Scraper {
 	return &Scraper{
-		client:  &http.Client{},
+		client:  httpx.NewClient(),
 		baseURL: "https://biblenow.net",
 	}
 }
@@ -44,8 +46,84 @@ var standardBookOrder = []string{
 	"3 john", "jude", "revelation",
 }
 
-// GetVerse fetches a verse or range of verses from BibleNow.
-func (s *Scraper) GetVerse(book, chapter, verse, version string) (string, error) {
+// Order of books in Catholic bibles, with the deuterocanonical books
+var catholicBookOrder = append([]string{
+	"genesis", "exodus", "leviticus", "numbers", "deuteronomy",
+	"joshua", "judges", "ruth", "1 samuel", "2 samuel",
+	"1 kings", "2 kings", "1 chronicles", "2 chronicles", "ezra",
+	"nehemiah", "tobit", "judith", "esther", "1 maccabees",
+	"2 maccabees", "job", "psalms", "proverbs", "ecclesiastes",
+	"song of solomon", "wisdom", "sirach", "isaiah", "jeremiah",
+	"lamentations", "baruch", "ezekiel", "daniel", "hosea",
+	"joel", "amos", "obadiah", "jonah", "micah",
+	"nahum", "habakkuk", "zephaniah", "haggai", "zechariah",
+	"malachi",
+}, standardBookOrder[39:]...)
+
+// Order of books in Orthodox bibles, which follow the Septuagint
+var orthodoxBookOrder = append([]string{
+	"genesis", "exodus", "leviticus", "numbers", "deuteronomy",
+	"joshua", "judges", "ruth", "1 samuel", "2 samuel",
+	"1 kings", "2 kings", "1 chronicles", "2 chronicles", "1 esdras",
+	"ezra", "nehemiah", "tobit", "judith", "esther",
+	"1 maccabees", "2 maccabees", "3 maccabees", "psalms", "job",
+	"proverbs", "ecclesiastes", "song of solomon", "wisdom", "sirach",
+	"hosea", "amos", "micah", "joel", "obadiah",
+	"jonah", "nahum", "habakkuk", "zephaniah", "haggai",
+	"zechariah", "malachi", "isaiah", "jeremiah", "baruch",
+	"lamentations", "letter of jeremiah", "ezekiel", "daniel",
+}, standardBookOrder[39:]...)
+
+// Order of books in Protestant bibles printed with the Apocrypha between the
+// testaments, such as the King James Version with Apocrypha
+var apocryphaBookOrder = append([]string{
+	"genesis", "exodus", "leviticus", "numbers", "deuteronomy",
+	"joshua", "judges", "ruth", "1 samuel", "2 samuel",
+	"1 kings", "2 kings", "1 chronicles", "2 chronicles", "ezra",
+	"nehemiah", "esther", "job", "psalms", "proverbs",
+	"ecclesiastes", "song of solomon", "isaiah", "jeremiah",
+	"lamentations", "ezekiel", "daniel", "hosea", "joel",
+	"amos", "obadiah", "jonah", "micah", "nahum",
+	"habakkuk", "zephaniah", "haggai", "zechariah", "malachi",
+	"1 esdras", "2 esdras", "tobit", "judith", "additions to esther",
+	"wisdom", "sirach", "baruch", "prayer of azariah", "susanna",
+	"bel and the dragon", "prayer of manasseh", "1 maccabees", "2 maccabees",
+}, standardBookOrder[39:]...)
+
+// bookOrders are the orders version pages list their books in. They differ
+// in length, so the number of books on a page tells which one it uses.
+var bookOrders = [][]string{standardBookOrder, catholicBookOrder, orthodoxBookOrder, apocryphaBookOrder}
+
+// bookOrder returns the order of a version listing count books. Versions
+// listing another number of books are assumed to follow the Protestant order.
+func bookOrder(count int) []string {
+	for _, order := range bookOrders {
+		if len(order) == count {
+			return order
+		}
+	}
+	return standardBookOrder
+}
+
+// bookIndex returns the position of book in order, or -1. Books are matched
+// by their names in the book registry, so abbreviations work too.
+func bookIndex(order []string, book string) int {
+	normalizedBook := strings.ToLower(strings.TrimSpace(book))
+	if b, ok := bible.LookupBook(book); ok {
+		normalizedBook = strings.ToLower(b.Name)
+	}
+	for i, b := range order {
+		if b == normalizedBook {
+			return i
+		}
+	}
+	return -1
+}
+
+// bookPath returns the path of the page of a book in a version, e.g.
+// /en/bible/king-james-version/old-testament/genesis. Book pages are found
+// among the links of the version page by the version's order of books.
+func (s *Scraper) bookPath(book, version string) (string, error) {
 	if version == "" {
 		version = "KJV"
 	}
@@ -60,17 +138,16 @@ func (s *Scraper) GetVerse(book, chapter, verse, version string) (string, error)
 	// Ensure versionPath does not start with / (relative to baseURL)
 	versionPath = strings.TrimPrefix(versionPath, "/")
 
-	// 1. Identify the book index
-	bookIndex := -1
-	normalizedBook := strings.ToLower(strings.TrimSpace(book))
-	for i, b := range standardBookOrder {
-		if b == normalizedBook {
-			bookIndex = i
+	// 1. Check the book is in one of the canons
+	known := false
+	for _, order := range bookOrders {
+		if bookIndex(order, book) != -1 {
+			known = true
 			break
 		}
 	}
-	if bookIndex == -1 {
-		return "", fmt.Errorf("unknown book: %s", book)
+	if !known {
+		return "", fmt.Errorf("%w: unknown book: %s", bible.ErrInvalidReference, book)
 	}
 
 	// 2. Fetch the version page to find the book URL
@@ -83,16 +160,15 @@ func (s *Scraper) GetVerse(book, chapter, verse, version string) (string, error)
 	if err != nil {
 		return "", err
 	}
-	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; BibleAIAPI/1.0)")
 
 	res, err := s.client.Do(req)
 	if err != nil {
-		return "", err
+		return "", fmt.Errorf("%w: %w", bible.ErrUpstreamUnavailable, err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode != 200 {
-		return "", fmt.Errorf("failed to fetch version page, status code: %d", res.StatusCode)
+		return "", fmt.Errorf("failed to fetch version page: %w", bible.NewStatusError(providerName, res))
 	}
 
 	doc, err := goquery.NewDocumentFromReader(res.Body)
@@ -152,11 +228,32 @@ func (s *Scraper) GetVerse(book, chapter, verse, version string) (string, error)
 		seen[href] = true
 	})
 
-	if bookIndex >= len(bookLinks) {
-		return "", fmt.Errorf("book index %d out of range (found %d books)", bookIndex, len(bookLinks))
+	if len(bookLinks) == 0 {
+		return "", &bible.MarkupError{Provider: providerName, Selector: bookLinkSelector, URL: versionURL}
+	}
+	index := bookIndex(bookOrder(len(bookLinks)), book)
+	if index == -1 || index >= len(bookLinks) {
+		return "", fmt.Errorf("book %w in version (index %d, found %d books)", bible.ErrNotFound, index, len(bookLinks))
 	}
 
-	bookURLPath := bookLinks[bookIndex]
+	return bookLinks[index], nil
+}
+
+// GetVerse fetches a verse or range of verses from BibleNow.
+func (s *Scraper) GetVerse(book, chapter, verse, version string) (string, error) {
+	return s.getVerse(book, chapter, verse, version, false)
+}
+
+// GetNumberedVerse is GetVerse with each verse preceded by its number.
+func (s *Scraper) GetNumberedVerse(book, chapter, verse, version string) (string, error) {
+	return s.getVerse(book, chapter, verse, version, true)
+}
+
+func (s *Scraper) getVerse(book, chapter, verse, version string, numbered bool) (string, error) {
+	bookURLPath, err := s.bookPath(book, version)
+	if err != nil {
+		return "", err
+	}
 
 	// 4. Determine Chapter Range
 	startVerse := 1
@@ -165,13 +262,13 @@ func (s *Scraper) GetVerse(book, chapter, verse, version string) (string, error)
 
 	startChapterVal, err := strconv.Atoi(chapter)
 	if err != nil {
-		return "", fmt.Errorf("invalid chapter format: %v", err)
+		return "", fmt.Errorf("%w: invalid chapter format: %v", bible.ErrInvalidReference, err)
 	}
 
 	if verse != "" {
 		parsed, err := util.ParseVerseRange(verse)
 		if err != nil {
-			return "", fmt.Errorf("invalid verse range: %v", err)
+			return "", fmt.Errorf("%w: invalid verse range: %v", bible.ErrInvalidReference, err)
 		}
 		startVerse = parsed.StartVerse
 		endVerse = parsed.EndVerse
@@ -201,31 +298,34 @@ func (s *Scraper) GetVerse(book, chapter, verse, version string) (string, error)
 		chapterURL := fmt.Sprintf("%s%s/%d", s.baseURL, bookURLPath, currentChap)
 
 		// 5. Fetch Chapter and scrape verses
-		req, err = http.NewRequest("GET", chapterURL, nil)
+		req, err := http.NewRequest("GET", chapterURL, nil)
 		if err != nil {
 			return "", err
 		}
-		req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; BibleAIAPI/1.0)")
 
-		res, err = s.client.Do(req)
+		res, err := s.client.Do(req)
 		if err != nil {
-			return "", fmt.Errorf("failed to fetch chapter %d: %v", currentChap, err)
+			return "", fmt.Errorf("failed to fetch chapter %d: %w: %w", currentChap, bible.ErrUpstreamUnavailable, err)
 		}
 		defer res.Body.Close()
 
 		if res.StatusCode != 200 {
-			return "", fmt.Errorf("failed to fetch chapter %d, status code: %d", currentChap, res.StatusCode)
+			return "", fmt.Errorf("failed to fetch chapter %d: %w", currentChap, bible.NewStatusError(providerName, res))
 		}
 
-		doc, err = goquery.NewDocumentFromReader(res.Body)
+		doc, err := goquery.NewDocumentFromReader(res.Body)
 		if err != nil {
 			return "", err
 		}
 
+		if err := markup.Require(doc.Selection, providerName, chapterURL, verseSelector); err != nil {
+			return "", err
+		}
+
 		var chapterTextBuilder strings.Builder
 
 		// Find all verses in the chapter content
-		doc.Find("div.chapter-content a.list-group-item p.verse").Each(func(i int, sel *goquery.Selection) {
+		doc.Find(verseSelector.Query).Each(func(i int, sel *goquery.Selection) {
 			verseNumSpan := sel.Find("span")
 			verseNumStr := strings.TrimSpace(verseNumSpan.Text())
 			verseNum, err := strconv.Atoi(verseNumStr)
@@ -239,13 +339,16 @@ func (s *Scraper) GetVerse(book, chapter, verse, version string) (string, error)
 					if node.Is("span") {
 						return
 					}
-					verseTextBuilder.WriteString(node.Text())
+					verseTextBuilder.WriteString(vocabulary.HTML(node))
 				})
 
 				text := strings.TrimSpace(verseTextBuilder.String())
 				if chapterTextBuilder.Len() > 0 {
 					chapterTextBuilder.WriteString(" ")
 				}
+				if numbered {
+					fmt.Fprintf(&chapterTextBuilder, "<sup>%d </sup>", verseNum)
+				}
 				chapterTextBuilder.WriteString(text)
 			}
 		})
@@ -267,15 +370,55 @@ func (s *Scraper) GetVerse(book, chapter, verse, version string) (string, error)
 
 	finalResult := strings.TrimSpace(allTextBuilder.String())
 	if finalResult == "" {
-		return "", fmt.Errorf("verses not found")
+		return "", fmt.Errorf("verses %w", bible.ErrNotFound)
 	}
 
 	return finalResult, nil
 }
 
-// SearchWords is not supported on BibleNow.
-func (s *Scraper) SearchWords(query, version string) ([]bible.SearchResult, error) {
-	return nil, fmt.Errorf("search not supported on BibleNow")
+// GetChapterCount returns the number of chapters of a book in a version:
+// the highest chapter the book page links to.
+func (s *Scraper) GetChapterCount(book, version string) (int, error) {
+	bookURLPath, err := s.bookPath(book, version)
+	if err != nil {
+		return 0, err
+	}
+
+	bookURL := s.baseURL + bookURLPath
+	req, err := http.NewRequest("GET", bookURL, nil)
+	if err != nil {
+		return 0, err
+	}
+	res, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", bible.ErrUpstreamUnavailable, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != 200 {
+		return 0, fmt.Errorf("failed to fetch book page: %w", bible.NewStatusError(providerName, res))
+	}
+
+	doc, err := goquery.NewDocumentFromReader(res.Body)
+	if err != nil {
+		return 0, err
+	}
+
+	chapters := 0
+	doc.Find("a").Each(func(i int, sel *goquery.Selection) {
+		href, _ := sel.Attr("href")
+		suffix, ok := strings.CutPrefix(strings.TrimPrefix(href, s.baseURL), bookURLPath+"/")
+		if !ok {
+			return
+		}
+		if n, err := strconv.Atoi(suffix); err == nil && n > chapters {
+			chapters = n
+		}
+	})
+	if chapters == 0 {
+		return 0, &bible.MarkupError{Provider: providerName, Selector: chapterLinkSelector, URL: bookURL}
+	}
+	return chapters, nil
 }
 
 func GetVersionSlug(version string) string {
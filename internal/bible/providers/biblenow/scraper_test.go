@@ -1,11 +1,14 @@
 package biblenow
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+
+	"bible-api-service/internal/bible"
 )
 
 func TestScraper_GetVerse(t *testing.T) {
@@ -79,6 +82,16 @@ func TestScraper_GetVerse(t *testing.T) {
 	if verseRange != expectedRange {
 		t.Errorf("expected '%s', got '%s'", expectedRange, verseRange)
 	}
+
+	// Test case 3: Numbered verse range
+	numbered, err := scraper.GetNumberedVerse("Genesis", "1", "1-2", "KJV")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expectedNumbered := "<sup>1 </sup>In the beginning God created the heaven and the earth. <sup>2 </sup>And the earth was without form, and void; and darkness was upon the face of the deep. And the Spirit of God moved upon the face of the waters."
+	if numbered != expectedNumbered {
+		t.Errorf("expected '%s', got '%s'", expectedNumbered, numbered)
+	}
 }
 
 func TestScraper_GetVerse_Spanish(t *testing.T) {
@@ -138,11 +151,42 @@ func TestScraper_GetVerse_Spanish(t *testing.T) {
 	}
 }
 
-func TestScraper_SearchWords(t *testing.T) {
+func TestScraper_GetChapterCount(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/en/bible/king-james-version":
+			w.Write([]byte(`<html><body>
+	<a href="/en/bible/king-james-version/old-testament/genesis">Genesis</a>
+	<a href="/en/bible/king-james-version/old-testament/exodus">Exodus</a>
+</body></html>`))
+		case "/en/bible/king-james-version/old-testament/exodus":
+			w.Write([]byte(`<html><body>
+	<a href="/en/bible/king-james-version/old-testament/genesis">Genesis</a>
+	<a href="/en/bible/king-james-version/old-testament/exodus/1">1</a>
+	<a href="/en/bible/king-james-version/old-testament/exodus/2">2</a>
+	<a href="/en/bible/king-james-version/old-testament/exodus/40">40</a>
+	<a href="/en/bible/king-james-version/old-testament/exodus/40">Last chapter</a>
+</body></html>`))
+		default:
+			w.Write([]byte(`<html><body><p>Genesis</p></body></html>`))
+		}
+	}))
+	defer server.Close()
+
 	scraper := NewScraper()
-	_, err := scraper.SearchWords("love", "KJV")
-	if err == nil {
-		t.Error("expected error for SearchWords, got nil")
+	scraper.baseURL = server.URL
+
+	count, err := scraper.GetChapterCount("Exodus", "KJV")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if count != 40 {
+		t.Errorf("expected 40 chapters, got %d", count)
+	}
+
+	var markupErr *bible.MarkupError
+	if _, err := scraper.GetChapterCount("Genesis", "KJV"); !errors.As(err, &markupErr) || markupErr.Selector != chapterLinkSelector {
+		t.Errorf("expected a chapter link markup error, got %v", err)
 	}
 }
 
@@ -238,3 +282,65 @@ func TestScraper_GetVerse_WithExtraLinks(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+func TestScraper_GetVerse_CatholicBookOrder(t *testing.T) {
+	mockChapterHTML := `<div class="verse list-group chapter-content"><a href="#" class="list-group-item"><p class="verse"><span>1</span> The book of the words of Tobit.</p></a></div>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/en/bible/douay-rheims" {
+			var links strings.Builder
+			for _, book := range catholicBookOrder {
+				slug := strings.ReplaceAll(book, " ", "-")
+				fmt.Fprintf(&links, `<a href="/en/bible/douay-rheims/testament/%s">%s</a>`, slug, book)
+			}
+			w.Write([]byte("<html><body>" + links.String() + "</body></html>"))
+			return
+		}
+		if r.URL.Path == "/en/bible/douay-rheims/testament/tobit/1" {
+			w.Write([]byte(mockChapterHTML))
+			return
+		}
+		t.Errorf("Unexpected request: %s", r.URL.Path)
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	scraper := NewScraper()
+	scraper.baseURL = server.URL
+
+	verse, err := scraper.GetVerse("Tobit", "1", "1", "en/bible/douay-rheims")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "The book of the words of Tobit."; verse != want {
+		t.Errorf("expected '%s', got '%s'", want, verse)
+	}
+}
+
+func TestBookOrder(t *testing.T) {
+	if got := len(bookOrder(66)); got != 66 {
+		t.Errorf("bookOrder(66) has %d books", got)
+	}
+	if got := bookIndex(bookOrder(73), "Psalms"); got != 22 {
+		t.Errorf("Psalms is book %d of the Catholic order, want 22", got)
+	}
+	if got := bookIndex(bookOrder(76), "Matthew"); got != 49 {
+		t.Errorf("Matthew is book %d of the Orthodox order, want 49", got)
+	}
+	if got := bookIndex(bookOrder(80), "Ecclesiasticus"); got != 45 {
+		t.Errorf("Ecclesiasticus is book %d of the Apocrypha order, want 45", got)
+	}
+	// Unknown canons fall back to the Protestant order
+	if got := bookIndex(bookOrder(27), "Tobit"); got != -1 {
+		t.Errorf("Tobit is book %d of the fallback order, want -1", got)
+	}
+
+	// Every book of every order is in the book registry
+	for _, order := range bookOrders {
+		for _, name := range order {
+			if b, ok := bible.LookupBook(name); !ok || strings.ToLower(b.Name) != name {
+				t.Errorf("book %q is not a registry name", name)
+			}
+		}
+	}
+}
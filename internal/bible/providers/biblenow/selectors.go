@@ -0,0 +1,35 @@
+package biblenow
+
+import (
+	"bible-api-service/internal/bible"
+	"bible-api-service/internal/bible/providers/markup"
+)
+
+const providerName = "biblenow"
+
+// Selectors the scraper cannot work without. When one of them matches nothing
+// on a page that was served successfully, the scraper returns a
+// *bible.MarkupError instead of "not found". Book, chapter and version links are
+// picked out of all links by their path, so their queries only approximate
+// that filter for the canary.
+var (
+	verseSelector       = bible.Selector{Name: "verse", Query: "div.chapter-content a.list-group-item p.verse"}
+	bookLinkSelector    = bible.Selector{Name: "book link", Query: "a[href*='testament']"}
+	chapterLinkSelector = bible.Selector{Name: "chapter link", Query: "a[href*='testament/']"}
+	versionLinkSelector = bible.Selector{Name: "version link", Query: "a[href*='/en/bible/']"}
+)
+
+// vocabulary escapes verse text: BibleNow marks up nothing the passage text
+// vocabulary covers.
+var vocabulary = markup.Vocabulary{}
+
+// CanaryChecks returns pages with known content that exercise every selector.
+func (s *Scraper) CanaryChecks() []bible.CanaryCheck {
+	version := s.baseURL + "/en/bible/" + GetVersionSlug("KJV")
+	return []bible.CanaryCheck{
+		{Name: "book list KJV", URL: version, Selectors: []bible.Selector{bookLinkSelector}},
+		{Name: "book John", URL: version + "/new-testament/john", Selectors: []bible.Selector{chapterLinkSelector}},
+		{Name: "chapter John 3", URL: version + "/new-testament/john/3", Selectors: []bible.Selector{verseSelector}},
+		{Name: "versions", URL: s.baseURL + "/en/bible", Selectors: []bible.Selector{versionLinkSelector}},
+	}
+}
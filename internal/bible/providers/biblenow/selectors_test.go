@@ -0,0 +1,54 @@
+package biblenow
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"bible-api-service/internal/bible"
+)
+
+func TestScraper_MarkupChanged(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/en/bible/king-james-version/no-books":
+			w.Write([]byte(`<html><body><nav><a href="/en/bible">Bibles</a></nav></body></html>`))
+		case "/en/bible/king-james-version":
+			w.Write([]byte(`<html><body><a href="/en/bible/king-james-version/old-testament/genesis">Genesis</a></body></html>`))
+		default:
+			// Chapters and the versions pages lost their markup
+			w.Write([]byte(`<html><body><ol class="verses"><li>In the beginning</li></ol></body></html>`))
+		}
+	}))
+	defer server.Close()
+
+	scraper := NewScraper()
+	scraper.baseURL = server.URL
+
+	tests := []struct {
+		name     string
+		call     func() error
+		selector bible.Selector
+	}{
+		{"book links", func() error {
+			_, err := scraper.GetVerse("Genesis", "1", "1", "en/bible/king-james-version/no-books")
+			return err
+		}, bookLinkSelector},
+		{"verses", func() error { _, err := scraper.GetVerse("Genesis", "1", "1", "KJV"); return err }, verseSelector},
+		{"versions", func() error { _, err := scraper.GetVersions(); return err }, versionLinkSelector},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			var markupErr *bible.MarkupError
+			if !errors.As(err, &markupErr) || !errors.Is(err, bible.ErrMarkupChanged) {
+				t.Fatalf("expected a markup error, got %v", err)
+			}
+			if markupErr.Selector != tt.selector {
+				t.Errorf("expected selector %q, got %q", tt.selector.Name, markupErr.Selector.Name)
+			}
+		})
+	}
+}
@@ -30,16 +30,15 @@ func (s *Scraper) GetVersions() ([]bible.ProviderVersion, error) {
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; BibleAIAPI/1.0)")
 
 	res, err := s.client.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %w", bible.ErrUpstreamUnavailable, err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("failed to fetch English page, status code: %d", res.StatusCode)
+		return nil, fmt.Errorf("failed to fetch English page: %w", bible.NewStatusError(providerName, res))
 	}
 
 	doc, err := goquery.NewDocumentFromReader(res.Body)
@@ -95,6 +94,9 @@ func (s *Scraper) GetVersions() ([]bible.ProviderVersion, error) {
 
 	wg.Wait()
 
+	if len(versions) == 0 {
+		return nil, &bible.MarkupError{Provider: providerName, Selector: versionLinkSelector, URL: url}
+	}
 	return versions, nil
 }
 
@@ -163,16 +165,15 @@ func (s *Scraper) fetchVersionsForLanguage(lang languageInfo) ([]bible.ProviderV
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; BibleAIAPI/1.0)")
 
 	res, err := s.client.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %w", bible.ErrUpstreamUnavailable, err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("status code: %d", res.StatusCode)
+		return nil, bible.NewStatusError(providerName, res)
 	}
 
 	doc, err := goquery.NewDocumentFromReader(res.Body)
@@ -0,0 +1,132 @@
+package httpx
+
+import (
+	"encoding/json"
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultTimeout       = 15 * time.Second
+	defaultMaxRetries    = 2
+	defaultBaseBackoff   = 500 * time.Millisecond
+	defaultMaxBackoff    = 10 * time.Second
+	defaultRatePerSecond = 5
+	defaultBurst         = 5
+	defaultMaxConcurrent = 4
+
+	// DefaultUserAgent identifies the service to the scraped sites.
+	DefaultUserAgent = "Mozilla/5.0 (compatible; BibleAIAPI/1.0)"
+)
+
+// Config tunes the scraper transport. Limits apply to each upstream host separately.
+type Config struct {
+	// Timeout bounds each attempt, including reading the body, for hosts
+	// without an entry in HostTimeouts. Zero uses the default of 15s.
+	Timeout time.Duration
+	// HostTimeouts holds per-host timeouts keyed by host name, e.g. "biblehub.com".
+	HostTimeouts map[string]time.Duration
+	// MaxRetries is the number of retries after a 429, a 5xx or a network error.
+	MaxRetries int
+	// BaseBackoff is the backoff before the first retry; it doubles on each retry,
+	// with full jitter, up to MaxBackoff. A Retry-After longer than MaxBackoff
+	// is not waited for.
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	// RatePerSecond and Burst limit the request rate. A zero rate disables limiting.
+	RatePerSecond float64
+	Burst         int
+	// MaxConcurrent caps the requests in flight. Zero means no cap.
+	MaxConcurrent int
+	// UserAgent is set on requests that do not have one.
+	UserAgent string
+	// ProxyURL routes requests through an outbound proxy. When empty, the
+	// standard HTTP_PROXY and HTTPS_PROXY variables apply.
+	ProxyURL string
+}
+
+// DefaultConfig returns the default transport configuration.
+func DefaultConfig() Config {
+	return Config{
+		Timeout:       defaultTimeout,
+		HostTimeouts:  make(map[string]time.Duration),
+		MaxRetries:    defaultMaxRetries,
+		BaseBackoff:   defaultBaseBackoff,
+		MaxBackoff:    defaultMaxBackoff,
+		RatePerSecond: defaultRatePerSecond,
+		Burst:         defaultBurst,
+		MaxConcurrent: defaultMaxConcurrent,
+		UserAgent:     DefaultUserAgent,
+	}
+}
+
+// LoadConfig reads the transport configuration from the environment:
+// SCRAPER_TIMEOUT (e.g. "20s"), SCRAPER_TIMEOUTS (JSON, e.g. {"biblehub.com":"30s"}),
+// SCRAPER_MAX_RETRIES, SCRAPER_RATE_LIMIT (requests per second per host),
+// SCRAPER_BURST, SCRAPER_MAX_CONCURRENT (per host), SCRAPER_USER_AGENT and
+// SCRAPER_PROXY_URL.
+func LoadConfig() Config {
+	cfg := DefaultConfig()
+	cfg.Timeout = durationEnv("SCRAPER_TIMEOUT", cfg.Timeout)
+	cfg.MaxRetries = intEnv("SCRAPER_MAX_RETRIES", cfg.MaxRetries)
+	cfg.Burst = intEnv("SCRAPER_BURST", cfg.Burst)
+	cfg.MaxConcurrent = intEnv("SCRAPER_MAX_CONCURRENT", cfg.MaxConcurrent)
+	cfg.ProxyURL = os.Getenv("SCRAPER_PROXY_URL")
+	if ua := os.Getenv("SCRAPER_USER_AGENT"); ua != "" {
+		cfg.UserAgent = ua
+	}
+
+	if raw := os.Getenv("SCRAPER_RATE_LIMIT"); raw != "" {
+		rate, err := strconv.ParseFloat(raw, 64)
+		if err != nil || rate < 0 {
+			slog.Warn("Invalid SCRAPER_RATE_LIMIT, using default", "value", raw)
+		} else {
+			cfg.RatePerSecond = rate
+		}
+	}
+
+	if raw := os.Getenv("SCRAPER_TIMEOUTS"); raw != "" {
+		var timeouts map[string]string
+		if err := json.Unmarshal([]byte(raw), &timeouts); err != nil {
+			slog.Warn("Invalid SCRAPER_TIMEOUTS JSON, ignoring", "error", err)
+		}
+		for host, value := range timeouts {
+			d, err := time.ParseDuration(value)
+			if err != nil || d <= 0 {
+				slog.Warn("Invalid timeout in SCRAPER_TIMEOUTS, ignoring", "host", host, "value", value)
+				continue
+			}
+			cfg.HostTimeouts[host] = d
+		}
+	}
+
+	return cfg
+}
+
+func durationEnv(key string, fallback time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		slog.Warn("Invalid duration, using default", "key", key, "value", value, "default", fallback.String())
+		return fallback
+	}
+	return d
+}
+
+func intEnv(key string, fallback int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		slog.Warn("Invalid integer, using default", "key", key, "value", value, "default", fallback)
+		return fallback
+	}
+	return n
+}
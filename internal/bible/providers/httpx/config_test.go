@@ -0,0 +1,43 @@
+package httpx
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Setenv("SCRAPER_TIMEOUT", "20s")
+	t.Setenv("SCRAPER_TIMEOUTS", `{"biblehub.com":"30s","biblenow.net":"bad"}`)
+	t.Setenv("SCRAPER_MAX_RETRIES", "0")
+	t.Setenv("SCRAPER_RATE_LIMIT", "1.5")
+	t.Setenv("SCRAPER_MAX_CONCURRENT", "-1")
+	t.Setenv("SCRAPER_USER_AGENT", "TestAgent/1.0")
+	t.Setenv("SCRAPER_PROXY_URL", "http://proxy.internal:3128")
+
+	cfg := LoadConfig()
+	if cfg.Timeout != 20*time.Second {
+		t.Errorf("Timeout = %v", cfg.Timeout)
+	}
+	if len(cfg.HostTimeouts) != 1 || cfg.HostTimeouts["biblehub.com"] != 30*time.Second {
+		t.Errorf("HostTimeouts = %v", cfg.HostTimeouts)
+	}
+	if cfg.MaxRetries != 0 {
+		t.Errorf("MaxRetries = %d", cfg.MaxRetries)
+	}
+	if cfg.RatePerSecond != 1.5 {
+		t.Errorf("RatePerSecond = %v", cfg.RatePerSecond)
+	}
+	if cfg.MaxConcurrent != defaultMaxConcurrent {
+		t.Errorf("expected invalid SCRAPER_MAX_CONCURRENT to use the default, got %d", cfg.MaxConcurrent)
+	}
+	if cfg.UserAgent != "TestAgent/1.0" || cfg.ProxyURL != "http://proxy.internal:3128" {
+		t.Errorf("UserAgent = %q, ProxyURL = %q", cfg.UserAgent, cfg.ProxyURL)
+	}
+}
+
+func TestLoadConfig_Defaults(t *testing.T) {
+	cfg := LoadConfig()
+	if cfg.Timeout != defaultTimeout || cfg.MaxRetries != defaultMaxRetries || cfg.UserAgent != DefaultUserAgent {
+		t.Errorf("unexpected defaults: %+v", cfg)
+	}
+}
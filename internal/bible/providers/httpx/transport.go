@@ -0,0 +1,262 @@
+// Package httpx provides the HTTP transport shared by the Bible scrapers. It
+// adds per-host timeouts, retries with jittered backoff, rate limiting and
+// concurrency caps, a default User-Agent and an optional outbound proxy.
+package httpx
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Transport is an http.RoundTripper for scraping upstream sites politely.
+type Transport struct {
+	base http.RoundTripper
+	cfg  Config
+
+	mu    sync.Mutex
+	hosts map[string]*host
+
+	// sleep waits between retries; it is replaced in tests.
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// host holds the limits of a single upstream host.
+type host struct {
+	limiter *rate.Limiter
+	slots   chan struct{}
+}
+
+// New creates a Transport. If cfg.ProxyURL is invalid, it is ignored and logged.
+func New(cfg Config) *Transport {
+	base := http.DefaultTransport.(*http.Transport).Clone()
+	if cfg.ProxyURL != "" {
+		proxy, err := url.Parse(cfg.ProxyURL)
+		if err != nil || proxy.Host == "" {
+			slog.Warn("Invalid scraper proxy URL, ignoring", "value", cfg.ProxyURL)
+		} else {
+			base.Proxy = http.ProxyURL(proxy)
+		}
+	}
+	return newTransport(base, cfg)
+}
+
+func newTransport(base http.RoundTripper, cfg Config) *Transport {
+	return &Transport{
+		base:  base,
+		cfg:   cfg,
+		hosts: make(map[string]*host),
+		sleep: sleepContext,
+	}
+}
+
+var (
+	defaultOnce      sync.Once
+	defaultTransport *Transport
+)
+
+// Default returns the transport shared by all scrapers, configured from the
+// environment on first use. Sharing it makes the per-host limits apply across
+// scrapers and requests.
+func Default() *Transport {
+	defaultOnce.Do(func() {
+		defaultTransport = New(LoadConfig())
+	})
+	return defaultTransport
+}
+
+// NewClient returns an HTTP client using the shared transport. Timeouts are
+// applied per host by the transport, so the client itself has none.
+func NewClient() *http.Client {
+	return &http.Client{Transport: Default()}
+}
+
+func (t *Transport) hostFor(name string) *host {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	h, ok := t.hosts[name]
+	if !ok {
+		h = &host{}
+		if t.cfg.RatePerSecond > 0 {
+			burst := t.cfg.Burst
+			if burst < 1 {
+				burst = 1
+			}
+			h.limiter = rate.NewLimiter(rate.Limit(t.cfg.RatePerSecond), burst)
+		}
+		if t.cfg.MaxConcurrent > 0 {
+			h.slots = make(chan struct{}, t.cfg.MaxConcurrent)
+		}
+		t.hosts[name] = h
+	}
+	return h
+}
+
+func (t *Transport) timeoutFor(hostname string) time.Duration {
+	if d, ok := t.cfg.HostTimeouts[hostname]; ok {
+		return d
+	}
+	if t.cfg.Timeout <= 0 {
+		return defaultTimeout
+	}
+	return t.cfg.Timeout
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("User-Agent") == "" && t.cfg.UserAgent != "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("User-Agent", t.cfg.UserAgent)
+	}
+
+	// Requests with a body cannot be replayed safely
+	retries := t.cfg.MaxRetries
+	if (req.Body != nil && req.Body != http.NoBody) || (req.Method != http.MethodGet && req.Method != http.MethodHead) {
+		retries = 0
+	}
+
+	h := t.hostFor(req.URL.Hostname())
+	for attempt := 0; ; attempt++ {
+		res, err := t.attempt(req, h)
+		if attempt >= retries || !retryable(req.Context(), res, err) {
+			return res, err
+		}
+
+		wait := t.backoff(attempt)
+		if res != nil {
+			if after, ok := retryAfter(res.Header.Get("Retry-After")); ok {
+				if after > t.cfg.MaxBackoff {
+					// Waiting that long would exceed the caller's patience; let it see the response
+					return res, nil
+				}
+				wait = after
+			}
+			io.Copy(io.Discard, io.LimitReader(res.Body, 64<<10))
+			res.Body.Close()
+		}
+
+		slog.DebugContext(req.Context(), "Retrying scraper request", "host", req.URL.Host, "attempt", attempt+1, "wait", wait.String(), "status", statusOf(res), "error", err)
+		if err := t.sleep(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// attempt sends a single request within the host's limits and timeout.
+func (t *Transport) attempt(req *http.Request, h *host) (*http.Response, error) {
+	ctx := req.Context()
+	if h.slots != nil {
+		select {
+		case h.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	release := func() {
+		if h.slots != nil {
+			<-h.slots
+		}
+	}
+
+	if h.limiter != nil {
+		if err := h.limiter.Wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, t.timeoutFor(req.URL.Hostname()))
+	res, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		release()
+		return nil, err
+	}
+	// The timeout and the concurrency slot cover reading the body too
+	res.Body = &releaseOnClose{ReadCloser: res.Body, release: func() {
+		cancel()
+		release()
+	}}
+	return res, nil
+}
+
+// releaseOnClose runs release once when the body is closed.
+type releaseOnClose struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releaseOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
+
+// retryable reports whether a response or error is worth retrying: 429,
+// 5xx other than 501, and network errors that are not the caller's cancellation.
+func retryable(ctx context.Context, res *http.Response, err error) bool {
+	if err != nil {
+		return ctx.Err() == nil && !errors.Is(err, context.Canceled)
+	}
+	return res.StatusCode == http.StatusTooManyRequests ||
+		(res.StatusCode >= 500 && res.StatusCode != http.StatusNotImplemented)
+}
+
+// backoff returns a random wait in [0, BaseBackoff*2^attempt), capped at MaxBackoff.
+func (t *Transport) backoff(attempt int) time.Duration {
+	d := t.cfg.BaseBackoff << attempt
+	if d <= 0 || d > t.cfg.MaxBackoff {
+		d = t.cfg.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+	return rand.N(d)
+}
+
+// retryAfter parses a Retry-After header given in seconds or as an HTTP date.
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		d := time.Until(at)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+func statusOf(res *http.Response) int {
+	if res == nil {
+		return 0
+	}
+	return res.StatusCode
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,325 @@
+package httpx
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testTransport returns a transport without rate limits whose retry waits are recorded instead of slept.
+func testTransport(cfg Config) (*Transport, *[]time.Duration) {
+	t := newTransport(http.DefaultTransport, cfg)
+	var waits []time.Duration
+	t.sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return ctx.Err()
+	}
+	return t, &waits
+}
+
+func testConfig() Config {
+	cfg := DefaultConfig()
+	cfg.RatePerSecond = 0
+	cfg.MaxConcurrent = 0
+	return cfg
+}
+
+func get(t *testing.T, transport http.RoundTripper, url string) *http.Response {
+	t.Helper()
+	client := &http.Client{Transport: transport}
+	res, err := client.Get(url)
+	if err != nil {
+		t.Fatalf("GET %s failed: %v", url, err)
+	}
+	return res
+}
+
+func TestTransport_RetriesServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		io.WriteString(w, "ok")
+	}))
+	defer server.Close()
+
+	transport, waits := testTransport(testConfig())
+	res := get(t, transport, server.URL)
+	body, _ := io.ReadAll(res.Body)
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusOK || string(body) != "ok" {
+		t.Errorf("expected 200 ok, got %d %q", res.StatusCode, body)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 calls, got %d", calls.Load())
+	}
+	if len(*waits) != 2 {
+		t.Fatalf("expected 2 waits, got %d", len(*waits))
+	}
+	for i, wait := range *waits {
+		if max := defaultBaseBackoff << i; wait < 0 || wait >= max {
+			t.Errorf("wait %d = %v, want jitter in [0, %v)", i, wait, max)
+		}
+	}
+}
+
+func TestTransport_GivesUpAfterMaxRetries(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	transport, _ := testTransport(testConfig())
+	res := get(t, transport, server.URL)
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusBadGateway {
+		t.Errorf("expected 502, got %d", res.StatusCode)
+	}
+	if calls.Load() != defaultMaxRetries+1 {
+		t.Errorf("expected %d calls, got %d", defaultMaxRetries+1, calls.Load())
+	}
+}
+
+func TestTransport_NotRetried(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		method string
+	}{
+		{"client error", http.StatusNotFound, http.MethodGet},
+		{"not implemented", http.StatusNotImplemented, http.MethodGet},
+		{"non-idempotent method", http.StatusServiceUnavailable, http.MethodPost},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			transport, _ := testTransport(testConfig())
+			req, _ := http.NewRequest(tt.method, server.URL, nil)
+			res, err := (&http.Client{Transport: transport}).Do(req)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			res.Body.Close()
+			if calls.Load() != 1 {
+				t.Errorf("expected 1 call, got %d", calls.Load())
+			}
+		})
+	}
+}
+
+func TestTransport_RetryAfter(t *testing.T) {
+	t.Run("Honored", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) == 1 {
+				w.Header().Set("Retry-After", "3")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		transport, waits := testTransport(testConfig())
+		res := get(t, transport, server.URL)
+		res.Body.Close()
+
+		if res.StatusCode != http.StatusOK {
+			t.Errorf("expected 200, got %d", res.StatusCode)
+		}
+		if len(*waits) != 1 || (*waits)[0] != 3*time.Second {
+			t.Errorf("expected a single 3s wait, got %v", *waits)
+		}
+	})
+
+	t.Run("TooLong", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer server.Close()
+
+		transport, _ := testTransport(testConfig())
+		res := get(t, transport, server.URL)
+		res.Body.Close()
+
+		if res.StatusCode != http.StatusTooManyRequests || calls.Load() != 1 {
+			t.Errorf("expected a single 429, got %d after %d calls", res.StatusCode, calls.Load())
+		}
+	})
+}
+
+func TestRetryAfter(t *testing.T) {
+	if d, ok := retryAfter("120"); !ok || d != 2*time.Minute {
+		t.Errorf("retryAfter(120) = %v, %v", d, ok)
+	}
+	date := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	if d, ok := retryAfter(date); !ok || d <= 50*time.Second || d > time.Minute {
+		t.Errorf("retryAfter(%q) = %v, %v", date, d, ok)
+	}
+	if _, ok := retryAfter("soon"); ok {
+		t.Error("expected invalid Retry-After to be ignored")
+	}
+}
+
+func TestTransport_HostTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(time.Second):
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+
+	cfg := testConfig()
+	cfg.MaxRetries = 0
+	cfg.HostTimeouts = map[string]time.Duration{"127.0.0.1": 20 * time.Millisecond}
+	transport, _ := testTransport(cfg)
+
+	start := time.Now()
+	_, err := (&http.Client{Transport: transport}).Get(server.URL)
+	if err == nil {
+		t.Fatal("expected timeout error")
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected the host timeout to apply, took %v", elapsed)
+	}
+}
+
+func TestTransport_UserAgent(t *testing.T) {
+	var userAgents []string
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		userAgents = append(userAgents, r.UserAgent())
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	cfg := testConfig()
+	cfg.UserAgent = "TestAgent/1.0"
+	transport, _ := testTransport(cfg)
+
+	get(t, transport, server.URL).Body.Close()
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	req.Header.Set("User-Agent", "Custom/2.0")
+	res, err := (&http.Client{Transport: transport}).Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if strings.Join(userAgents, ",") != "TestAgent/1.0,Custom/2.0" {
+		t.Errorf("unexpected user agents: %v", userAgents)
+	}
+}
+
+func TestTransport_ConcurrencyCap(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		inFlight.Add(-1)
+	}))
+	defer server.Close()
+
+	cfg := testConfig()
+	cfg.MaxConcurrent = 2
+	transport, _ := testTransport(cfg)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := (&http.Client{Transport: transport}).Get(server.URL)
+			if err == nil {
+				res.Body.Close()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if peak.Load() > 2 {
+		t.Errorf("expected at most 2 requests in flight, got %d", peak.Load())
+	}
+}
+
+func TestTransport_RateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	cfg := testConfig()
+	cfg.RatePerSecond = 50
+	cfg.Burst = 1
+	transport, _ := testTransport(cfg)
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		get(t, transport, server.URL).Body.Close()
+	}
+	// The first request uses the burst, the other three wait 20ms each
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("expected requests to be spaced out, took %v", elapsed)
+	}
+}
+
+func TestNew_Proxy(t *testing.T) {
+	var proxied atomic.Bool
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied.Store(r.URL.Host == "example.invalid")
+	}))
+	defer proxy.Close()
+
+	cfg := testConfig()
+	cfg.ProxyURL = proxy.URL
+	get(t, New(cfg), "http://example.invalid/").Body.Close()
+
+	if !proxied.Load() {
+		t.Error("expected the request to go through the proxy")
+	}
+}
+
+func TestNew_ZeroConfig(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "ok")
+	}))
+	defer server.Close()
+
+	res := get(t, New(Config{}), server.URL)
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatalf("reading body failed: %v", err)
+	}
+	if string(body) != "ok" {
+		t.Errorf("expected ok, got %q", body)
+	}
+}
@@ -0,0 +1,31 @@
+// Package markup reads provider pages for the scrapers. It checks the
+// selectors scrapers depend on, so that a site redesign surfaces as
+// bible.ErrMarkupChanged rather than as "not found", and maps site markup into
+// the passage text vocabulary described in package bible.
+package markup
+
+import (
+	"bible-api-service/internal/bible"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Missing returns the selectors that match nothing in doc.
+func Missing(doc *goquery.Selection, selectors ...bible.Selector) []bible.Selector {
+	var missing []bible.Selector
+	for _, sel := range selectors {
+		if doc.Find(sel.Query).Length() == 0 {
+			missing = append(missing, sel)
+		}
+	}
+	return missing
+}
+
+// Require returns a *bible.MarkupError for the first selector that matches
+// nothing in doc, or nil if they all match.
+func Require(doc *goquery.Selection, provider, pageURL string, selectors ...bible.Selector) error {
+	if missing := Missing(doc, selectors...); len(missing) > 0 {
+		return &bible.MarkupError{Provider: provider, Selector: missing[0], URL: pageURL}
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package markup
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"bible-api-service/internal/bible"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+func TestRequire(t *testing.T) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(`<div class="passage-text"><sup class="versenum">16</sup>For God so loved the world</div>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	passage := bible.Selector{Name: "passage", Query: ".passage-text"}
+	verseNum := bible.Selector{Name: "verse number", Query: "sup.versenum"}
+	footnote := bible.Selector{Name: "footnote", Query: ".footnote"}
+	chapterNum := bible.Selector{Name: "chapter number", Query: ".chapternum"}
+
+	if err := Require(doc.Selection, "biblegateway", "https://example.com", passage, verseNum); err != nil {
+		t.Errorf("expected matching selectors to pass, got %v", err)
+	}
+
+	if missing := Missing(doc.Selection, passage, footnote, chapterNum); len(missing) != 2 || missing[0] != footnote || missing[1] != chapterNum {
+		t.Errorf("expected footnote and chapter number to be missing, got %v", missing)
+	}
+
+	err = Require(doc.Selection, "biblegateway", "https://example.com", passage, footnote, chapterNum)
+	var markupErr *bible.MarkupError
+	if !errors.As(err, &markupErr) {
+		t.Fatalf("expected a MarkupError, got %v", err)
+	}
+	if markupErr.Provider != "biblegateway" || markupErr.Selector != footnote || markupErr.URL != "https://example.com" {
+		t.Errorf("unexpected error fields: %+v", markupErr)
+	}
+}
@@ -0,0 +1,85 @@
+package markup
+
+import (
+	"strings"
+
+	"bible-api-service/internal/bible"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+var textEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// Vocabulary maps a site's markup into the passage text vocabulary.
+type Vocabulary struct {
+	// Classes maps site classes to semantic classes, e.g. "wj" to
+	// bible.ClassWordsOfJesus. Hashed CSS module classes such as
+	// "ChapterContent_wj__ZCpX7" are looked up without their "__" suffix.
+	Classes map[string]string
+	// Skip matches elements left out of the text, such as note markers.
+	Skip string
+}
+
+// HTML returns the text of nodes as semantic HTML: elements with a mapped
+// class become spans with the semantic class, line breaks are kept and every
+// other element is reduced to its content.
+func (v Vocabulary) HTML(nodes *goquery.Selection) string {
+	var b strings.Builder
+	nodes.Each(func(_ int, sel *goquery.Selection) {
+		v.write(&b, sel)
+	})
+	return b.String()
+}
+
+func (v Vocabulary) write(b *strings.Builder, sel *goquery.Selection) {
+	node := sel.Get(0)
+	switch node.Type {
+	case html.TextNode:
+		b.WriteString(textEscaper.Replace(node.Data))
+	case html.ElementNode:
+		if v.Skip != "" && sel.Is(v.Skip) {
+			return
+		}
+		if node.Data == "br" {
+			b.WriteString("<br/>")
+			return
+		}
+		class := v.classOf(sel)
+		if class != "" {
+			b.WriteString(`<span class="` + class + `">`)
+		}
+		sel.Contents().Each(func(_ int, child *goquery.Selection) {
+			v.write(b, child)
+		})
+		if class != "" {
+			b.WriteString("</span>")
+		}
+	}
+}
+
+// classOf returns the semantic classes of an element, space-separated.
+func (v Vocabulary) classOf(sel *goquery.Selection) string {
+	var classes []string
+	for _, class := range strings.Fields(sel.AttrOr("class", "")) {
+		semantic, ok := v.Classes[class]
+		if !ok {
+			if i := strings.Index(class, "__"); i > 0 {
+				semantic, ok = v.Classes[class[:i]]
+			}
+		}
+		if ok && bible.IsSemanticClass(semantic) && !contains(classes, semantic) {
+			classes = append(classes, semantic)
+		}
+	}
+	return strings.Join(classes, " ")
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,38 @@
+package markup
+
+import (
+	"strings"
+	"testing"
+
+	"bible-api-service/internal/bible"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+func TestVocabularyHTML(t *testing.T) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(`<span data-usfm="PSA.3.2">` +
+		`<span class="ChapterContent_label__R2PLt">2</span>` +
+		`<span class="ChapterContent_content__RrUqA">Many are saying of my soul, &amp; of the <span class="ChapterContent_nd__ECPAf">Lord</span>,<br>` +
+		`<span class="ChapterContent_note__YlDW0">note</span></span>` +
+		`<span class="ChapterContent_qs__Z6bIt">Selah</span>` +
+		`<span class="wj ChapterContent_wj__ZCpX7">Yes</span></span>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := Vocabulary{
+		Classes: map[string]string{
+			"ChapterContent_nd": bible.ClassDivineName,
+			"ChapterContent_qs": bible.ClassSelah,
+			"ChapterContent_wj": bible.ClassWordsOfJesus,
+			"wj":                bible.ClassWordsOfJesus,
+			"unknown":           "not-semantic",
+		},
+		Skip: "[class*='ChapterContent_label'], [class*='ChapterContent_note']",
+	}
+
+	got := v.HTML(doc.Find("span[data-usfm]").Contents())
+	want := `Many are saying of my soul, &amp; of the <span class="divine-name">Lord</span>,<br/><span class="selah">Selah</span><span class="woj">Yes</span>`
+	if got != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, got)
+	}
+}
@@ -0,0 +1,130 @@
+// Package replay provides a record/replay http.RoundTripper for scraper tests.
+// In record mode, real responses are saved as golden files; in replay mode they
+// are served from those files without touching the network.
+package replay
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Mode selects whether responses are recorded or replayed.
+type Mode string
+
+const (
+	// ModeReplay serves responses from fixtures and fails on missing ones.
+	ModeReplay Mode = "replay"
+	// ModeRecord sends requests to the real site and saves the responses.
+	ModeRecord Mode = "record"
+)
+
+// ModeEnv is the environment variable selecting the mode.
+const ModeEnv = "SCRAPER_FIXTURES"
+
+// ErrNoFixture is returned in replay mode when no response was recorded for a request.
+var ErrNoFixture = errors.New("no fixture recorded")
+
+// volatileHeaders change on every request and would make re-recorded fixtures
+// differ for no reason.
+var volatileHeaders = []string{
+	"Date", "Set-Cookie", "Age", "Expires", "Last-Modified", "Etag",
+	"Cf-Ray", "Cf-Cache-Status", "X-Request-Id", "X-Amz-Cf-Id", "X-Cache", "Report-To", "Nel",
+}
+
+// Transport records or replays responses, one file per request.
+type Transport struct {
+	Mode Mode
+	// Dir holds the fixtures, in a subdirectory per host.
+	Dir string
+	// Base sends requests in record mode.
+	Base http.RoundTripper
+}
+
+// ModeFromEnv returns the mode set in SCRAPER_FIXTURES, replay by default.
+func ModeFromEnv() Mode {
+	if Mode(os.Getenv(ModeEnv)) == ModeRecord {
+		return ModeRecord
+	}
+	return ModeReplay
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	path := t.Path(req)
+	if t.Mode == ModeRecord {
+		return t.record(req, path)
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w for %s %s (%s); record it with %s=%s", ErrNoFixture, req.Method, req.URL, path, ModeEnv, ModeRecord)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return http.ReadResponse(bufio.NewReader(bytes.NewReader(data)), req)
+}
+
+func (t *Transport) record(req *http.Request, path string) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	res, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, h := range volatileHeaders {
+		res.Header.Del(h)
+	}
+	// The body is stored decoded and in full, with its own length
+	res.Header.Del("Content-Encoding")
+	res.TransferEncoding = nil
+	res.ContentLength = int64(len(body))
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	dump, err := httputil.DumpResponse(res, true)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, dump, 0644); err != nil {
+		return nil, err
+	}
+	return http.ReadResponse(bufio.NewReader(bytes.NewReader(dump)), req)
+}
+
+var unsafeChars = regexp.MustCompile(`[^A-Za-z0-9.-]+`)
+
+// Path returns the fixture file for a request:
+// <Dir>/<host>/<METHOD>_<path and query>_<hash>.http. The hash of the full
+// URL keeps names unique after unsafe characters are replaced and long names cut.
+func (t *Transport) Path(req *http.Request) string {
+	sum := sha256.Sum256([]byte(req.Method + " " + req.URL.String()))
+	name := strings.Trim(unsafeChars.ReplaceAllString(req.URL.EscapedPath()+"?"+req.URL.RawQuery, "_"), "_")
+	if len(name) > 100 {
+		name = name[:100]
+	}
+	file := fmt.Sprintf("%s_%s_%s.http", req.Method, name, hex.EncodeToString(sum[:4]))
+	return filepath.Join(t.Dir, unsafeChars.ReplaceAllString(req.URL.Host, "_"), file)
+}
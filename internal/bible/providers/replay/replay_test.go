@@ -0,0 +1,98 @@
+package replay
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTransport_RecordThenReplay(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Set-Cookie", "session=abc")
+		w.Header().Set("Content-Type", "text/html")
+		io.WriteString(w, "<p>For God so loved the world</p>")
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	url := server.URL + "/esv/john/3.htm?x=1"
+
+	recorder := &http.Client{Transport: &Transport{Mode: ModeRecord, Dir: dir}}
+	res, err := recorder.Get(url)
+	if err != nil {
+		t.Fatalf("record failed: %v", err)
+	}
+	body, _ := io.ReadAll(res.Body)
+	res.Body.Close()
+	if string(body) != "<p>For God so loved the world</p>" {
+		t.Errorf("unexpected recorded body %q", body)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, url, nil)
+	path := (&Transport{Dir: dir}).Path(req)
+	fixture, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("fixture not written: %v", err)
+	}
+	if strings.Contains(string(fixture), "Set-Cookie") || strings.Contains(string(fixture), "Date:") {
+		t.Errorf("volatile headers were recorded:\n%s", fixture)
+	}
+
+	server.Close()
+	player := &http.Client{Transport: &Transport{Mode: ModeReplay, Dir: dir}}
+	res, err = player.Get(url)
+	if err != nil {
+		t.Fatalf("replay failed: %v", err)
+	}
+	body, _ = io.ReadAll(res.Body)
+	res.Body.Close()
+	if res.StatusCode != http.StatusOK || res.Header.Get("Content-Type") != "text/html" || string(body) != "<p>For God so loved the world</p>" {
+		t.Errorf("unexpected replayed response %d %q %q", res.StatusCode, res.Header.Get("Content-Type"), body)
+	}
+	if calls != 1 {
+		t.Errorf("expected replay not to reach the server, got %d calls", calls)
+	}
+}
+
+func TestTransport_MissingFixture(t *testing.T) {
+	client := &http.Client{Transport: &Transport{Mode: ModeReplay, Dir: t.TempDir()}}
+	_, err := client.Get("https://biblehub.com/esv/john/3.htm")
+	if !errors.Is(err, ErrNoFixture) {
+		t.Errorf("expected ErrNoFixture, got %v", err)
+	}
+}
+
+func TestTransport_Path(t *testing.T) {
+	tr := &Transport{Dir: "fixtures"}
+	req, _ := http.NewRequest(http.MethodGet, "https://classic.biblegateway.com/passage/?search=John+3%3A16&version=ESV", nil)
+	path := tr.Path(req)
+	if filepath.Dir(path) != filepath.Join("fixtures", "classic.biblegateway.com") {
+		t.Errorf("unexpected fixture directory %s", path)
+	}
+	if !strings.HasPrefix(filepath.Base(path), "GET_passage_search_John_3_3A16_version_ESV_") {
+		t.Errorf("unexpected fixture name %s", filepath.Base(path))
+	}
+
+	other, _ := http.NewRequest(http.MethodGet, "https://classic.biblegateway.com/passage/?search=John+3%3A17&version=ESV", nil)
+	if tr.Path(other) == path {
+		t.Error("expected different requests to use different fixtures")
+	}
+}
+
+func TestModeFromEnv(t *testing.T) {
+	t.Setenv(ModeEnv, "")
+	if ModeFromEnv() != ModeReplay {
+		t.Error("expected replay by default")
+	}
+	t.Setenv(ModeEnv, "record")
+	if ModeFromEnv() != ModeRecord {
+		t.Error("expected record mode")
+	}
+}
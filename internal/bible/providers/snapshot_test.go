@@ -0,0 +1,134 @@
+package providers_test
+
+import (
+	"errors"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"bible-api-service/internal/bible"
+	"bible-api-service/internal/bible/providers/biblecom"
+	"bible-api-service/internal/bible/providers/biblegateway"
+	"bible-api-service/internal/bible/providers/biblehub"
+	"bible-api-service/internal/bible/providers/biblenow"
+	"bible-api-service/internal/bible/providers/httpx"
+	"bible-api-service/internal/bible/providers/replay"
+)
+
+// fixturesDir holds recorded copies of the real sites. Re-record them with
+//
+//	SCRAPER_FIXTURES=record go test ./internal/bible/providers -run TestSnapshots
+//
+// or the Record Scraper Fixtures workflow, and review the diff: a changed
+// fixture with failing tests means the site's markup changed. The Bible
+// Gateway verse and search fixtures were seeded from the pages captured in
+// tests/testdata.
+var fixturesDir = filepath.Join("testdata", "fixtures")
+
+// unrecorded lists the snapshots whose fixtures have not been committed yet.
+// They are skipped in replay mode while their fixtures are missing; remove an
+// entry once the workflow has recorded it. Every other missing fixture fails.
+var unrecorded = map[string]bool{
+	"biblegateway/GetVersions": true,
+	"biblehub/GetVerse":        true,
+	"biblehub/SearchWords":     true,
+	"biblehub/GetVersions":     true,
+	"biblenow/GetVerse":        true,
+	"biblenow/GetVersions":     true,
+	"biblecom/GetVerse":        true,
+	"biblecom/GetVersions":     true,
+}
+
+type scraper interface {
+	bible.Provider
+	SetHTTPClient(client *http.Client)
+}
+
+// TestSnapshots runs every scraper against its fixtures: a verse, a search
+// and the version list.
+func TestSnapshots(t *testing.T) {
+	mode := replay.ModeFromEnv()
+	client := &http.Client{Transport: &replay.Transport{Mode: mode, Dir: fixturesDir, Base: httpx.Default()}}
+
+	providers := []struct {
+		name    string
+		scraper scraper
+		// version is the provider-specific code of the ESV
+		version string
+		// search is a query whose results were recorded, empty for providers
+		// that cannot search
+		search string
+	}{
+		{"biblegateway", biblegateway.NewScraper(), "ESV", "love"},
+		{"biblehub", biblehub.NewScraper(), "esv", "love"},
+		{"biblenow", biblenow.NewScraper(), "english-standard-version", ""},
+		{"biblecom", biblecom.NewScraper(), "59", ""},
+	}
+
+	for _, p := range providers {
+		t.Run(p.name, func(t *testing.T) {
+			p.scraper.SetHTTPClient(client)
+
+			// failed fails the snapshot, or skips it while its fixtures
+			// have not been recorded
+			failed := func(t *testing.T, call string, err error) {
+				t.Helper()
+				if mode == replay.ModeReplay && errors.Is(err, replay.ErrNoFixture) && unrecorded[p.name+"/"+call] {
+					t.Skipf("%s fixtures not recorded yet: %v", call, err)
+				}
+				t.Fatalf("%s failed: %v", call, err)
+			}
+
+			t.Run("GetVerse", func(t *testing.T) {
+				verse, err := p.scraper.GetVerse("John", "3", "16", p.version)
+				if err != nil {
+					failed(t, "GetVerse", err)
+				}
+				if !strings.Contains(verse, "loved the world") {
+					t.Errorf("expected John 3:16, got %q", verse)
+				}
+			})
+
+			t.Run("SearchWords", func(t *testing.T) {
+				if p.search == "" {
+					if _, err := bible.Search(p.scraper, "love", p.version); !errors.Is(err, bible.ErrUnsupportedVersion) {
+						t.Errorf("expected ErrUnsupportedVersion from a provider that cannot search, got %v", err)
+					}
+					return
+				}
+				results, err := bible.Search(p.scraper, p.search, p.version)
+				if err != nil {
+					failed(t, "SearchWords", err)
+				}
+				if len(results) == 0 {
+					t.Fatal("expected search results")
+				}
+				for _, r := range results {
+					if r.Verse == "" || r.Text == "" {
+						t.Errorf("incomplete search result %+v", r)
+					}
+				}
+			})
+
+			t.Run("GetVersions", func(t *testing.T) {
+				versions, err := p.scraper.GetVersions()
+				if err != nil {
+					failed(t, "GetVersions", err)
+				}
+				found := false
+				for _, v := range versions {
+					if v.Code == "" || v.Name == "" {
+						t.Errorf("incomplete version %+v", v)
+					}
+					if strings.EqualFold(v.Code, p.version) || strings.EqualFold(v.Value, p.version) {
+						found = true
+					}
+				}
+				if !found {
+					t.Errorf("expected %s among %d versions", p.version, len(versions))
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,324 @@
+package bible
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Format is an output format for passage text.
+type Format string
+
+const (
+	// FormatHTML returns the passage text as is, with its semantic classes.
+	FormatHTML Format = "html"
+	// FormatMarkdown renders headings, verse numbers, poetry lines and
+	// italics as Markdown.
+	FormatMarkdown Format = "markdown"
+	// FormatPlain renders the passage as plain text.
+	FormatPlain Format = "plain"
+	// FormatJSONVerses splits the passage into verses of plain text; see Verses.
+	FormatJSONVerses Format = "json-verses"
+	// FormatUSFM renders the passage as a USFM fragment without an \id line.
+	FormatUSFM Format = "usfm"
+)
+
+// Reference locates passage text for the formats that number verses by
+// chapter: the book, the chapter the text starts in and the verse that text
+// before the first verse number belongs to. A new chapter starts wherever the
+// verse numbers go back.
+type Reference struct {
+	Book    string
+	Chapter int
+	Verse   int
+}
+
+// ParseFormat returns the Format named s. An empty name means FormatHTML.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(strings.TrimSpace(s))); f {
+	case "":
+		return FormatHTML, nil
+	case FormatHTML, FormatMarkdown, FormatPlain, FormatJSONVerses, FormatUSFM:
+		return f, nil
+	}
+	return "", fmt.Errorf("unsupported format: %q", s)
+}
+
+// Render converts passage text in the semantic vocabulary to format. Words of
+// Jesus have no Markdown or plain text equivalent and keep only their text;
+// the divine name is upper-cased. ref is only used by FormatUSFM. Use Verses
+// for FormatJSONVerses.
+func Render(content string, format Format, ref Reference) (string, error) {
+	switch format {
+	case FormatHTML, "":
+		return content, nil
+	case FormatMarkdown, FormatPlain:
+	case FormatUSFM:
+		return renderUSFM(content, ref)
+	default:
+		return "", fmt.Errorf("unsupported format: %q", format)
+	}
+
+	nodes, err := parseFragment(content)
+	if err != nil {
+		return "", err
+	}
+	r := &renderer{markdown: format == FormatMarkdown}
+	for _, n := range nodes {
+		r.node(n)
+	}
+	return r.b.String(), nil
+}
+
+// VerseText is a verse of a passage as plain text.
+type VerseText struct {
+	Book    string `json:"book"`
+	Chapter int    `json:"chapter"`
+	Verse   int    `json:"verse"`
+	Text    string `json:"text"`
+}
+
+// Verses splits passage text into verses at its verse numbers and renders
+// each as plain text. Headings are left out.
+func Verses(content string, ref Reference) ([]VerseText, error) {
+	nodes, err := parseFragment(content)
+	if err != nil {
+		return nil, err
+	}
+	verses := []VerseText{}
+	current := VerseText{Book: ref.Book, Chapter: ref.Chapter, Verse: ref.Verse}
+	numbered := false
+	r := &renderer{}
+	// end appends the verse rendered so far
+	end := func() {
+		if text := r.b.String(); text != "" {
+			current.Text = text
+			verses = append(verses, current)
+		}
+		r.b.Reset()
+		r.pending = breakNone
+		r.indent = ""
+	}
+	r.onVerse = func(number int) {
+		end()
+		if numbered && number <= current.Verse {
+			current.Chapter++
+		}
+		current.Verse = number
+		numbered = true
+	}
+	for _, n := range nodes {
+		r.node(n)
+	}
+	end()
+	return verses, nil
+}
+
+func parseFragment(content string) ([]*html.Node, error) {
+	return html.ParseFragment(strings.NewReader(content), &html.Node{Type: html.ElementNode, Data: "div", DataAtom: atom.Div})
+}
+
+// Breaks between pieces of text, in increasing strength. Breaks are written
+// lazily, before the next piece of text, so that trailing ones are dropped
+// and a stronger break absorbs weaker ones.
+const (
+	breakNone = iota
+	breakSpace
+	breakLine
+	breakBlock
+)
+
+type renderer struct {
+	b        strings.Builder
+	markdown bool
+	pending  int
+	// indent is written at the start of the next line.
+	indent string
+	upper  bool
+	// onVerse, if set, is called at each verse number instead of writing it,
+	// and headings are skipped.
+	onVerse func(number int)
+}
+
+func (r *renderer) brk(kind int) {
+	r.pending = max(r.pending, kind)
+}
+
+func (r *renderer) flush() {
+	if r.b.Len() > 0 {
+		switch r.pending {
+		case breakSpace:
+			r.b.WriteString(" ")
+		case breakLine:
+			if r.markdown {
+				// A hard line break
+				r.b.WriteString("  ")
+			}
+			r.b.WriteString("\n")
+		case breakBlock:
+			r.b.WriteString("\n\n")
+		}
+	}
+	if r.pending >= breakLine || r.b.Len() == 0 {
+		r.b.WriteString(r.indent)
+	}
+	r.indent = ""
+	r.pending = breakNone
+}
+
+// raw writes markup without escaping it.
+func (r *renderer) raw(s string) {
+	r.flush()
+	r.b.WriteString(s)
+}
+
+// close writes the closing half of a Markdown delimiter pair right after the
+// text it encloses, leaving any pending break for after it.
+func (r *renderer) close(s string) {
+	r.b.WriteString(s)
+}
+
+// text writes text, collapsing runs of whitespace as a browser would.
+func (r *renderer) text(s string) {
+	if r.upper {
+		s = strings.ToUpper(s)
+	}
+	for _, c := range s {
+		if unicode.IsSpace(c) {
+			r.brk(breakSpace)
+			continue
+		}
+		r.flush()
+		if r.markdown && strings.ContainsRune("\\*_`[]", c) {
+			r.b.WriteRune('\\')
+		}
+		r.b.WriteRune(c)
+	}
+}
+
+func (r *renderer) children(n *html.Node) {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		r.node(c)
+	}
+}
+
+func (r *renderer) node(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		r.text(n.Data)
+		return
+	case html.ElementNode:
+	default:
+		return
+	}
+
+	switch n.DataAtom {
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		if r.onVerse != nil {
+			return
+		}
+		r.brk(breakBlock)
+		if r.markdown {
+			r.raw(strings.Repeat("#", int(n.Data[1]-'0')) + " ")
+		}
+		r.children(n)
+		r.brk(breakBlock)
+	case atom.P, atom.Div:
+		r.brk(breakBlock)
+		r.children(n)
+		r.brk(breakBlock)
+	case atom.Br:
+		r.brk(breakLine)
+	case atom.Sup:
+		number := strings.TrimSpace(textContent(n))
+		if number == "" {
+			return
+		}
+		if r.onVerse != nil {
+			if v, err := strconv.Atoi(number); err == nil {
+				r.onVerse(v)
+				return
+			}
+		}
+		if r.markdown {
+			r.raw("**" + number + "**")
+		} else {
+			r.raw(number)
+		}
+		r.brk(breakSpace)
+	case atom.I, atom.Em:
+		if r.markdown {
+			r.raw("*")
+			r.children(n)
+			r.close("*")
+		} else {
+			r.children(n)
+		}
+	default:
+		r.span(n)
+	}
+}
+
+func (r *renderer) span(n *html.Node) {
+	var line, selah, divineName bool
+	indent := 0
+	for _, class := range strings.Fields(attr(n, "class")) {
+		switch class {
+		case ClassLine:
+			line = true
+		case ClassSelah:
+			selah = true
+		case ClassDivineName:
+			divineName = true
+		default:
+			if level := IndentLevel(class); level > 0 {
+				indent = level
+			}
+		}
+	}
+
+	if line {
+		r.brk(breakLine)
+		if indent > 0 {
+			// Leading spaces are not significant in Markdown
+			space := "  "
+			if r.markdown {
+				space = "\u00a0\u00a0"
+			}
+			r.indent = strings.Repeat(space, indent)
+		}
+	}
+	if selah && r.markdown {
+		r.raw("*")
+		defer r.close("*")
+	}
+	if divineName && !r.upper {
+		r.upper = true
+		defer func() { r.upper = false }()
+	}
+	r.children(n)
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func textContent(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	var b strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		b.WriteString(textContent(c))
+	}
+	return b.String()
+}
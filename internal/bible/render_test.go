@@ -0,0 +1,170 @@
+package bible
+
+import (
+	"reflect"
+	"testing"
+)
+
+const psalm3 = `<h3>Save Me, O My God</h3>
+<p><span class="line"><sup>1 </sup>O <span class="divine-name">Lord</span>, how many are my foes!</span><br/><span class="indent-1 line"><span>Many are rising against me;</span></span><br/>` +
+	`<span class="line"><sup>2 </sup>many are saying of my soul,</span><br/><span class="indent-1 line"><span>“There is no salvation for him in God.” <span class="selah">Selah</span></span></span></p>
+<p><span><sup>3 </sup><span class="woj">“Blessed are the <i>poor</i> in spirit,</span></span></p>`
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		format  Format
+		want    string
+	}{
+		{
+			name:    "html is unchanged",
+			content: psalm3,
+			format:  FormatHTML,
+			want:    psalm3,
+		},
+		{
+			name:    "plain",
+			content: psalm3,
+			format:  FormatPlain,
+			want: "Save Me, O My God\n\n" +
+				"1 O LORD, how many are my foes!\n  Many are rising against me;\n" +
+				"2 many are saying of my soul,\n  “There is no salvation for him in God.” Selah\n\n" +
+				"3 “Blessed are the poor in spirit,",
+		},
+		{
+			name:    "markdown",
+			content: psalm3,
+			format:  FormatMarkdown,
+			want: "### Save Me, O My God\n\n" +
+				"**1** O LORD, how many are my foes!  \n\u00a0\u00a0Many are rising against me;  \n" +
+				"**2** many are saying of my soul,  \n\u00a0\u00a0“There is no salvation for him in God.” *Selah*\n\n" +
+				"**3** “Blessed are the *poor* in spirit,",
+		},
+		{
+			name:    "plain text input",
+			content: "For God so loved the world &amp; gave\n  his only Son",
+			format:  FormatPlain,
+			want:    "For God so loved the world & gave his only Son",
+		},
+		{
+			name:    "markdown escapes",
+			content: "a*b_c [d]",
+			format:  FormatMarkdown,
+			want:    `a\*b\_c \[d\]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Render(tt.content, tt.format, Reference{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected\n%q\ngot\n%q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for _, s := range []string{"", "html", "Markdown", "plain"} {
+		if _, err := ParseFormat(s); err != nil {
+			t.Errorf("ParseFormat(%q) failed: %v", s, err)
+		}
+	}
+	if _, err := ParseFormat("pdf"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+	if _, err := Render("text", Format("pdf"), Reference{}); err == nil {
+		t.Error("expected Render to reject an unknown format")
+	}
+}
+
+func TestIsSemanticClass(t *testing.T) {
+	for _, class := range []string{ClassWordsOfJesus, ClassDivineName, ClassLine, ClassSelah, IndentClass(2)} {
+		if !IsSemanticClass(class) {
+			t.Errorf("expected %q to be semantic", class)
+		}
+	}
+	for _, class := range []string{"text", "small-caps", "indent-0", "indent-x", "indent-1-breaks"} {
+		if IsSemanticClass(class) {
+			t.Errorf("expected %q not to be semantic", class)
+		}
+	}
+}
+
+func TestRender_USFM(t *testing.T) {
+	got, err := Render(psalm3, FormatUSFM, Reference{Book: "Psalm", Chapter: 3, Verse: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `\c 3
+\s1 Save Me, O My God
+\q1
+\v 1 O \nd Lord\nd*, how many are my foes!
+\q2 Many are rising against me;
+\q1
+\v 2 many are saying of my soul,
+\q2 “There is no salvation for him in God.” \qs Selah\qs*
+\p
+\v 3 \wj “Blessed are the \+it poor\+it* in spirit,\wj*`
+	if got != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, got)
+	}
+}
+
+func TestRender_USFMChapters(t *testing.T) {
+	got, err := Render(`<sup>36 </sup>Verse 36 <sup>1 </sup>Verse 1`, FormatUSFM, Reference{Book: "John", Chapter: 3, Verse: 36})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "\\c 3\n\\v 36 Verse 36\n\\c 4\n\\p\n\\v 1 Verse 1"
+	if got != want {
+		t.Errorf("expected\n%q\ngot\n%q", want, got)
+	}
+}
+
+func TestVerses(t *testing.T) {
+	t.Run("splits at verse numbers", func(t *testing.T) {
+		got, err := Verses(psalm3, Reference{Book: "Psalm", Chapter: 3, Verse: 1})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := []VerseText{
+			{Book: "Psalm", Chapter: 3, Verse: 1, Text: "O LORD, how many are my foes!\n  Many are rising against me;"},
+			{Book: "Psalm", Chapter: 3, Verse: 2, Text: "many are saying of my soul,\n  “There is no salvation for him in God.” Selah"},
+			{Book: "Psalm", Chapter: 3, Verse: 3, Text: "“Blessed are the poor in spirit,"},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("expected\n%+v\ngot\n%+v", want, got)
+		}
+	})
+
+	t.Run("crosses chapters", func(t *testing.T) {
+		got, err := Verses(`<sup>36 </sup>Verse 36<br/><sup>1 </sup>Verse 1 <sup>2 </sup>Verse 2`, Reference{Book: "John", Chapter: 3, Verse: 36})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := []VerseText{
+			{Book: "John", Chapter: 3, Verse: 36, Text: "Verse 36"},
+			{Book: "John", Chapter: 4, Verse: 1, Text: "Verse 1"},
+			{Book: "John", Chapter: 4, Verse: 2, Text: "Verse 2"},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("expected\n%+v\ngot\n%+v", want, got)
+		}
+	})
+
+	t.Run("text without verse numbers", func(t *testing.T) {
+		got, err := Verses("For God so loved the world", Reference{Book: "John", Chapter: 3, Verse: 16})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := []VerseText{{Book: "John", Chapter: 3, Verse: 16, Text: "For God so loved the world"}}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("expected %+v, got %+v", want, got)
+		}
+	})
+}
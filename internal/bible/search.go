@@ -0,0 +1,21 @@
+package bible
+
+// MergeSearchResults merges lists of search results from several searches,
+// keeping the first result for each verse and the order the results come
+// in. Verses are compared by their normalized references, so "Eph 4:32"
+// from one source and "Ephesians 4:32" from another are the same.
+func MergeSearchResults(lists ...[]SearchResult) []SearchResult {
+	merged := []SearchResult{}
+	seen := make(map[string]bool)
+	for _, results := range lists {
+		for _, result := range results {
+			ref := NormalizeReference(result.Verse)
+			if seen[ref] {
+				continue
+			}
+			seen[ref] = true
+			merged = append(merged, result)
+		}
+	}
+	return merged
+}
@@ -0,0 +1,26 @@
+package bible
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeSearchResults(t *testing.T) {
+	got := MergeSearchResults(
+		[]SearchResult{{Verse: "Eph 4:32", Text: "be kind"}, {Verse: "John 3:16", Text: "God so loved"}},
+		nil,
+		[]SearchResult{{Verse: "Ephesians  4:32", Text: "be ye kind"}, {Verse: "Col 3:13", Text: "forgiving"}, {Verse: "John 3:16", Text: "loved"}},
+	)
+	want := []SearchResult{
+		{Verse: "Eph 4:32", Text: "be kind"},
+		{Verse: "John 3:16", Text: "God so loved"},
+		{Verse: "Col 3:13", Text: "forgiving"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MergeSearchResults() = %+v, want %+v", got, want)
+	}
+
+	if got := MergeSearchResults(); got == nil || len(got) != 0 {
+		t.Errorf("MergeSearchResults() = %#v, want an empty list", got)
+	}
+}
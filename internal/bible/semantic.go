@@ -0,0 +1,51 @@
+package bible
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Passage text is HTML restricted to a provider-neutral vocabulary, so that
+// it can be rendered the same way whichever provider served it:
+//
+//   - h1 to h6: section headings, keeping their level
+//   - p and br: paragraphs and line breaks
+//   - sup: a verse number
+//   - i: italics, e.g. words supplied by translators
+//   - span with one or more of the classes below
+//
+// Scrapers map whatever their site marks up into it; text without any markup
+// is valid too.
+const (
+	// ClassWordsOfJesus marks red-letter text.
+	ClassWordsOfJesus = "woj"
+	// ClassDivineName marks the divine name printed in small caps, e.g. LORD.
+	ClassDivineName = "divine-name"
+	// ClassLine marks a line of poetry, optionally indented with IndentClass.
+	ClassLine = "line"
+	// ClassSelah marks the musical interlude "Selah" in the Psalms.
+	ClassSelah = "selah"
+)
+
+// IndentClass returns the class of a poetry line indented by level, e.g. "indent-1".
+func IndentClass(level int) string {
+	return "indent-" + strconv.Itoa(level)
+}
+
+// IndentLevel returns the level of an indent class, or 0 if class is not one.
+func IndentLevel(class string) int {
+	level, err := strconv.Atoi(strings.TrimPrefix(class, "indent-"))
+	if err != nil || !strings.HasPrefix(class, "indent-") || level < 1 {
+		return 0
+	}
+	return level
+}
+
+// IsSemanticClass reports whether class belongs to the passage text vocabulary.
+func IsSemanticClass(class string) bool {
+	switch class {
+	case ClassWordsOfJesus, ClassDivineName, ClassLine, ClassSelah:
+		return true
+	}
+	return IndentLevel(class) > 0
+}
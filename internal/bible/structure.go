@@ -0,0 +1,119 @@
+package bible
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BookStructure is a book with its chapters and verses, numbered as a version
+// numbers them.
+type BookStructure struct {
+	Book
+	// Chapters is the number of chapters. It is 0 for the Additions to
+	// Esther, whose chapters editions number differently.
+	Chapters int `json:"chapters"`
+	// Verses is the number of verses of each chapter, the first chapter first.
+	// It is only known for the books of the Protestant canon.
+	Verses []int `json:"verses,omitempty"`
+}
+
+// Structure returns the chapters and verses of the book numbered in scheme.
+func (b Book) Structure(scheme Versification) BookStructure {
+	counts, ok := englishVerseCounts[b.USFM]
+	if !ok {
+		return BookStructure{Book: b, Chapters: deuterocanonChapters[b.USFM]}
+	}
+	if !hasRules(b, scheme) {
+		return BookStructure{Book: b, Chapters: len(counts), Verses: append([]int(nil), counts...)}
+	}
+
+	// Every verse is mapped, so chapters count the psalm titles numbered as
+	// verses and the verses moved in from other chapters
+	var verses []int
+	for i, n := range counts {
+		for verse := 1; verse <= n; verse++ {
+			ref := fromEnglish(VerseRef{Book: b.Name, Chapter: i + 1, Verse: verse}, scheme)
+			for len(verses) < ref.Chapter {
+				verses = append(verses, 0)
+			}
+			verses[ref.Chapter-1] = max(verses[ref.Chapter-1], ref.Verse)
+		}
+	}
+	return BookStructure{Book: b, Chapters: len(verses), Verses: verses}
+}
+
+// hasRules reports whether scheme numbers any verse of the book differently
+// from the English numbering.
+func hasRules(b Book, scheme Versification) bool {
+	name := strings.ToLower(b.Name)
+	for _, r := range versificationRules[scheme] {
+		if r.Book == name {
+			return true
+		}
+	}
+	return false
+}
+
+// CanonBooks returns the books of a canon in order, numbered in scheme. An
+// empty canon is CanonProtestant.
+func CanonBooks(canon string, scheme Versification) []BookStructure {
+	var structures []BookStructure
+	for _, b := range books {
+		if canonHas(canon, b) {
+			structures = append(structures, b.Structure(scheme))
+		}
+	}
+	return structures
+}
+
+// AdjacentChapters returns references to the chapters before and after a
+// chapter of book, e.g. "John 2" and "John 4" for John 3, taking the last
+// chapter of the previous book and the first of the next at either end of
+// the book. books are the books of the version, as CanonBooks returns them.
+// A reference is empty at the ends of the Bible, and both are when the book
+// or chapter is not among books.
+func AdjacentChapters(books []BookStructure, book string, chapter int) (prev, next string) {
+	b, ok := ReferenceBook(book, fmt.Sprint(chapter))
+	if !ok {
+		return "", ""
+	}
+	if named, _ := LookupBook(book); named.USFM != b.USFM {
+		// Psalm 151 numbered as a chapter of the Psalms
+		chapter = 1
+	}
+	index := -1
+	for i, s := range books {
+		if s.USFM == b.USFM {
+			index = i
+		}
+	}
+	if index == -1 || chapter < 1 || chapter > books[index].Chapters {
+		return "", ""
+	}
+
+	if chapter > 1 {
+		prev = chapterReference(books[index].Name, chapter-1)
+	} else {
+		for i := index - 1; i >= 0; i-- {
+			if books[i].Chapters > 0 {
+				prev = chapterReference(books[i].Name, books[i].Chapters)
+				break
+			}
+		}
+	}
+	if chapter < books[index].Chapters {
+		next = chapterReference(books[index].Name, chapter+1)
+	} else {
+		for i := index + 1; i < len(books); i++ {
+			if books[i].Chapters > 0 {
+				next = chapterReference(books[i].Name, 1)
+				break
+			}
+		}
+	}
+	return prev, next
+}
+
+func chapterReference(book string, chapter int) string {
+	return fmt.Sprintf("%s %d", book, chapter)
+}
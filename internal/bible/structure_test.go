@@ -0,0 +1,109 @@
+package bible
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEnglishVerseCounts(t *testing.T) {
+	chapters, verses := 0, 0
+	for _, counts := range englishVerseCounts {
+		chapters += len(counts)
+		for _, n := range counts {
+			verses += n
+		}
+	}
+	if chapters != 1189 || verses != 31102 {
+		t.Errorf("got %d chapters and %d verses, want 1189 and 31102", chapters, verses)
+	}
+	for _, b := range books {
+		_, hasVerses := englishVerseCounts[b.USFM]
+		if hasVerses != (b.Canon == CanonProtestant) {
+			t.Errorf("%s: verse counts %v for canon %s", b.Name, hasVerses, b.Canon)
+		}
+	}
+}
+
+func TestBook_Structure(t *testing.T) {
+	tests := []struct {
+		book     string
+		scheme   Versification
+		chapters int
+		verses   map[int]int
+	}{
+		{"Genesis", VersificationHebrew, 50, map[int]int{1: 31, 50: 26}},
+		{"Malachi", VersificationEnglish, 4, map[int]int{3: 18, 4: 6}},
+		{"Malachi", VersificationHebrew, 3, map[int]int{3: 24}},
+		{"Joel", VersificationHebrew, 4, map[int]int{2: 27, 3: 5, 4: 21}},
+		{"Psalms", VersificationHebrew, 150, map[int]int{3: 9, 23: 6, 51: 21}},
+		{"Psalms", VersificationVulgate, 150, map[int]int{9: 39, 10: 7, 113: 26, 114: 9, 115: 10, 146: 11, 147: 9}},
+		{"Tobit", VersificationEnglish, 14, nil},
+	}
+	for _, tt := range tests {
+		b, _ := LookupBook(tt.book)
+		s := b.Structure(tt.scheme)
+		if s.Chapters != tt.chapters {
+			t.Errorf("%s in %s: %d chapters, want %d", tt.book, tt.scheme, s.Chapters, tt.chapters)
+		}
+		if tt.verses == nil && s.Verses != nil {
+			t.Errorf("%s in %s: verses %v, want none", tt.book, tt.scheme, s.Verses)
+		}
+		for chapter, want := range tt.verses {
+			if got := s.Verses[chapter-1]; got != want {
+				t.Errorf("%s %d in %s: %d verses, want %d", tt.book, chapter, tt.scheme, got, want)
+			}
+		}
+	}
+}
+
+func TestCanonBooks(t *testing.T) {
+	for canon, want := range map[string]int{"": 66, CanonCatholic: 78, CanonOrthodox: 84} {
+		if got := len(CanonBooks(canon, VersificationEnglish)); got != want {
+			t.Errorf("CanonBooks(%q) has %d books, want %d", canon, got, want)
+		}
+	}
+
+	var names []string
+	for _, b := range CanonBooks(CanonCatholic, VersificationEnglish)[15:21] {
+		names = append(names, b.Name)
+	}
+	want := []string{"Nehemiah", "Tobit", "Judith", "Esther", "Additions to Esther", "1 Maccabees"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("Catholic books = %v, want %v", names, want)
+	}
+}
+
+func TestAdjacentChapters(t *testing.T) {
+	protestant := CanonBooks(CanonProtestant, VersificationEnglish)
+	catholic := CanonBooks(CanonCatholic, VersificationEnglish)
+	orthodox := CanonBooks(CanonOrthodox, VersificationEnglish)
+	hebrew := CanonBooks(CanonProtestant, VersificationHebrew)
+	tests := []struct {
+		name       string
+		books      []BookStructure
+		book       string
+		chapter    int
+		prev, next string
+	}{
+		{"within a book", protestant, "John", 3, "John 2", "John 4"},
+		{"end of a book", protestant, "Malachi", 4, "Malachi 3", "Matthew 1"},
+		{"start of a book", protestant, "Matt", 1, "Malachi 4", "Matthew 2"},
+		{"start of the Bible", protestant, "Genesis", 1, "", "Genesis 2"},
+		{"end of the Bible", protestant, "Revelation", 22, "Revelation 21", ""},
+		{"into the deuterocanon", catholic, "Nehemiah", 13, "Nehemiah 12", "Tobit 1"},
+		{"past the Additions to Esther", catholic, "Esther", 10, "Esther 9", "1 Maccabees 1"},
+		{"hebrew numbering", hebrew, "Malachi", 3, "Malachi 2", "Matthew 1"},
+		{"psalm 151", orthodox, "Psalms", 151, "Psalms 150", "Proverbs 1"},
+		{"chapter out of range", protestant, "John", 22, "", ""},
+		{"book not in canon", protestant, "Tobit", 1, "", ""},
+		{"unknown book", protestant, "Hezekiah", 1, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prev, next := AdjacentChapters(tt.books, tt.book, tt.chapter)
+			if prev != tt.prev || next != tt.next {
+				t.Errorf("AdjacentChapters(%s %d) = %q, %q, want %q, %q", tt.book, tt.chapter, prev, next, tt.prev, tt.next)
+			}
+		})
+	}
+}
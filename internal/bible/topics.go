@@ -0,0 +1,69 @@
+package bible
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sources of the verses of a topic.
+const (
+	TopicSourceIndex  = "index"
+	TopicSourceSearch = "search"
+)
+
+// TopicVerse is a verse listed for a topic.
+type TopicVerse struct {
+	// Reference is the verse in the English numbering, e.g. "Matthew 6:14".
+	Reference string `json:"reference"`
+	// Note is what the topical index lists the verse for, e.g. "Of enemies".
+	Note string `json:"note,omitempty"`
+	// Text is a snippet of the verse, if known.
+	Text string `json:"text,omitempty"`
+	// Rank orders the verses from the most relevant, starting at 1.
+	Rank int `json:"rank"`
+}
+
+// Topic is an entry of a topical index, or the results of searching for it.
+type Topic struct {
+	Topic string `json:"topic"`
+	// Definition is the topic's Bible dictionary entry, if there is one.
+	Definition string `json:"definition,omitempty"`
+	// Source is TopicSourceIndex or TopicSourceSearch.
+	Source string       `json:"source"`
+	Verses []TopicVerse `json:"verses"`
+}
+
+// TopicProvider is implemented by providers with a topical index.
+type TopicProvider interface {
+	LookupTopic(topic string) (Topic, error)
+}
+
+// LookupTopic looks a topic up in p's topical index.
+func LookupTopic(p Provider, topic string) (Topic, error) {
+	tp, ok := p.(TopicProvider)
+	if !ok {
+		return Topic{}, fmt.Errorf("%w: provider has no topical index", ErrUnsupportedVersion)
+	}
+	return tp.LookupTopic(topic)
+}
+
+// FindTopic looks a topic up in index, falling back to search when the index
+// does not have it. Search results keep the order they are ranked in, and
+// their references are taken to be in the English numbering.
+func FindTopic(index Provider, search func(topic string) ([]SearchResult, error), topic string) (Topic, error) {
+	if index != nil {
+		t, err := LookupTopic(index, topic)
+		if err == nil || !(errors.Is(err, ErrNotFound) || errors.Is(err, ErrUnsupportedVersion)) {
+			return t, err
+		}
+	}
+	results, err := search(topic)
+	if err != nil {
+		return Topic{}, err
+	}
+	t := Topic{Topic: topic, Source: TopicSourceSearch, Verses: []TopicVerse{}}
+	for i, result := range results {
+		t.Verses = append(t.Verses, TopicVerse{Reference: NormalizeReference(result.Verse), Text: result.Text, Rank: i + 1})
+	}
+	return t, nil
+}
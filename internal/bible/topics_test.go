@@ -0,0 +1,58 @@
+package bible
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"bible-api-service/internal/metrics"
+)
+
+type topicProvider struct {
+	MockProvider
+	topics map[string]Topic
+}
+
+func (p *topicProvider) LookupTopic(topic string) (Topic, error) {
+	if t, ok := p.topics[topic]; ok {
+		return t, nil
+	}
+	return Topic{}, fmt.Errorf("topic %w", ErrNotFound)
+}
+
+func TestFindTopic(t *testing.T) {
+	forgiveness := Topic{Topic: "Forgiveness", Source: TopicSourceIndex, Verses: []TopicVerse{{Reference: "Matthew 6:14", Rank: 1}}}
+	index := NewObservedProvider("test", &topicProvider{topics: map[string]Topic{"forgiveness": forgiveness}}, metrics.NewRegistry(time.Minute))
+	var searched []string
+	searcher := &MockProvider{SearchWordsFunc: func(query, version string) ([]SearchResult, error) {
+		searched = append(searched, query+" in "+version)
+		return []SearchResult{{Verse: "Eph 4:32", Text: "forgiving one another"}, {Verse: "Col 3:13", Text: "forgive each other"}}, nil
+	}}
+	search := func(topic string) ([]SearchResult, error) { return Search(searcher, topic, "ESV") }
+
+	got, err := FindTopic(index, search, "forgiveness")
+	if err != nil || !reflect.DeepEqual(got, forgiveness) {
+		t.Errorf("FindTopic(forgiveness) = %+v, %v, want %+v", got, err, forgiveness)
+	}
+
+	want := Topic{Topic: "kindness", Source: TopicSourceSearch, Verses: []TopicVerse{
+		{Reference: "Ephesians 4:32", Text: "forgiving one another", Rank: 1},
+		{Reference: "Colossians 3:13", Text: "forgive each other", Rank: 2},
+	}}
+	for name, index := range map[string]Provider{"not in index": index, "no index": searcher} {
+		got, err := FindTopic(index, search, "kindness")
+		if err != nil || !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: FindTopic(kindness) = %+v, %v, want %+v", name, got, err, want)
+		}
+	}
+	if want := []string{"kindness in ESV", "kindness in ESV"}; !reflect.DeepEqual(searched, want) {
+		t.Errorf("searched %v, want %v", searched, want)
+	}
+
+	failing := func(topic string) ([]SearchResult, error) { return nil, ErrUpstreamUnavailable }
+	if _, err := FindTopic(index, failing, "kindness"); !errors.Is(err, ErrUpstreamUnavailable) {
+		t.Errorf("expected ErrUpstreamUnavailable, got %v", err)
+	}
+}
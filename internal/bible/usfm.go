@@ -0,0 +1,173 @@
+package bible
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// renderUSFM renders passage text as USFM: \c for chapters, \s1 and \s2 for
+// headings, \p for paragraphs, \q1, \q2... for poetry lines, \v for verses
+// and the \wj, \nd, \qs and \it character styles.
+func renderUSFM(content string, ref Reference) (string, error) {
+	nodes, err := parseFragment(content)
+	if err != nil {
+		return "", err
+	}
+	u := &usfmRenderer{chapter: ref.Chapter}
+	if u.chapter > 0 {
+		u.line(`\c ` + strconv.Itoa(u.chapter))
+	}
+	for _, n := range nodes {
+		u.node(n)
+	}
+	return u.b.String(), nil
+}
+
+type usfmRenderer struct {
+	b strings.Builder
+	// marker is a paragraph marker written before the next text.
+	marker string
+	// space is a space to write before the next text.
+	space     bool
+	chapter   int
+	lastVerse int
+	// depth is the number of open character styles.
+	depth int
+}
+
+// line starts a new line with a marker.
+func (u *usfmRenderer) line(marker string) {
+	if u.b.Len() > 0 {
+		u.b.WriteString("\n")
+	}
+	u.b.WriteString(marker)
+	u.space = true
+}
+
+// open writes the pending paragraph marker, if any.
+func (u *usfmRenderer) open() {
+	if u.marker != "" {
+		u.line(u.marker)
+		u.marker = ""
+	}
+}
+
+func (u *usfmRenderer) text(s string) {
+	for _, c := range s {
+		if unicode.IsSpace(c) {
+			if u.b.Len() > 0 {
+				u.space = true
+			}
+			continue
+		}
+		u.open()
+		if u.space {
+			u.b.WriteString(" ")
+			u.space = false
+		}
+		u.b.WriteRune(c)
+	}
+}
+
+// style wraps the children of n in a character style. Styles nested in
+// another style take a plus sign: \wj ... \+nd LORD\+nd*\wj*.
+func (u *usfmRenderer) style(n *html.Node, names ...string) {
+	if len(names) == 0 {
+		u.children(n)
+		return
+	}
+	marker := `\` + names[0]
+	if u.depth > 0 {
+		marker = `\+` + names[0]
+	}
+	u.open()
+	if u.space {
+		u.b.WriteString(" ")
+	}
+	u.b.WriteString(marker)
+	// The space after the marker delimits it
+	u.space = true
+	u.depth++
+	u.style(n, names[1:]...)
+	u.depth--
+	u.b.WriteString(marker + "*")
+}
+
+func (u *usfmRenderer) children(n *html.Node) {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		u.node(c)
+	}
+}
+
+func (u *usfmRenderer) node(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		u.text(n.Data)
+		return
+	case html.ElementNode:
+	default:
+		return
+	}
+
+	switch n.DataAtom {
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		u.marker = `\s1`
+		if n.Data[1] > '3' {
+			u.marker = `\s2`
+		}
+		u.children(n)
+		u.marker = `\p`
+	case atom.P, atom.Div:
+		u.marker = `\p`
+		u.children(n)
+	case atom.Sup:
+		number, err := strconv.Atoi(strings.TrimSpace(textContent(n)))
+		if err != nil {
+			return
+		}
+		if u.lastVerse > 0 && number <= u.lastVerse {
+			u.chapter++
+			u.line(fmt.Sprintf(`\c %d`, u.chapter))
+			if u.marker == "" {
+				u.marker = `\p`
+			}
+		}
+		u.lastVerse = number
+		u.open()
+		u.line(fmt.Sprintf(`\v %d`, number))
+	case atom.I, atom.Em:
+		u.style(n, "it")
+	default:
+		u.span(n)
+	}
+}
+
+func (u *usfmRenderer) span(n *html.Node) {
+	var styles []string
+	line, indent := false, 0
+	for _, class := range strings.Fields(attr(n, "class")) {
+		switch class {
+		case ClassLine:
+			line = true
+		case ClassWordsOfJesus:
+			styles = append(styles, "wj")
+		case ClassDivineName:
+			styles = append(styles, "nd")
+		case ClassSelah:
+			styles = append(styles, "qs")
+		default:
+			if level := IndentLevel(class); level > 0 {
+				indent = level
+			}
+		}
+	}
+	if line {
+		u.marker = `\q` + strconv.Itoa(indent+1)
+	}
+	u.style(n, styles...)
+}
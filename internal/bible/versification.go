@@ -0,0 +1,347 @@
+package bible
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Versification is a verse numbering scheme. Versions mostly agree on where
+// verses start and end but not on how they are numbered: Hebrew Bibles number
+// psalm titles as verses and end Malachi at chapter 3, and Bibles following
+// the Septuagint count Psalms 9 and 10 as one psalm, for instance.
+type Versification string
+
+const (
+	// VersificationEnglish is the numbering of the King James tradition, which
+	// Protestant and most other versions follow. Versions without a
+	// versification use it.
+	VersificationEnglish Versification = "english"
+	// VersificationHebrew is the numbering of the Masoretic text, followed by
+	// Jewish versions and modern Catholic ones such as the NABRE.
+	VersificationHebrew Versification = "hebrew"
+	// VersificationVulgate numbers the Psalms as the Septuagint does, with
+	// Hebrew psalm titles, and Joel and Malachi as English versions do. The
+	// Vulgate, the Douay-Rheims and the Russian Synodal follow it.
+	VersificationVulgate Versification = "vulgate"
+	// VersificationSeptuagint is the numbering of the Greek Old Testament,
+	// followed by Orthodox versions: the Psalms as in VersificationVulgate and
+	// Joel and Malachi as in VersificationHebrew. The Septuagint also moves
+	// Malachi 4:4 after 4:6; that is not covered.
+	VersificationSeptuagint Versification = "septuagint"
+)
+
+// ParseVersification returns the Versification named s. An empty name means
+// VersificationEnglish.
+func ParseVersification(s string) (Versification, error) {
+	switch v := Versification(strings.ToLower(strings.TrimSpace(s))); v {
+	case "":
+		return VersificationEnglish, nil
+	case VersificationEnglish, VersificationHebrew, VersificationVulgate, VersificationSeptuagint:
+		return v, nil
+	}
+	return "", fmt.Errorf("unsupported versification: %q", s)
+}
+
+// VerseRef is a single verse. Verse 0 stands for a psalm title that only some
+// schemes number as verses.
+type VerseRef struct {
+	Book    string
+	Chapter int
+	Verse   int
+}
+
+// versificationRule moves verses First to Last of a chapter in the English
+// numbering to ToChapter, adding Offset to their numbers. A Last of 0 covers
+// the rest of the chapter. Rules that split or merge chapters always set Last.
+type versificationRule struct {
+	Book      string
+	Chapter   int
+	First     int
+	Last      int
+	ToChapter int
+	Offset    int
+	// Title is the number of verses the psalm title takes at the start of ToChapter.
+	Title int
+}
+
+// psalmTitleVerses is the number of verses the Hebrew numbering gives the
+// titles of the psalms whose titles are not part of their first verse.
+var psalmTitleVerses = map[int]int{
+	3: 1, 4: 1, 5: 1, 6: 1, 7: 1, 8: 1, 9: 1, 12: 1, 13: 1, 18: 1, 19: 1,
+	20: 1, 21: 1, 22: 1, 30: 1, 31: 1, 34: 1, 36: 1, 38: 1, 39: 1, 40: 1,
+	41: 1, 42: 1, 44: 1, 45: 1, 46: 1, 47: 1, 48: 1, 49: 1, 51: 2, 52: 2,
+	53: 1, 54: 2, 55: 1, 56: 1, 57: 1, 58: 1, 59: 1, 60: 2, 61: 1, 62: 1,
+	63: 1, 64: 1, 65: 1, 67: 1, 68: 1, 69: 1, 70: 1, 75: 1, 76: 1, 77: 1,
+	80: 1, 81: 1, 83: 1, 84: 1, 85: 1, 88: 1, 89: 1, 92: 1, 102: 1, 108: 1,
+	140: 1, 142: 1,
+}
+
+// versificationRules maps the English numbering to the other schemes. Verses
+// without a rule have the same number in both. A few psalms also divide their
+// last verses differently, e.g. Psalm 13; those are not covered.
+var versificationRules = map[Versification][]versificationRule{
+	VersificationHebrew:     append(hebrewProphetRules(), hebrewPsalmRules()...),
+	VersificationVulgate:    greekPsalmRules(),
+	VersificationSeptuagint: append(hebrewProphetRules(), greekPsalmRules()...),
+}
+
+// hebrewProphetRules end Joel 2 at verse 27, starting a chapter with verse
+// 28, and end Malachi at chapter 3.
+func hebrewProphetRules() []versificationRule {
+	return []versificationRule{
+		{Book: "joel", Chapter: 2, First: 28, Last: 32, ToChapter: 3, Offset: -27},
+		{Book: "joel", Chapter: 3, First: 1, ToChapter: 4},
+		{Book: "malachi", Chapter: 4, First: 1, Last: 6, ToChapter: 3, Offset: 18},
+	}
+}
+
+// hebrewPsalmRules number psalm titles as verses.
+func hebrewPsalmRules() []versificationRule {
+	var rules []versificationRule
+	for chapter, title := range psalmTitleVerses {
+		rules = append(rules, versificationRule{Book: "psalms", Chapter: chapter, First: 1, ToChapter: chapter, Offset: title, Title: title})
+	}
+	return rules
+}
+
+// greekPsalmRules number psalm titles as verses and the Psalms as the
+// Septuagint does: 9 and 10 are one psalm, as are 114 and 115, while 116 and
+// 147 are split in two, so most psalms are numbered one lower.
+func greekPsalmRules() []versificationRule {
+	var rules []versificationRule
+	for chapter := 1; chapter <= 150; chapter++ {
+		title := psalmTitleVerses[chapter]
+		add := func(first, last, to, offset int) {
+			rule := versificationRule{Book: "psalms", Chapter: chapter, First: first, Last: last, ToChapter: to, Offset: offset}
+			if first == 1 {
+				rule.Title = title
+			}
+			if rule.ToChapter != chapter || rule.Offset != 0 {
+				rules = append(rules, rule)
+			}
+		}
+		switch {
+		case chapter == 9:
+			add(1, 20, 9, title)
+		case chapter == 10:
+			add(1, 18, 9, 21)
+		case chapter == 114:
+			add(1, 8, 113, 0)
+		case chapter == 115:
+			add(1, 18, 113, 8)
+		case chapter == 116:
+			add(1, 9, 114, 0)
+			add(10, 19, 115, -9)
+		case chapter == 147:
+			add(1, 11, 146, 0)
+			add(12, 20, 147, -11)
+		case chapter > 10 && chapter < 147:
+			add(1, 0, chapter-1, title)
+		default:
+			add(1, 0, chapter, title)
+		}
+	}
+	return rules
+}
+
+// versificationBook returns the name rules use for book.
+func versificationBook(book string) string {
+	if b, ok := LookupBook(book); ok {
+		return strings.ToLower(b.Name)
+	}
+	return strings.ToLower(strings.TrimSpace(book))
+}
+
+// MapVerse returns the number that ref, numbered in from, has in to.
+func MapVerse(ref VerseRef, from, to Versification) VerseRef {
+	if from == to {
+		return ref
+	}
+	return fromEnglish(toEnglish(ref, from), to)
+}
+
+// englishRule returns the rule that moves ref, numbered in English, to the scheme.
+func englishRule(ref VerseRef, to Versification) (versificationRule, bool) {
+	book := versificationBook(ref.Book)
+	for _, r := range versificationRules[to] {
+		if r.Book == book && r.Chapter == ref.Chapter && ref.Verse >= r.First && (r.Last == 0 || ref.Verse <= r.Last) {
+			return r, true
+		}
+	}
+	return versificationRule{}, false
+}
+
+func fromEnglish(ref VerseRef, to Versification) VerseRef {
+	if r, ok := englishRule(ref, to); ok {
+		return VerseRef{Book: ref.Book, Chapter: r.ToChapter, Verse: ref.Verse + r.Offset}
+	}
+	return ref
+}
+
+func toEnglish(ref VerseRef, from Versification) VerseRef {
+	book := versificationBook(ref.Book)
+	for _, r := range versificationRules[from] {
+		if r.Book != book || r.ToChapter != ref.Chapter {
+			continue
+		}
+		if ref.Verse <= r.Title {
+			return VerseRef{Book: ref.Book, Chapter: r.Chapter}
+		}
+		verse := ref.Verse - r.Offset
+		if verse < r.First || (r.Last > 0 && verse > r.Last) {
+			continue
+		}
+		return VerseRef{Book: ref.Book, Chapter: r.Chapter, Verse: verse}
+	}
+	return ref
+}
+
+// passageSpan is the verses from start to end, or a whole chapter, the one
+// start is in.
+type passageSpan struct {
+	start, end VerseRef
+	whole      bool
+}
+
+// MapReference translates the chapter and verse of a reference, as split by
+// util.ParseVerseReference, from one scheme to another. verse may be empty for
+// a whole chapter, a number, a range or a range into a later chapter. A whole
+// chapter that is split or merged with another becomes a range.
+func MapReference(book, chapter, verse string, from, to Versification) (string, string, error) {
+	if from == to {
+		return chapter, verse, nil
+	}
+	ch, err := parseNumber(chapter)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: invalid chapter %q", ErrInvalidReference, chapter)
+	}
+
+	span := passageSpan{start: VerseRef{Book: book, Chapter: ch}, whole: true}
+	if verse != "" {
+		first, rest, isRange := strings.Cut(verse, "-")
+		v, err := parseNumber(first)
+		if err != nil {
+			return "", "", fmt.Errorf("%w: invalid verse %q", ErrInvalidReference, verse)
+		}
+		span = passageSpan{start: VerseRef{Book: book, Chapter: ch, Verse: v}}
+		span.end = span.start
+		if isRange {
+			endChapter, endVerse := ch, rest
+			if c, v, ok := strings.Cut(rest, ":"); ok {
+				if endChapter, err = parseNumber(c); err != nil {
+					return "", "", fmt.Errorf("%w: invalid verse %q", ErrInvalidReference, verse)
+				}
+				endVerse = v
+			}
+			v, err := parseNumber(endVerse)
+			if err != nil {
+				return "", "", fmt.Errorf("%w: invalid verse %q", ErrInvalidReference, verse)
+			}
+			span.end = VerseRef{Book: book, Chapter: endChapter, Verse: v}
+		}
+	}
+
+	span = spanFromEnglish(spanToEnglish(span, from), to)
+	if span.whole {
+		return strconv.Itoa(span.start.Chapter), "", nil
+	}
+	start, end := span.start, span.end
+	// Titles only some schemes number belong to the first verse
+	start.Verse = max(start.Verse, 1)
+	if end.Chapter == start.Chapter {
+		end.Verse = max(end.Verse, start.Verse)
+	}
+	mapped := strconv.Itoa(start.Verse)
+	switch {
+	case end.Chapter != start.Chapter:
+		mapped += fmt.Sprintf("-%d:%d", end.Chapter, end.Verse)
+	case end.Verse != start.Verse:
+		mapped += fmt.Sprintf("-%d", end.Verse)
+	}
+	return strconv.Itoa(start.Chapter), mapped, nil
+}
+
+func spanToEnglish(span passageSpan, from Versification) passageSpan {
+	if from == VersificationEnglish {
+		return span
+	}
+	if !span.whole {
+		return passageSpan{start: toEnglish(span.start, from), end: toEnglish(span.end, from)}
+	}
+
+	book, chapter := span.start.Book, span.start.Chapter
+	name := versificationBook(book)
+	start := toEnglish(VerseRef{Book: book, Chapter: chapter, Verse: 1}, from)
+	start.Verse = max(start.Verse, 1)
+	var end VerseRef
+	found := false
+	// The last English verse moved into the chapter, or else the verse before
+	// those that move out of the chapter the first verse is in
+	for _, r := range versificationRules[from] {
+		if r.Book == name && r.Last > 0 && r.ToChapter == chapter && (!found || after(VerseRef{Chapter: r.Chapter, Verse: r.Last}, end)) {
+			end, found = VerseRef{Book: book, Chapter: r.Chapter, Verse: r.Last}, true
+		}
+	}
+	if !found {
+		for _, r := range versificationRules[from] {
+			if r.Book == name && r.Last > 0 && r.Chapter == start.Chapter && r.ToChapter != chapter && (!found || r.First-1 < end.Verse) {
+				end, found = VerseRef{Book: book, Chapter: start.Chapter, Verse: r.First - 1}, true
+			}
+		}
+	}
+	if !found {
+		return passageSpan{start: start, whole: true}
+	}
+	return passageSpan{start: start, end: end}
+}
+
+func spanFromEnglish(span passageSpan, to Versification) passageSpan {
+	if to == VersificationEnglish {
+		return span
+	}
+	if !span.whole {
+		return passageSpan{start: fromEnglish(span.start, to), end: fromEnglish(span.end, to)}
+	}
+
+	book, chapter := span.start.Book, span.start.Chapter
+	name := versificationBook(book)
+	first := VerseRef{Book: book, Chapter: chapter, Verse: 1}
+	start := fromEnglish(first, to)
+	if r, ok := englishRule(first, to); ok && r.Title > 0 {
+		start.Verse = 1
+	}
+	var end VerseRef
+	found := false
+	// The last verse of the chapter in the scheme, or else the verse before
+	// those moved into the chapter the first verse is in
+	for _, r := range versificationRules[to] {
+		if r.Book == name && r.Last > 0 && r.Chapter == chapter {
+			if last := fromEnglish(VerseRef{Book: book, Chapter: chapter, Verse: r.Last}, to); !found || after(last, end) {
+				end, found = last, true
+			}
+		}
+	}
+	if !found {
+		for _, r := range versificationRules[to] {
+			if r.Book == name && r.Last > 0 && r.ToChapter == start.Chapter && r.Chapter != chapter && (!found || r.First+r.Offset-1 < end.Verse) {
+				end, found = VerseRef{Book: book, Chapter: start.Chapter, Verse: r.First + r.Offset - 1}, true
+			}
+		}
+	}
+	if !found {
+		return passageSpan{start: start, whole: true}
+	}
+	return passageSpan{start: start, end: end}
+}
+
+func after(a, b VerseRef) bool {
+	if a.Chapter != b.Chapter {
+		return a.Chapter > b.Chapter
+	}
+	return a.Verse > b.Verse
+}
+
+func parseNumber(s string) (int, error) {
+	return strconv.Atoi(strings.TrimSpace(s))
+}
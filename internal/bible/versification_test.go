@@ -0,0 +1,165 @@
+package bible
+
+import "testing"
+
+func TestMapVerse(t *testing.T) {
+	tests := []struct {
+		name    string
+		ref     VerseRef
+		hebrew  VerseRef
+		english VerseRef
+	}{
+		{
+			name:    "unchanged verse",
+			ref:     VerseRef{Book: "John", Chapter: 3, Verse: 16},
+			hebrew:  VerseRef{Book: "John", Chapter: 3, Verse: 16},
+			english: VerseRef{Book: "John", Chapter: 3, Verse: 16},
+		},
+		{
+			name:    "psalm title",
+			ref:     VerseRef{Book: "Psalm", Chapter: 3, Verse: 1},
+			hebrew:  VerseRef{Book: "Psalm", Chapter: 3, Verse: 2},
+			english: VerseRef{Book: "Psalm", Chapter: 3, Verse: 0},
+		},
+		{
+			name:    "two verse psalm title",
+			ref:     VerseRef{Book: "Psalms", Chapter: 51, Verse: 2},
+			hebrew:  VerseRef{Book: "Psalms", Chapter: 51, Verse: 4},
+			english: VerseRef{Book: "Psalms", Chapter: 51, Verse: 0},
+		},
+		{
+			name:    "psalm without title verse",
+			ref:     VerseRef{Book: "Psalms", Chapter: 23, Verse: 1},
+			hebrew:  VerseRef{Book: "Psalms", Chapter: 23, Verse: 1},
+			english: VerseRef{Book: "Psalms", Chapter: 23, Verse: 1},
+		},
+		{
+			name:    "malachi 4",
+			ref:     VerseRef{Book: "Malachi", Chapter: 4, Verse: 5},
+			hebrew:  VerseRef{Book: "Malachi", Chapter: 3, Verse: 23},
+			english: VerseRef{Book: "Malachi", Chapter: 4, Verse: 5},
+		},
+		{
+			name:    "end of malachi 3",
+			ref:     VerseRef{Book: "Malachi", Chapter: 3, Verse: 20},
+			hebrew:  VerseRef{Book: "Malachi", Chapter: 3, Verse: 20},
+			english: VerseRef{Book: "Malachi", Chapter: 4, Verse: 2},
+		},
+		{
+			name:    "joel 2",
+			ref:     VerseRef{Book: "Joel", Chapter: 2, Verse: 28},
+			hebrew:  VerseRef{Book: "Joel", Chapter: 3, Verse: 1},
+			english: VerseRef{Book: "Joel", Chapter: 2, Verse: 28},
+		},
+		{
+			name:    "joel 3",
+			ref:     VerseRef{Book: "Joel", Chapter: 3, Verse: 2},
+			hebrew:  VerseRef{Book: "Joel", Chapter: 4, Verse: 2},
+			english: VerseRef{Book: "Joel", Chapter: 2, Verse: 29},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MapVerse(tt.ref, VersificationEnglish, VersificationHebrew); got != tt.hebrew {
+				t.Errorf("MapVerse(english to hebrew) = %+v, want %+v", got, tt.hebrew)
+			}
+			if got := MapVerse(tt.ref, VersificationHebrew, VersificationEnglish); got != tt.english {
+				t.Errorf("MapVerse(hebrew to english) = %+v, want %+v", got, tt.english)
+			}
+		})
+	}
+}
+
+func TestMapReference(t *testing.T) {
+	tests := []struct {
+		name        string
+		book        string
+		chapter     string
+		verse       string
+		from, to    Versification
+		wantChapter string
+		wantVerse   string
+	}{
+		{"same scheme", "Malachi", "4", "1", VersificationEnglish, VersificationEnglish, "4", "1"},
+		{"range", "Malachi", "4", "1-2", VersificationEnglish, VersificationHebrew, "3", "19-20"},
+		{"range into moved chapter", "Malachi", "3", "16-4:2", VersificationEnglish, VersificationHebrew, "3", "16-20"},
+		{"range out of moved chapter", "Malachi", "3", "16-20", VersificationHebrew, VersificationEnglish, "3", "16-4:2"},
+		{"moved chapter", "Malachi", "4", "", VersificationEnglish, VersificationHebrew, "3", "19-24"},
+		{"split chapter", "Joel", "2", "", VersificationEnglish, VersificationHebrew, "2", "1-3:5"},
+		{"renumbered chapter", "Joel", "3", "", VersificationEnglish, VersificationHebrew, "4", ""},
+		{"hebrew chapter", "Joel", "3", "", VersificationHebrew, VersificationEnglish, "2", "28-32"},
+		{"whole psalm", "Psalm", "3", "", VersificationEnglish, VersificationHebrew, "3", ""},
+		{"psalm verses", "Psalm", "51", "1-2", VersificationEnglish, VersificationHebrew, "51", "3-4"},
+		{"psalm title", "Psalm", "51", "1-3", VersificationHebrew, VersificationEnglish, "51", "1"},
+		{"greek psalm", "Psalm", "23", "1-2", VersificationEnglish, VersificationVulgate, "22", "1-2"},
+		{"greek psalm with title", "Psalm", "51", "1", VersificationEnglish, VersificationSeptuagint, "50", "3"},
+		{"whole greek psalm", "Psalm", "23", "", VersificationEnglish, VersificationVulgate, "22", ""},
+		{"merged psalm", "Psalm", "10", "", VersificationEnglish, VersificationVulgate, "9", "22-39"},
+		{"psalm merged into", "Psalm", "9", "", VersificationEnglish, VersificationVulgate, "9", "1-21"},
+		{"greek merged psalm", "Psalm", "9", "", VersificationVulgate, VersificationEnglish, "9", "1-10:18"},
+		{"split psalm", "Psalm", "116", "", VersificationEnglish, VersificationVulgate, "114", "1-115:10"},
+		{"greek split psalm", "Psalm", "115", "", VersificationVulgate, VersificationEnglish, "116", "10-19"},
+		{"greek to hebrew", "Psalm", "9", "30", VersificationVulgate, VersificationHebrew, "10", "9"},
+		{"whole greek to hebrew", "Psalm", "113", "", VersificationSeptuagint, VersificationHebrew, "114", "1-115:18"},
+		{"vulgate prophets", "Malachi", "4", "1", VersificationEnglish, VersificationVulgate, "4", "1"},
+		{"septuagint prophets", "Malachi", "4", "1", VersificationEnglish, VersificationSeptuagint, "3", "19"},
+		{"hebrew chapter with moved end", "Joel", "2", "", VersificationHebrew, VersificationEnglish, "2", "1-27"},
+		{"chapter with moved end", "Malachi", "3", "", VersificationEnglish, VersificationHebrew, "3", "1-18"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chapter, verse, err := MapReference(tt.book, tt.chapter, tt.verse, tt.from, tt.to)
+			if err != nil {
+				t.Fatalf("MapReference() error = %v", err)
+			}
+			if chapter != tt.wantChapter || verse != tt.wantVerse {
+				t.Errorf("MapReference() = %q, %q, want %q, %q", chapter, verse, tt.wantChapter, tt.wantVerse)
+			}
+		})
+	}
+
+	if _, _, err := MapReference("Joel", "2", "x", VersificationEnglish, VersificationHebrew); err == nil {
+		t.Error("expected error for invalid verse, got nil")
+	}
+}
+
+func TestMapVerse_GreekPsalms(t *testing.T) {
+	tests := []struct {
+		english VerseRef
+		greek   VerseRef
+	}{
+		{VerseRef{Book: "Psalms", Chapter: 1, Verse: 1}, VerseRef{Book: "Psalms", Chapter: 1, Verse: 1}},
+		{VerseRef{Book: "Psalms", Chapter: 3, Verse: 1}, VerseRef{Book: "Psalms", Chapter: 3, Verse: 2}},
+		{VerseRef{Book: "Psalms", Chapter: 9, Verse: 20}, VerseRef{Book: "Psalms", Chapter: 9, Verse: 21}},
+		{VerseRef{Book: "Psalms", Chapter: 10, Verse: 1}, VerseRef{Book: "Psalms", Chapter: 9, Verse: 22}},
+		{VerseRef{Book: "Psalms", Chapter: 23, Verse: 4}, VerseRef{Book: "Psalms", Chapter: 22, Verse: 4}},
+		{VerseRef{Book: "Psalms", Chapter: 115, Verse: 1}, VerseRef{Book: "Psalms", Chapter: 113, Verse: 9}},
+		{VerseRef{Book: "Psalms", Chapter: 116, Verse: 10}, VerseRef{Book: "Psalms", Chapter: 115, Verse: 1}},
+		{VerseRef{Book: "Psalms", Chapter: 117, Verse: 1}, VerseRef{Book: "Psalms", Chapter: 116, Verse: 1}},
+		{VerseRef{Book: "Psalms", Chapter: 147, Verse: 12}, VerseRef{Book: "Psalms", Chapter: 147, Verse: 1}},
+		{VerseRef{Book: "Psalms", Chapter: 148, Verse: 1}, VerseRef{Book: "Psalms", Chapter: 148, Verse: 1}},
+		{VerseRef{Book: "Psalms", Chapter: 12}, VerseRef{Book: "Psalms", Chapter: 11, Verse: 1}},
+	}
+	for _, tt := range tests {
+		if tt.english.Verse > 0 {
+			if got := MapVerse(tt.english, VersificationEnglish, VersificationVulgate); got != tt.greek {
+				t.Errorf("MapVerse(%+v to vulgate) = %+v, want %+v", tt.english, got, tt.greek)
+			}
+		}
+		if got := MapVerse(tt.greek, VersificationVulgate, VersificationEnglish); got != tt.english {
+			t.Errorf("MapVerse(%+v from vulgate) = %+v, want %+v", tt.greek, got, tt.english)
+		}
+	}
+}
+
+func TestParseVersification(t *testing.T) {
+	for input, want := range map[string]Versification{"": VersificationEnglish, "Hebrew": VersificationHebrew, "english": VersificationEnglish, "septuagint": VersificationSeptuagint} {
+		got, err := ParseVersification(input)
+		if err != nil || got != want {
+			t.Errorf("ParseVersification(%q) = %q, %v, want %q", input, got, err, want)
+		}
+	}
+	if _, err := ParseVersification("lxx"); err == nil {
+		t.Error("expected error for unknown versification, got nil")
+	}
+}
@@ -2,12 +2,41 @@ package bible
 
 // Version represents a Bible version configuration.
 type Version struct {
-	Code      string            `json:"code" yaml:"code"`
-	Name      string            `json:"name" yaml:"name"`
-	Language  string            `json:"language" yaml:"language"`
+	Code         string `json:"code" yaml:"code"`
+	Name         string `json:"name" yaml:"name"`
+	Abbreviation string `json:"abbreviation,omitempty" yaml:"abbreviation,omitempty"`
+	Publisher    string `json:"publisher,omitempty" yaml:"publisher,omitempty"`
+	Language     string `json:"language" yaml:"language"`
+	// LanguageCode is the ISO 639-3 code of the language, e.g. "eng".
+	LanguageCode string `json:"language_code,omitempty" yaml:"language_code,omitempty"`
+	// Direction is the text direction of the language: DirectionLTR or DirectionRTL.
+	Direction string `json:"direction,omitempty" yaml:"direction,omitempty"`
+	// Coverage is the part of the Bible the version contains, e.g. CoverageNT.
+	// It is empty when unknown.
+	Coverage string `json:"coverage,omitempty" yaml:"coverage,omitempty"`
+	// Versification is the verse numbering scheme of the version, see
+	// ParseVersification. It is empty for the English numbering.
+	Versification string `json:"versification,omitempty" yaml:"versification,omitempty"`
+	// Canon is the set of books the version contains, e.g. CanonCatholic. It
+	// is empty for CanonProtestant.
+	Canon     string            `json:"canon,omitempty" yaml:"canon,omitempty"`
 	Providers map[string]string `json:"providers" yaml:"providers"`
 }
 
+// Text directions.
+const (
+	DirectionLTR = "ltr"
+	DirectionRTL = "rtl"
+)
+
+// Testament coverage of a version.
+const (
+	CoverageFull     = "full"
+	CoverageOT       = "ot"
+	CoverageNT       = "nt"
+	CoveragePortions = "portions"
+)
+
 
 // ProviderConfig represents a specific provider's configuration for a version.
 type ProviderConfig struct {
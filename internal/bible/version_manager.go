@@ -1,17 +1,47 @@
 package bible
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"log/slog"
 	"os"
+	"sort"
 	"strings"
+	"sync"
+	"time"
+
+	"bible-api-service/internal/circuit"
 
 	"gopkg.in/yaml.v2"
 )
 
+// HealthChecker reports the circuit state of a Bible provider.
+type HealthChecker interface {
+	ProviderState(name string) circuit.State
+}
+
+// CapabilityChecker reports the capabilities of a Bible provider.
+type CapabilityChecker interface {
+	Capabilities(name string) []string
+}
+
+// ConfigRetriever fetches the raw versions config. It matches the retrievers
+// used for feature flags, so config.FallbackRetriever can be used as is.
+type ConfigRetriever interface {
+	Retrieve(ctx context.Context) ([]byte, error)
+}
+
 // VersionManager manages Bible versions and their provider mappings.
+// The versions can be replaced at runtime with Reload or Watch.
 type VersionManager struct {
+	mu       sync.RWMutex
 	versions []Version
 	byCode   map[string]Version
+	// data is the raw config last loaded, so that polling can skip unchanged content.
+	data         []byte
+	health       HealthChecker
+	capabilities CapabilityChecker
 }
 
 // NewVersionManager creates a new VersionManager by loading versions from the config file.
@@ -21,38 +51,260 @@ func NewVersionManager(path string) (*VersionManager, error) {
 		return nil, fmt.Errorf("failed to read versions config: %w", err)
 	}
 
+	vm := &VersionManager{}
+	if err := vm.Reload(data); err != nil {
+		return nil, err
+	}
+	return vm, nil
+}
+
+// parseVersions unmarshals and validates a versions config.
+func parseVersions(data []byte) ([]Version, map[string]Version, error) {
 	var versions []Version
 	if err := yaml.Unmarshal(data, &versions); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal versions config: %w", err)
+		return nil, nil, fmt.Errorf("failed to unmarshal versions config: %w", err)
 	}
 
-	vm := &VersionManager{
-		versions: versions,
-		byCode:   make(map[string]Version),
+	byCode := make(map[string]Version, len(versions))
+	for i, v := range versions {
+		if strings.TrimSpace(v.Code) == "" {
+			return nil, nil, fmt.Errorf("invalid versions config: version %d has no code", i+1)
+		}
+		if _, err := ParseVersification(v.Versification); err != nil {
+			return nil, nil, fmt.Errorf("invalid versions config: version %s: %w", v.Code, err)
+		}
+		switch v.Canon {
+		case "", CanonProtestant, CanonCatholic, CanonOrthodox:
+		default:
+			return nil, nil, fmt.Errorf("invalid versions config: version %s: unsupported canon: %q", v.Code, v.Canon)
+		}
+		code := strings.ToUpper(v.Code)
+		if _, ok := byCode[code]; ok {
+			return nil, nil, fmt.Errorf("invalid versions config: duplicate version code %s", v.Code)
+		}
+		byCode[code] = v
 	}
+	return versions, byCode, nil
+}
 
-	for _, v := range versions {
-		vm.byCode[strings.ToUpper(v.Code)] = v
+// Reload validates a versions config and swaps it in. If the config is
+// invalid, the current versions are kept and the error is returned. An empty
+// config is only accepted if no versions are loaded, since it more likely
+// means a truncated file than the removal of every version.
+func (vm *VersionManager) Reload(data []byte) error {
+	versions, byCode, err := parseVersions(data)
+	if err != nil {
+		return err
 	}
 
-	return vm, nil
+	vm.mu.Lock()
+	if len(versions) == 0 && len(vm.versions) > 0 {
+		vm.mu.Unlock()
+		return fmt.Errorf("invalid versions config: no versions")
+	}
+	vm.versions = versions
+	vm.byCode = byCode
+	vm.data = data
+	vm.mu.Unlock()
+	return nil
+}
+
+// Watch polls the retriever every interval and reloads the versions when the
+// config changes, until ctx is done. Failed retrievals and invalid configs are
+// logged and the last good config stays in use.
+func (vm *VersionManager) Watch(ctx context.Context, retriever ConfigRetriever, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			vm.poll(ctx, retriever)
+		}
+	}
+}
+
+func (vm *VersionManager) poll(ctx context.Context, retriever ConfigRetriever) {
+	data, err := retriever.Retrieve(ctx)
+	if err != nil {
+		slog.Warn("Failed to retrieve versions config, keeping current versions", "error", err)
+		return
+	}
+
+	vm.mu.RLock()
+	unchanged := bytes.Equal(data, vm.data)
+	vm.mu.RUnlock()
+	if unchanged {
+		return
+	}
+
+	if err := vm.Reload(data); err != nil {
+		slog.Error("Invalid versions config, keeping current versions", "error", err)
+		return
+	}
+	slog.Info("Reloaded versions config", "versions", len(vm.GetAll()))
+}
+
+// lookup returns the version with the given code under the read lock.
+func (vm *VersionManager) lookup(code string) (Version, bool) {
+	vm.mu.RLock()
+	defer vm.mu.RUnlock()
+	v, ok := vm.byCode[strings.ToUpper(code)]
+	return v, ok
+}
+
+// SetHealthChecker makes provider selection skip providers whose circuit is open
+// and prefer healthy providers over ones that are recovering.
+func (vm *VersionManager) SetHealthChecker(health HealthChecker) {
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+	vm.health = health
+}
+
+// SetCapabilityChecker makes provider selection skip providers that lack the
+// capability a query needs.
+func (vm *VersionManager) SetCapabilityChecker(capabilities CapabilityChecker) {
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+	vm.capabilities = capabilities
 }
 
 // GetAll returns all available versions.
 func (vm *VersionManager) GetAll() []Version {
-	// Return a copy to avoid mutation?
-	// For now, slice of structs is copy-ish (structs are copied if accessed by value, but slice backing array is shared)
-	// Given we only read, it's fine.
+	// Reload replaces the slice rather than changing it, so callers can keep
+	// reading the one they got.
+	vm.mu.RLock()
+	defer vm.mu.RUnlock()
 	return vm.versions
 }
 
+// Get returns the version with the given unified code.
+func (vm *VersionManager) Get(code string) (Version, bool) {
+	return vm.lookup(code)
+}
+
+// Versification returns the verse numbering scheme of a version. Unknown
+// versions use VersificationEnglish.
+func (vm *VersionManager) Versification(code string) Versification {
+	v, _ := vm.lookup(code)
+	// parseVersions rejects unknown schemes
+	scheme, _ := ParseVersification(v.Versification)
+	return scheme
+}
+
+// CheckBook returns an error wrapping ErrUnsupportedVersion if a reference
+// to chapter of book is in a book the version does not contain, such as
+// Tobit in a Protestant version. Unknown versions and books are left to the
+// providers.
+func (vm *VersionManager) CheckBook(code, book, chapter string) error {
+	v, ok := vm.lookup(code)
+	if !ok {
+		return nil
+	}
+	b, ok := ReferenceBook(book, chapter)
+	if !ok || v.HasBook(b) {
+		return nil
+	}
+	return fmt.Errorf("%w: %s is not in version %s", ErrUnsupportedVersion, b.Name, v.Code)
+}
+
+// ProviderState returns the circuit state of a provider as seen by provider
+// selection. Without a health checker every provider is reported as closed.
+func (vm *VersionManager) ProviderState(name string) circuit.State {
+	vm.mu.RLock()
+	health := vm.health
+	vm.mu.RUnlock()
+	if health == nil {
+		return circuit.Closed
+	}
+	return health.ProviderState(name)
+}
+
+// ProviderCapabilities returns the capabilities of a provider as seen by
+// provider selection. Without a capability checker none are reported.
+func (vm *VersionManager) ProviderCapabilities(name string) []string {
+	vm.mu.RLock()
+	capabilities := vm.capabilities
+	vm.mu.RUnlock()
+	if capabilities == nil {
+		return nil
+	}
+	return capabilities.Capabilities(name)
+}
+
+// providerHas reports whether a provider offers a capability. Without a
+// capability checker every provider is assumed to offer every capability.
+func (vm *VersionManager) providerHas(name, capability string) bool {
+	vm.mu.RLock()
+	capabilities := vm.capabilities
+	vm.mu.RUnlock()
+	if capabilities == nil {
+		return true
+	}
+	for _, c := range capabilities.Capabilities(name) {
+		if c == capability {
+			return true
+		}
+	}
+	return false
+}
+
+// Supports reports whether any provider mapping the version offers the
+// capability, whatever its health.
+func (vm *VersionManager) Supports(v Version, capability string) bool {
+	for provider, code := range v.Providers {
+		if code != "" && vm.providerHas(provider, capability) {
+			return true
+		}
+	}
+	return false
+}
+
+// EquivalentVersions returns the other versions in the same language and
+// verse numbering as the version with the given code that a provider offers
+// the capability for, those with the same canon first. Their references
+// carry over to the version unchanged, so they can stand in for it, e.g. to
+// search a version no provider can search.
+func (vm *VersionManager) EquivalentVersions(code, capability string) []Version {
+	v, ok := vm.lookup(code)
+	if !ok {
+		return nil
+	}
+	scheme := vm.Versification(v.Code)
+
+	var equivalents []Version
+	for _, other := range vm.GetAll() {
+		if other.Code == v.Code || !sameLanguage(v, other) || vm.Versification(other.Code) != scheme {
+			continue
+		}
+		if vm.Supports(other, capability) {
+			equivalents = append(equivalents, other)
+		}
+	}
+	sort.SliceStable(equivalents, func(i, j int) bool {
+		return equivalents[i].Canon == v.Canon && equivalents[j].Canon != v.Canon
+	})
+	return equivalents
+}
+
+// sameLanguage reports whether two versions are in the same language, by
+// ISO code when both have one and by name otherwise.
+func sameLanguage(a, b Version) bool {
+	if a.LanguageCode != "" && b.LanguageCode != "" {
+		return a.LanguageCode == b.LanguageCode
+	}
+	return a.Language != "" && strings.EqualFold(a.Language, b.Language)
+}
+
 // GetProviderCode returns the provider-specific code for a given unified version code.
 func (vm *VersionManager) GetProviderCode(unifiedCode, provider string) (string, error) {
 	if unifiedCode == "" {
 		return "", nil // Or default?
 	}
 
-	v, ok := vm.byCode[strings.ToUpper(unifiedCode)]
+	v, ok := vm.lookup(unifiedCode)
 	if !ok {
 		// If version is not found in our config, assume it's valid and pass it through?
 		// Or strictly enforce?
@@ -69,54 +321,86 @@ func (vm *VersionManager) GetProviderCode(unifiedCode, provider string) (string,
 }
 
 // SelectProvider resolves the best provider and provider-specific code for a unified version code.
-// It iterates through the preferredProviders list and selects the first one that supports the version.
+// It iterates through the preferredProviders list and selects the first healthy one that supports the version,
+// falling back to providers that are recovering. Providers with an open circuit are skipped.
 // If preferredProviders is nil or empty, it defaults to ["biblegateway", "biblehub", "biblenow"].
 func (vm *VersionManager) SelectProvider(unifiedCode string, preferredProviders []string) (string, string, error) {
+	return vm.SelectProviderFor(unifiedCode, CapabilityVerses, preferredProviders)
+}
+
+// SelectProviderFor is SelectProvider for a query that needs a capability:
+// providers that lack it are skipped. The error wraps ErrUnsupportedVersion
+// when no provider of the version offers it.
+func (vm *VersionManager) SelectProviderFor(unifiedCode, capability string, preferredProviders []string) (string, string, error) {
 	if len(preferredProviders) == 0 {
 		preferredProviders = []string{"biblegateway", "biblehub", "biblenow"}
 	}
 
-	v, ok := vm.byCode[strings.ToUpper(unifiedCode)]
+	v, ok := vm.lookup(unifiedCode)
 	if !ok {
-		return "", "", fmt.Errorf("version not found: %s", unifiedCode)
+		return "", "", fmt.Errorf("%w: version not found: %s", ErrUnsupportedVersion, unifiedCode)
 	}
 
+	configs, mapped := vm.rankProviders(v, capability, preferredProviders)
+	if len(configs) > 0 {
+		return configs[0].Name, configs[0].VersionCode, nil
+	}
+	if mapped > 0 {
+		return "", "", fmt.Errorf("%w: no healthy provider available for version: %s", ErrUpstreamUnavailable, unifiedCode)
+	}
+	if capability != CapabilityVerses {
+		return "", "", fmt.Errorf("%w: no provider supports %s for version: %s", ErrUnsupportedVersion, capability, unifiedCode)
+	}
+
+	return "", "", fmt.Errorf("%w: no suitable provider found for version: %s", ErrUnsupportedVersion, unifiedCode)
+}
+
+// rankProviders returns the providers from preferredProviders that map the version
+// and offer the capability, healthy ones first and recovering ones after, skipping
+// open circuits. It also returns how many of them there are regardless of health.
+func (vm *VersionManager) rankProviders(v Version, capability string, preferredProviders []string) ([]ProviderConfig, int) {
+	var healthy, recovering []ProviderConfig
+	mapped := 0
 	for _, provider := range preferredProviders {
-		if code, ok := v.Providers[provider]; ok && code != "" {
-			return provider, code, nil
+		code, ok := v.Providers[provider]
+		if !ok || code == "" || !vm.providerHas(provider, capability) {
+			continue
 		}
-	}
+		mapped++
 
-	return "", "", fmt.Errorf("no suitable provider found for version: %s", unifiedCode)
+		config := ProviderConfig{Name: provider, VersionCode: code}
+		switch vm.ProviderState(provider) {
+		case circuit.Closed:
+			healthy = append(healthy, config)
+		case circuit.HalfOpen:
+			recovering = append(recovering, config)
+		}
+	}
+	return append(healthy, recovering...), mapped
 }
 
 
 // GetPrioritizedProviders returns a list of providers that support the given version,
-// prioritized by the preferredProviders list (or default order).
+// prioritized by health and then by the preferredProviders list (or default order).
 func (vm *VersionManager) GetPrioritizedProviders(unifiedCode string, preferredProviders []string) ([]ProviderConfig, error) {
 	if len(preferredProviders) == 0 {
 		preferredProviders = []string{"biblegateway", "biblehub", "biblenow", "biblecom"}
 	}
 
-	v, ok := vm.byCode[strings.ToUpper(unifiedCode)]
+	v, ok := vm.lookup(unifiedCode)
 	if !ok {
-		return nil, fmt.Errorf("version not found: %s", unifiedCode)
+		return nil, fmt.Errorf("%w: version not found: %s", ErrUnsupportedVersion, unifiedCode)
 	}
 
-	var configs []ProviderConfig
-	for _, provider := range preferredProviders {
-		if code, ok := v.Providers[provider]; ok && code != "" {
-			configs = append(configs, ProviderConfig{
-				Name:        provider,
-				VersionCode: code,
-			})
-		}
+	configs, mapped := vm.rankProviders(v, CapabilityVerses, preferredProviders)
+	if len(configs) == 0 && mapped > 0 {
+		return nil, fmt.Errorf("%w: no healthy providers available for version: %s", ErrUpstreamUnavailable, unifiedCode)
 	}
 
 	if len(configs) == 0 {
 		// Fallback: if no providers explicitly listed, maybe try to match any available?
 		// But for now, if it's in the map it should be found.
-		return nil, fmt.Errorf("no providers available for version: %s", unifiedCode)
+		return nil, fmt.Errorf("%w: no providers available for version: %s", ErrUnsupportedVersion, unifiedCode)
 	}
 
 	return configs, nil
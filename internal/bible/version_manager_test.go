@@ -1,9 +1,17 @@
 package bible
 
 import (
+	"context"
+	"errors"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
+	"time"
+
+	"bible-api-service/internal/circuit"
 )
 
 func TestNewVersionManager(t *testing.T) {
@@ -230,3 +238,399 @@ func TestVersionManager_SelectProvider(t *testing.T) {
 		})
 	}
 }
+
+type stubHealth map[string]circuit.State
+
+func (s stubHealth) ProviderState(name string) circuit.State {
+	return s[name]
+}
+
+func TestVersionManager_SelectProvider_Health(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "versions.yaml")
+	content := []byte(`
+- code: KJV
+  name: King James Version
+  language: English
+  providers:
+    biblegateway: KJV
+    biblehub: kjv
+    biblenow: king-james-version
+`)
+	if err := os.WriteFile(configPath, content, 0644); err != nil {
+		t.Fatalf("failed to create config file: %v", err)
+	}
+
+	vm, err := NewVersionManager(configPath)
+	if err != nil {
+		t.Fatalf("NewVersionManager failed: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		health       stubHealth
+		wantProvider string
+		wantOrder    []string
+		wantErr      bool
+	}{
+		{
+			name:         "all healthy keeps preference order",
+			health:       stubHealth{},
+			wantProvider: "biblegateway",
+			wantOrder:    []string{"biblegateway", "biblehub", "biblenow"},
+		},
+		{
+			name:         "open circuit is skipped",
+			health:       stubHealth{"biblegateway": circuit.Open},
+			wantProvider: "biblehub",
+			wantOrder:    []string{"biblehub", "biblenow"},
+		},
+		{
+			name:         "healthy preferred over recovering",
+			health:       stubHealth{"biblegateway": circuit.HalfOpen, "biblehub": circuit.Open},
+			wantProvider: "biblenow",
+			wantOrder:    []string{"biblenow", "biblegateway"},
+		},
+		{
+			name:    "all open",
+			health:  stubHealth{"biblegateway": circuit.Open, "biblehub": circuit.Open, "biblenow": circuit.Open},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vm.SetHealthChecker(tt.health)
+
+			provider, _, err := vm.SelectProvider("KJV", nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SelectProvider() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if provider != tt.wantProvider {
+				t.Errorf("SelectProvider() provider = %v, want %v", provider, tt.wantProvider)
+			}
+
+			configs, err := vm.GetPrioritizedProviders("KJV", nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetPrioritizedProviders() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var order []string
+			for _, c := range configs {
+				order = append(order, c.Name)
+			}
+			if strings.Join(order, ",") != strings.Join(tt.wantOrder, ",") {
+				t.Errorf("GetPrioritizedProviders() order = %v, want %v", order, tt.wantOrder)
+			}
+		})
+	}
+}
+
+type stubCapabilities map[string][]string
+
+func (s stubCapabilities) Capabilities(name string) []string {
+	return s[name]
+}
+
+func TestVersionManager_SelectProviderFor(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "versions.yaml")
+	content := []byte(`
+- code: KJV
+  name: King James Version
+  language: English
+  providers:
+    biblegateway: KJV
+    biblehub: kjv
+    biblenow: king-james-version
+`)
+	if err := os.WriteFile(configPath, content, 0644); err != nil {
+		t.Fatalf("failed to create config file: %v", err)
+	}
+
+	vm, err := NewVersionManager(configPath)
+	if err != nil {
+		t.Fatalf("NewVersionManager failed: %v", err)
+	}
+
+	// Without a capability checker no provider is skipped
+	if provider, _, err := vm.SelectProviderFor("KJV", CapabilityAudio, nil); err != nil || provider != "biblegateway" {
+		t.Errorf("SelectProviderFor(audio) without checker = %v, %v, want biblegateway", provider, err)
+	}
+
+	vm.SetCapabilityChecker(stubCapabilities{
+		"biblegateway": {CapabilityVerses, CapabilitySearch},
+		"biblehub":     {CapabilityVerses, CapabilitySearch, CapabilityInterlinear},
+		"biblenow":     {CapabilityVerses, CapabilityChapterStructure},
+	})
+	tests := []struct {
+		name         string
+		capability   string
+		health       stubHealth
+		wantProvider string
+		wantErr      error
+	}{
+		{"verses", CapabilityVerses, stubHealth{}, "biblegateway", nil},
+		{"only one provider capable", CapabilityChapterStructure, stubHealth{}, "biblenow", nil},
+		{"capable provider down", CapabilitySearch, stubHealth{"biblegateway": circuit.Open}, "biblehub", nil},
+		{"no capable provider up", CapabilityInterlinear, stubHealth{"biblehub": circuit.Open}, "", ErrUpstreamUnavailable},
+		{"no capable provider", CapabilityAudio, stubHealth{}, "", ErrUnsupportedVersion},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vm.SetHealthChecker(tt.health)
+			provider, _, err := vm.SelectProviderFor("KJV", tt.capability, nil)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SelectProviderFor() error = %v, want %v", err, tt.wantErr)
+			}
+			if provider != tt.wantProvider {
+				t.Errorf("SelectProviderFor() provider = %v, want %v", provider, tt.wantProvider)
+			}
+		})
+	}
+
+	v, _ := vm.Get("KJV")
+	if !vm.Supports(v, CapabilityInterlinear) || vm.Supports(v, CapabilityAudio) {
+		t.Error("Supports does not match the capabilities of the version's providers")
+	}
+	if got := vm.ProviderCapabilities("biblenow"); !reflect.DeepEqual(got, []string{CapabilityVerses, CapabilityChapterStructure}) {
+		t.Errorf("ProviderCapabilities(biblenow) = %v", got)
+	}
+}
+
+func TestVersionManager_EquivalentVersions(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "versions.yaml")
+	content := []byte(`
+- code: NIVUK
+  name: New International Version (Anglicised)
+  language: English
+  language_code: eng
+  providers:
+    biblecom: "113"
+- code: KJV
+  name: King James Version
+  language: English
+  language_code: eng
+  providers:
+    biblegateway: KJV
+- code: NABRE
+  name: New American Bible (Revised Edition)
+  language: English
+  language_code: eng
+  versification: hebrew
+  canon: catholic
+  providers:
+    biblegateway: NABRE
+- code: NRSVCE
+  name: New Revised Standard Version Catholic Edition
+  language: english
+  canon: catholic
+  providers:
+    biblegateway: NRSVCE
+- code: ESV
+  name: English Standard Version
+  language: English
+  language_code: eng
+  providers:
+    biblenow: english-standard-version
+- code: RVR1960
+  name: Reina-Valera 1960
+  language: Spanish
+  language_code: spa
+  providers:
+    biblegateway: RVR1960
+- code: RSVCE
+  name: Revised Standard Version Catholic Edition
+  language: English
+  language_code: eng
+  canon: catholic
+  providers:
+    biblegateway: RSVCE
+`)
+	if err := os.WriteFile(configPath, content, 0644); err != nil {
+		t.Fatalf("failed to create config file: %v", err)
+	}
+	vm, err := NewVersionManager(configPath)
+	if err != nil {
+		t.Fatalf("NewVersionManager failed: %v", err)
+	}
+	vm.SetCapabilityChecker(stubCapabilities{
+		"biblegateway": {CapabilityVerses, CapabilitySearch},
+		"biblenow":     {CapabilityVerses},
+		"biblecom":     {CapabilityVerses},
+	})
+
+	codes := func(versions []Version) []string {
+		var codes []string
+		for _, v := range versions {
+			codes = append(codes, v.Code)
+		}
+		return codes
+	}
+	// The NABRE is numbered differently, the ESV cannot be searched and the
+	// NRSVCE is matched by language name
+	if got, want := codes(vm.EquivalentVersions("NIVUK", CapabilitySearch)), []string{"KJV", "NRSVCE", "RSVCE"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("EquivalentVersions(NIVUK) = %v, want %v", got, want)
+	}
+	// Versions of the same canon come first
+	if got, want := codes(vm.EquivalentVersions("NRSVCE", CapabilitySearch)), []string{"RSVCE", "KJV"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("EquivalentVersions(NRSVCE) = %v, want %v", got, want)
+	}
+	if got := vm.EquivalentVersions("UNKNOWN", CapabilitySearch); got != nil {
+		t.Errorf("EquivalentVersions(UNKNOWN) = %v, want nil", codes(got))
+	}
+}
+
+const reloadConfig = `
+- code: KJV
+  name: King James Version
+  language: English
+  providers:
+    biblegateway: KJV
+`
+
+func TestVersionManager_Reload(t *testing.T) {
+	vm := &VersionManager{}
+	if err := vm.Reload([]byte(reloadConfig)); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+
+	invalid := map[string]string{
+		"invalid yaml":   "invalid: yaml: content: [",
+		"no versions":    "[]",
+		"missing code":   "- name: No Code\n  providers:\n    biblegateway: X\n",
+		"duplicate code": "- code: KJV\n  providers:\n    biblegateway: KJV\n- code: kjv\n  providers:\n    biblehub: kjv\n",
+		"versification":  "- code: KJV\n  versification: vulgar\n  providers:\n    biblegateway: KJV\n",
+		"canon":          "- code: KJV\n  canon: anglican\n  providers:\n    biblegateway: KJV\n",
+	}
+	for name, config := range invalid {
+		t.Run(name, func(t *testing.T) {
+			if err := vm.Reload([]byte(config)); err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			// The last good config is kept
+			if provider, _, err := vm.SelectProvider("KJV", nil); err != nil || provider != "biblegateway" {
+				t.Errorf("SelectProvider() = %q, %v after failed reload", provider, err)
+			}
+		})
+	}
+}
+
+func TestVersionManager_Versification(t *testing.T) {
+	vm := &VersionManager{}
+	config := "- code: KJV\n  providers:\n    biblegateway: KJV\n- code: NABRE\n  versification: hebrew\n  providers:\n    biblegateway: NABRE\n"
+	if err := vm.Reload([]byte(config)); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	for code, want := range map[string]Versification{
+		"KJV":     VersificationEnglish,
+		"nabre":   VersificationHebrew,
+		"UNKNOWN": VersificationEnglish,
+	} {
+		if got := vm.Versification(code); got != want {
+			t.Errorf("Versification(%q) = %q, want %q", code, got, want)
+		}
+	}
+}
+
+func TestVersionManager_CheckBook(t *testing.T) {
+	vm := &VersionManager{}
+	config := "- code: KJV\n  providers:\n    biblegateway: KJV\n- code: NRSVCE\n  canon: catholic\n  providers:\n    biblegateway: NRSVCE\n- code: NRSVUE\n  canon: orthodox\n  providers:\n    biblegateway: NRSVUE\n"
+	if err := vm.Reload([]byte(config)); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	tests := []struct {
+		code, book, chapter string
+		wantErr             bool
+	}{
+		{"KJV", "John", "3", false},
+		{"KJV", "Tobit", "1", true},
+		{"KJV", "Psalm", "150", false},
+		{"KJV", "Psalm", "151", true},
+		{"NRSVCE", "Sirach", "1", false},
+		{"NRSVCE", "1 Macc", "1", false},
+		{"NRSVCE", "3 Maccabees", "1", true},
+		{"NRSVCE", "Psalm", "151", true},
+		{"NRSVUE", "Prayer of Manasseh", "1", false},
+		{"NRSVUE", "Psalm", "151", false},
+		{"UNKNOWN", "Tobit", "1", false},
+		{"KJV", "Hezekiah", "1", false},
+	}
+	for _, tt := range tests {
+		err := vm.CheckBook(tt.code, tt.book, tt.chapter)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("CheckBook(%q, %q, %q) = %v, wantErr %v", tt.code, tt.book, tt.chapter, err, tt.wantErr)
+		}
+		if err != nil && !errors.Is(err, ErrUnsupportedVersion) {
+			t.Errorf("CheckBook(%q, %q, %q) = %v, want ErrUnsupportedVersion", tt.code, tt.book, tt.chapter, err)
+		}
+	}
+}
+
+type stubRetriever struct {
+	mu   sync.Mutex
+	data []byte
+	err  error
+}
+
+func (r *stubRetriever) set(data string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.data, r.err = []byte(data), err
+}
+
+func (r *stubRetriever) Retrieve(ctx context.Context) ([]byte, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.data, r.err
+}
+
+func TestVersionManager_Watch(t *testing.T) {
+	vm := &VersionManager{}
+	if err := vm.Reload([]byte(reloadConfig)); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+
+	retriever := &stubRetriever{}
+	retriever.set(reloadConfig+"- code: ESV\n  name: English Standard Version\n  providers:\n    biblehub: esv\n", nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go vm.Watch(ctx, retriever, 5*time.Millisecond)
+
+	waitFor := func(want int) {
+		t.Helper()
+		deadline := time.Now().Add(time.Second)
+		for len(vm.GetAll()) != want {
+			if time.Now().After(deadline) {
+				t.Fatalf("expected %d versions, got %d", want, len(vm.GetAll()))
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+	waitFor(2)
+
+	// Failed retrievals and invalid configs keep the last good config
+	retriever.set("", errors.New("unavailable"))
+	time.Sleep(20 * time.Millisecond)
+	retriever.set("[]", nil)
+	time.Sleep(20 * time.Millisecond)
+	waitFor(2)
+
+	// Concurrent readers see either config, never a partial one
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if _, _, err := vm.SelectProvider("KJV", nil); err != nil {
+					t.Errorf("SelectProvider() error = %v", err)
+					return
+				}
+			}
+		}()
+	}
+	retriever.set(reloadConfig, nil)
+	waitFor(1)
+	wg.Wait()
+}
@@ -0,0 +1,82 @@
+package bible
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// InterlinearWord is a word of the original text with its lexical data.
+type InterlinearWord struct {
+	Original        string `json:"original"`
+	Transliteration string `json:"transliteration"`
+	// Strongs is the word's Strong's number, e.g. "G25", or empty for words
+	// without one.
+	Strongs string `json:"strongs,omitempty"`
+	// Parsing is the word's grammatical form, e.g. "V-AIA-3S".
+	Parsing string `json:"parsing,omitempty"`
+	Gloss   string `json:"gloss"`
+}
+
+// InterlinearVerse is a verse of the original text, word by word.
+type InterlinearVerse struct {
+	// Reference is the verse in the English numbering, e.g. "John 3:16".
+	Reference string            `json:"reference"`
+	Words     []InterlinearWord `json:"words"`
+}
+
+// LexiconEntry is the entry of a Strong's number in a lexicon.
+type LexiconEntry struct {
+	Strongs         string `json:"strongs"`
+	Lemma           string `json:"lemma"`
+	Transliteration string `json:"transliteration"`
+	Pronunciation   string `json:"pronunciation,omitempty"`
+	PartOfSpeech    string `json:"part_of_speech,omitempty"`
+	Definition      string `json:"definition"`
+	Usage           string `json:"usage,omitempty"`
+}
+
+// InterlinearProvider is implemented by providers that offer the original
+// text of a passage word by word. References use the English numbering.
+type InterlinearProvider interface {
+	GetInterlinear(book, chapter, verse string) ([]InterlinearVerse, error)
+}
+
+// LexiconProvider is implemented by providers that offer Strong's lexicon entries.
+type LexiconProvider interface {
+	GetLexiconEntry(strongs string) (LexiconEntry, error)
+}
+
+// GetInterlinear fetches an interlinear passage from p.
+func GetInterlinear(p Provider, book, chapter, verse string) ([]InterlinearVerse, error) {
+	ip, ok := p.(InterlinearProvider)
+	if !ok {
+		return nil, fmt.Errorf("%w: provider has no interlinear text", ErrUnsupportedVersion)
+	}
+	return ip.GetInterlinear(book, chapter, verse)
+}
+
+// GetLexiconEntry fetches the lexicon entry of a Strong's number from p.
+func GetLexiconEntry(p Provider, strongs string) (LexiconEntry, error) {
+	lp, ok := p.(LexiconProvider)
+	if !ok {
+		return LexiconEntry{}, fmt.Errorf("%w: provider has no lexicon", ErrUnsupportedVersion)
+	}
+	return lp.GetLexiconEntry(strongs)
+}
+
+var strongsPattern = regexp.MustCompile(`^([GHgh])0*(\d+)$`)
+
+// ParseStrongs normalizes a Strong's number, e.g. "g0025" to "G25". Greek
+// numbers start with G and Hebrew ones with H.
+func ParseStrongs(s string) (string, error) {
+	m := strongsPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return "", fmt.Errorf("%w: invalid Strong's number: %q", ErrInvalidReference, s)
+	}
+	if n, _ := strconv.Atoi(m[2]); n == 0 {
+		return "", fmt.Errorf("%w: invalid Strong's number: %q", ErrInvalidReference, s)
+	}
+	return strings.ToUpper(m[1]) + m[2], nil
+}
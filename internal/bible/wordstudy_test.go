@@ -0,0 +1,67 @@
+package bible
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"bible-api-service/internal/metrics"
+)
+
+type wordStudyProvider struct {
+	MockProvider
+	verses []InterlinearVerse
+	entry  LexiconEntry
+}
+
+func (p *wordStudyProvider) GetInterlinear(book, chapter, verse string) ([]InterlinearVerse, error) {
+	return p.verses, nil
+}
+
+func (p *wordStudyProvider) GetLexiconEntry(strongs string) (LexiconEntry, error) {
+	return p.entry, nil
+}
+
+func TestGetInterlinear(t *testing.T) {
+	want := []InterlinearVerse{{Reference: "John 3:16", Words: []InterlinearWord{{Original: "Οὕτως", Strongs: "G3779", Gloss: "so"}}}}
+	p := NewObservedProvider("test", &wordStudyProvider{verses: want}, metrics.NewRegistry(time.Minute))
+	verses, err := GetInterlinear(p, "John", "3", "16")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(verses) != 1 || verses[0].Words[0] != want[0].Words[0] {
+		t.Errorf("expected %+v, got %+v", want, verses)
+	}
+
+	_, err = GetInterlinear(NewObservedProvider("test", &MockProvider{}, metrics.NewRegistry(time.Minute)), "John", "3", "16")
+	if !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("expected ErrUnsupportedVersion, got %v", err)
+	}
+}
+
+func TestGetLexiconEntry(t *testing.T) {
+	want := LexiconEntry{Strongs: "G25", Lemma: "ἀγαπάω", Definition: "to love"}
+	p := NewObservedProvider("test", &wordStudyProvider{entry: want}, metrics.NewRegistry(time.Minute))
+	entry, err := GetLexiconEntry(p, "G25")
+	if err != nil || entry != want {
+		t.Errorf("GetLexiconEntry() = %+v, %v, want %+v", entry, err, want)
+	}
+
+	if _, err := GetLexiconEntry(&MockProvider{}, "G25"); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("expected ErrUnsupportedVersion, got %v", err)
+	}
+}
+
+func TestParseStrongs(t *testing.T) {
+	for input, want := range map[string]string{"G25": "G25", "g0025": "G25", " H7225 ": "H7225", "h1": "H1"} {
+		got, err := ParseStrongs(input)
+		if err != nil || got != want {
+			t.Errorf("ParseStrongs(%q) = %q, %v, want %q", input, got, err, want)
+		}
+	}
+	for _, input := range []string{"", "25", "G", "G0", "A25", "G25a"} {
+		if _, err := ParseStrongs(input); !errors.Is(err, ErrInvalidReference) {
+			t.Errorf("ParseStrongs(%q) error = %v, want ErrInvalidReference", input, err)
+		}
+	}
+}
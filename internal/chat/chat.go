@@ -4,10 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"strings"
 
 	"bible-api-service/internal/bible"
 	"bible-api-service/internal/llm/provider"
+	"bible-api-service/internal/logging"
 	"bible-api-service/internal/util"
 
 	"github.com/xeipuuv/gojsonschema"
@@ -18,14 +20,37 @@ const (
 	promptHistoryItemFormat   = "- %s\n"
 	promptHeaderVerses        = "\n\nBible Verses:\n"
 	promptHeaderSearchResults = "\n\nRelevant Search Results:\n"
-	promptInstructionHTML     = "\n\nPlease format your response using semantic HTML."
+	promptHeaderInterlinear   = "\n\nOriginal Language Text:\n"
+	promptHeaderLexicon       = "\n\nLexicon Entries:\n"
+	promptHeaderTopics        = "\n\nTopical References:\n"
+	promptInstructionFormat   = "\n\nPlease format your response using %s."
 	promptItemFormat          = "%s: %s"
 	promptSectionSeparator    = "\n\n"
 )
 
+// formatNames names the formats an LLM can answer in, as prompts refer to them.
+var formatNames = map[bible.Format]string{
+	bible.FormatHTML:     "semantic HTML",
+	bible.FormatMarkdown: "Markdown",
+	bible.FormatPlain:    "plain text",
+}
+
+// FormatName returns how prompts refer to format, and whether an LLM can be
+// asked to answer in it. The empty format is HTML.
+func FormatName(format bible.Format) (string, bool) {
+	if format == "" {
+		format = bible.FormatHTML
+	}
+	name, ok := formatNames[format]
+	return name, ok
+}
+
 // BibleProviderRegistry defines the interface for retrieving Bible providers.
 type BibleProviderRegistry interface {
 	GetProvider(name string) (bible.Provider, error)
+	// ProviderWith returns a provider offering a capability, for data that
+	// does not depend on the version.
+	ProviderWith(capability string) (bible.Provider, error)
 }
 
 // GetLLMClient defines the function signature for getting an LLM client.
@@ -56,6 +81,22 @@ type Request struct {
 	AIProvider string   `json:"ai_provider"`
 	Stream     bool     `json:"stream"`
 	History    []string `json:"history"`
+	// Interlinear lists passages whose original text is quoted word by word,
+	// and Strongs the Strong's numbers whose lexicon entries are quoted. Both
+	// come from providers with the capabilities, whatever the provider.
+	Interlinear []string `json:"interlinear"`
+	Strongs     []string `json:"strongs"`
+	// Topics lists topics whose verses are quoted, looked up in the topical
+	// index of a provider with one or else searched for in Version.
+	Topics []string `json:"topics"`
+	// Format is the format of the response text, and of the verses quoted in
+	// the prompt. It must be one that FormatName accepts; empty means HTML.
+	Format bible.Format `json:"format"`
+	// Versification is the verse numbering of Version and RefVersification
+	// that of VerseRefs and Interlinear, which are translated before they are
+	// fetched. Empty means the English numbering.
+	Versification    bible.Versification `json:"versification"`
+	RefVersification bible.Versification `json:"ref_versification"`
 }
 
 // Response represents the structured output from the LLM.
@@ -71,6 +112,11 @@ type Result struct {
 
 // Process handles the chat request.
 func (s *ChatService) Process(ctx context.Context, req Request) (*Result, error) {
+	formatName, ok := FormatName(req.Format)
+	if !ok {
+		return nil, fmt.Errorf("unsupported response format: %s", req.Format)
+	}
+
 	// Get the provider
 	bibleProvider, err := s.BibleProviderRegistry.GetProvider(req.Provider)
 	if err != nil {
@@ -85,19 +131,28 @@ func (s *ChatService) Process(ctx context.Context, req Request) (*Result, error)
 			return nil, fmt.Errorf("invalid verse reference format (%s): %w", verseRef, err)
 		}
 
+		chapter, verseNum, err = bible.MapReference(book, chapter, verseNum, req.RefVersification, req.Versification)
+		if err != nil {
+			return nil, fmt.Errorf("invalid verse reference (%s): %w", verseRef, err)
+		}
+
 		verseHTML, err := bibleProvider.GetVerse(book, chapter, verseNum, req.Version)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get verse %s: %w", verseRef, err)
 		}
 
-		// 2. Keep the verse HTML content to preserve structure/poetry
-		verseTexts = append(verseTexts, fmt.Sprintf(promptItemFormat, verseRef, verseHTML))
+		// 2. Keep the verse structure/poetry, in the format the response should use
+		verseText, err := bible.Render(verseHTML, req.Format, bible.Reference{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to render verse %s: %w", verseRef, err)
+		}
+		verseTexts = append(verseTexts, fmt.Sprintf(promptItemFormat, verseRef, verseText))
 	}
 
 	// 3. Search for words and add to context
 	var searchResults []string
 	for _, word := range req.Words {
-		results, err := bibleProvider.SearchWords(word, req.Version)
+		results, err := bible.Search(bibleProvider, word, req.Version)
 		if err != nil {
 			return nil, fmt.Errorf("failed to search word %s: %w", word, err)
 		}
@@ -106,6 +161,22 @@ func (s *ChatService) Process(ctx context.Context, req Request) (*Result, error)
 		}
 	}
 
+	// 3a. Look topics up and add their best ranked verses
+	var topics []string
+	for _, name := range req.Topics {
+		topic, err := s.findTopic(bibleProvider, name, req.Version)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up topic %s: %w", name, err)
+		}
+		topics = append(topics, formatTopic(topic))
+	}
+
+	// 3b. Add the original text and lexicon entries for word studies
+	interlinear, lexicon, err := s.wordStudy(req)
+	if err != nil {
+		return nil, err
+	}
+
 	// 4. Add the text content to the chat context for llm
 	var promptBuilder strings.Builder
 
@@ -126,10 +197,26 @@ func (s *ChatService) Process(ctx context.Context, req Request) (*Result, error)
 		promptBuilder.WriteString(strings.Join(searchResults, promptSectionSeparator))
 	}
 
-	// Append instruction to return semantic HTML
-	promptBuilder.WriteString(promptInstructionHTML)
+	if len(topics) > 0 {
+		promptBuilder.WriteString(promptHeaderTopics)
+		promptBuilder.WriteString(strings.Join(topics, promptSectionSeparator))
+	}
+
+	if len(interlinear) > 0 {
+		promptBuilder.WriteString(promptHeaderInterlinear)
+		promptBuilder.WriteString(strings.Join(interlinear, promptSectionSeparator))
+	}
+
+	if len(lexicon) > 0 {
+		promptBuilder.WriteString(promptHeaderLexicon)
+		promptBuilder.WriteString(strings.Join(lexicon, promptSectionSeparator))
+	}
+
+	// Append instruction to answer in the requested format
+	promptBuilder.WriteString(fmt.Sprintf(promptInstructionFormat, formatName))
 
 	llmPrompt := promptBuilder.String()
+	slog.DebugContext(ctx, "Built LLM prompt", logging.Prompt(llmPrompt))
 
 	// 5. Refer to the system prompt specified by the request, and send this
 	llmClient, err := s.GetLLMClient()
@@ -192,6 +279,116 @@ func (s *ChatService) Process(ctx context.Context, req Request) (*Result, error)
 	}
 }
 
+// maxPromptTopicVerses is the number of best ranked verses quoted per topic.
+const maxPromptTopicVerses = 10
+
+// findTopic looks a topic up in the topical index, or searches for it in
+// version through search if there is no index.
+func (s *ChatService) findTopic(search bible.Provider, topic, version string) (bible.Topic, error) {
+	index, err := s.BibleProviderRegistry.ProviderWith(bible.CapabilityTopics)
+	if err != nil {
+		index = nil
+	}
+	return bible.FindTopic(index, func(topic string) ([]bible.SearchResult, error) {
+		return bible.Search(search, topic, version)
+	}, topic)
+}
+
+// formatTopic formats a topic for the prompt: its definition, if any, and its
+// best ranked verses, e.g. "Forgiveness: Matthew 6:14 (Of injuries); ...".
+func formatTopic(topic bible.Topic) string {
+	verses := topic.Verses
+	if len(verses) > maxPromptTopicVerses {
+		verses = verses[:maxPromptTopicVerses]
+	}
+	refs := make([]string, len(verses))
+	for i, v := range verses {
+		refs[i] = v.Reference
+		if v.Note != "" {
+			refs[i] += " (" + v.Note + ")"
+		}
+		if v.Text != "" {
+			refs[i] += " " + v.Text
+		}
+	}
+	text := strings.Join(refs, "; ")
+	if topic.Definition != "" {
+		text = topic.Definition + " Verses: " + text
+	}
+	return fmt.Sprintf(promptItemFormat, topic.Topic, text)
+}
+
+// wordStudy fetches the interlinear passages and lexicon entries of a request
+// and formats them for the prompt, one item per verse or entry.
+func (s *ChatService) wordStudy(req Request) (interlinear, lexicon []string, err error) {
+	if len(req.Interlinear) > 0 {
+		p, err := s.BibleProviderRegistry.ProviderWith(bible.CapabilityInterlinear)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get interlinear provider: %w", err)
+		}
+		if interlinear, err = formatInterlinear(p, req); err != nil {
+			return nil, nil, err
+		}
+	}
+	if len(req.Strongs) > 0 {
+		p, err := s.BibleProviderRegistry.ProviderWith(bible.CapabilityLexicon)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get lexicon provider: %w", err)
+		}
+		if lexicon, err = formatLexicon(p, req.Strongs); err != nil {
+			return nil, nil, err
+		}
+	}
+	return interlinear, lexicon, nil
+}
+
+// formatInterlinear fetches the interlinear passages of a request from p and
+// formats them for the prompt, one item per verse.
+func formatInterlinear(p bible.Provider, req Request) ([]string, error) {
+	var interlinear []string
+	for _, ref := range req.Interlinear {
+		book, chapter, verseNum, err := util.ParseVerseReference(ref)
+		if err != nil {
+			return nil, fmt.Errorf("invalid verse reference format (%s): %w", ref, err)
+		}
+		// Interlinear pages use the English numbering
+		chapter, verseNum, err = bible.MapReference(book, chapter, verseNum, req.RefVersification, bible.VersificationEnglish)
+		if err != nil {
+			return nil, fmt.Errorf("invalid verse reference (%s): %w", ref, err)
+		}
+		verses, err := bible.GetInterlinear(p, book, chapter, verseNum)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get interlinear %s: %w", ref, err)
+		}
+		for _, v := range verses {
+			words := make([]string, len(v.Words))
+			for i, word := range v.Words {
+				words[i] = fmt.Sprintf("%s (%s, %s, %s) %q", word.Original, word.Transliteration, word.Strongs, word.Parsing, word.Gloss)
+			}
+			interlinear = append(interlinear, fmt.Sprintf(promptItemFormat, v.Reference, strings.Join(words, "; ")))
+		}
+	}
+	return interlinear, nil
+}
+
+// formatLexicon fetches the lexicon entries of Strong's numbers from p and
+// formats them for the prompt, one item per entry.
+func formatLexicon(p bible.Provider, numbers []string) ([]string, error) {
+	var lexicon []string
+	for _, number := range numbers {
+		entry, err := bible.GetLexiconEntry(p, number)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get lexicon entry %s: %w", number, err)
+		}
+		text := fmt.Sprintf("%s (%s, %s): %s", entry.Lemma, entry.Transliteration, entry.PartOfSpeech, entry.Definition)
+		if entry.Usage != "" {
+			text += ". " + entry.Usage
+		}
+		lexicon = append(lexicon, fmt.Sprintf(promptItemFormat, entry.Strongs, text))
+	}
+	return lexicon, nil
+}
+
 // formatHistory formats the history list into a string, limiting to the last N entries.
 func formatHistory(history []string) string {
 	const maxHistory = 6
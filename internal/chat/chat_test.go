@@ -3,6 +3,7 @@ package chat
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -51,6 +52,14 @@ func (m *MockBibleProviderRegistry) GetProvider(name string) (bible.Provider, er
 	return args.Get(0).(bible.Provider), args.Error(1)
 }
 
+func (m *MockBibleProviderRegistry) ProviderWith(capability string) (bible.Provider, error) {
+	args := m.Called(capability)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(bible.Provider), args.Error(1)
+}
+
 // MockLLMClient is a mock type for the LLMClient interface
 type MockLLMClient struct {
 	mock.Mock
@@ -469,3 +478,205 @@ func TestChatService_Process_WithHistory(t *testing.T) {
 	mockProvider.AssertExpectations(t)
 	mockLLMClient.AssertExpectations(t)
 }
+
+func TestChatService_Process_Format(t *testing.T) {
+	mockRegistry := new(MockBibleProviderRegistry)
+	mockProvider := new(MockProvider)
+	mockLLMClient := new(MockLLMClient)
+
+	chatService := NewChatService(mockRegistry, func() (provider.LLMClient, error) {
+		return mockLLMClient, nil
+	})
+
+	req := Request{
+		VerseRefs: []string{"Psalm 23:1"},
+		Version:   "ESV",
+		Provider:  "biblegateway",
+		Prompt:    "Explain this verse.",
+		Format:    bible.FormatMarkdown,
+	}
+
+	mockRegistry.On("GetProvider", "biblegateway").Return(mockProvider, nil)
+	mockProvider.On("GetVerse", "Psalm", "23", "1", "ESV").Return(`<p><sup>1 </sup>The <span class="divine-name">Lord</span> is my shepherd;</p>`, nil)
+
+	mockLLMClient.On("Query", mock.Anything, mock.MatchedBy(func(prompt string) bool {
+		return strings.Contains(prompt, "Psalm 23:1: **1** The LORD is my shepherd;") &&
+			strings.Contains(prompt, "Please format your response using Markdown.")
+	}), "").Return(`{"text": "The LORD cares for you."}`, "mock-provider", nil)
+
+	result, err := chatService.Process(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, "The LORD cares for you.", result.Data["text"])
+
+	_, err = chatService.Process(context.Background(), Request{Provider: "biblegateway", Prompt: "Explain", Format: bible.FormatUSFM})
+	assert.Error(t, err)
+}
+
+func TestChatService_Process_Versification(t *testing.T) {
+	mockRegistry := new(MockBibleProviderRegistry)
+	mockProvider := new(MockProvider)
+	mockLLMClient := new(MockLLMClient)
+
+	chatService := NewChatService(mockRegistry, func() (provider.LLMClient, error) {
+		return mockLLMClient, nil
+	})
+
+	req := Request{
+		VerseRefs:     []string{"Psalm 23:1"},
+		Version:       "DRA",
+		Provider:      "biblegateway",
+		Prompt:        "Explain this verse.",
+		Versification: bible.VersificationVulgate,
+	}
+
+	mockRegistry.On("GetProvider", "biblegateway").Return(mockProvider, nil)
+	// The Douay-Rheims numbers Psalm 23 as 22
+	mockProvider.On("GetVerse", "Psalm", "22", "1", "DRA").Return(`The Lord ruleth me: and I shall want nothing.`, nil)
+
+	mockLLMClient.On("Query", mock.Anything, mock.MatchedBy(func(prompt string) bool {
+		return strings.Contains(prompt, "Psalm 23:1: The Lord ruleth me")
+	}), mock.Anything).Return(`{"text": "The Lord provides."}`, "mock-provider", nil)
+
+	result, err := chatService.Process(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, "The Lord provides.", result.Data["text"])
+	mockProvider.AssertExpectations(t)
+}
+
+// MockWordStudyProvider is a mock provider that also offers interlinear text and lexicon entries
+type MockWordStudyProvider struct {
+	MockProvider
+}
+
+func (m *MockWordStudyProvider) GetInterlinear(book, chapter, verse string) ([]bible.InterlinearVerse, error) {
+	args := m.Called(book, chapter, verse)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]bible.InterlinearVerse), args.Error(1)
+}
+
+func (m *MockWordStudyProvider) GetLexiconEntry(strongs string) (bible.LexiconEntry, error) {
+	args := m.Called(strongs)
+	return args.Get(0).(bible.LexiconEntry), args.Error(1)
+}
+
+func TestChatService_Process_WordStudy(t *testing.T) {
+	mockRegistry := new(MockBibleProviderRegistry)
+	mockProvider := new(MockProvider)
+	mockHub := new(MockWordStudyProvider)
+	mockLLMClient := new(MockLLMClient)
+
+	chatService := NewChatService(mockRegistry, func() (provider.LLMClient, error) {
+		return mockLLMClient, nil
+	})
+
+	req := Request{
+		Interlinear:      []string{"Psalm 23:1"},
+		Strongs:          []string{"H7462"},
+		Version:          "ESV",
+		Provider:         "biblegateway",
+		Prompt:           "What does shepherd mean here?",
+		RefVersification: bible.VersificationHebrew,
+	}
+
+	mockRegistry.On("GetProvider", "biblegateway").Return(mockProvider, nil)
+	mockRegistry.On("ProviderWith", bible.CapabilityInterlinear).Return(mockHub, nil)
+	mockRegistry.On("ProviderWith", bible.CapabilityLexicon).Return(mockHub, nil)
+	// Psalm 23 has no title verse, so the numbering is the same
+	mockHub.On("GetInterlinear", "Psalm", "23", "1").Return([]bible.InterlinearVerse{{
+		Reference: "Psalms 23:1",
+		Words: []bible.InterlinearWord{
+			{Original: "יְהוָ֥ה", Transliteration: "Yah·weh", Strongs: "H3068", Parsing: "N-proper-ms", Gloss: "The LORD"},
+			{Original: "רֹ֝עִ֗י", Transliteration: "rō·‘î", Strongs: "H7462", Parsing: "V-Qal-Prtcpl-msc | 1cs", Gloss: "[is] my shepherd"},
+		},
+	}}, nil)
+	mockHub.On("GetLexiconEntry", "H7462").Return(bible.LexiconEntry{
+		Strongs: "H7462", Lemma: "רָעָה", Transliteration: "raah", PartOfSpeech: "Verb", Definition: "to pasture, tend, graze",
+	}, nil)
+
+	expectedInterlinear := "Original Language Text:\nPsalms 23:1: יְהוָ֥ה (Yah·weh, H3068, N-proper-ms) \"The LORD\"; רֹ֝עִ֗י (rō·‘î, H7462, V-Qal-Prtcpl-msc | 1cs) \"[is] my shepherd\""
+	expectedLexicon := "Lexicon Entries:\nH7462: רָעָה (raah, Verb): to pasture, tend, graze"
+
+	mockLLMClient.On("Query", mock.Anything, mock.MatchedBy(func(prompt string) bool {
+		return strings.Contains(prompt, expectedInterlinear) && strings.Contains(prompt, expectedLexicon)
+	}), "").Return(`{"text": "A shepherd tends the flock."}`, "mock-provider", nil)
+
+	result, err := chatService.Process(context.Background(), req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "A shepherd tends the flock.", result.Data["text"])
+	mockHub.AssertExpectations(t)
+	mockLLMClient.AssertExpectations(t)
+}
+
+func TestChatService_Process_WordStudyUnsupported(t *testing.T) {
+	mockRegistry := new(MockBibleProviderRegistry)
+	mockProvider := new(MockProvider)
+	chatService := NewChatService(mockRegistry, func() (provider.LLMClient, error) {
+		return new(MockLLMClient), nil
+	})
+
+	mockRegistry.On("GetProvider", "biblegateway").Return(mockProvider, nil)
+	mockRegistry.On("ProviderWith", bible.CapabilityLexicon).Return(nil, fmt.Errorf("%w: no provider supports lexicon", bible.ErrUnsupportedVersion))
+
+	_, err := chatService.Process(context.Background(), Request{Strongs: []string{"G25"}, Provider: "biblegateway", Prompt: "Define love"})
+	assert.ErrorIs(t, err, bible.ErrUnsupportedVersion)
+}
+
+// MockTopicProvider is a mock provider with a topical index
+type MockTopicProvider struct {
+	MockProvider
+}
+
+func (m *MockTopicProvider) LookupTopic(topic string) (bible.Topic, error) {
+	args := m.Called(topic)
+	return args.Get(0).(bible.Topic), args.Error(1)
+}
+
+func TestChatService_Process_Topics(t *testing.T) {
+	mockRegistry := new(MockBibleProviderRegistry)
+	mockProvider := new(MockProvider)
+	mockIndex := new(MockTopicProvider)
+	mockLLMClient := new(MockLLMClient)
+
+	chatService := NewChatService(mockRegistry, func() (provider.LLMClient, error) {
+		return mockLLMClient, nil
+	})
+
+	req := Request{
+		Topics:   []string{"forgiveness", "kindness"},
+		Version:  "NASB",
+		Provider: "biblenow",
+		Prompt:   "Plan a lesson on forgiveness",
+	}
+
+	mockRegistry.On("GetProvider", "biblenow").Return(mockProvider, nil)
+	mockRegistry.On("ProviderWith", bible.CapabilityTopics).Return(mockIndex, nil)
+	mockIndex.On("LookupTopic", "forgiveness").Return(bible.Topic{
+		Topic:      "forgiveness",
+		Definition: "The pardon of an offense.",
+		Source:     bible.TopicSourceIndex,
+		Verses: []bible.TopicVerse{
+			{Reference: "Matthew 6:14", Note: "Of injuries", Rank: 1},
+			{Reference: "Luke 6:37", Rank: 2},
+		},
+	}, nil)
+	// Topics missing from the index are searched for in the version
+	mockIndex.On("LookupTopic", "kindness").Return(bible.Topic{}, fmt.Errorf("topic %w", bible.ErrNotFound))
+	mockProvider.On("SearchWords", "kindness", "NASB").Return([]bible.SearchResult{{Verse: "Eph 4:32", Text: "Be kind to one another"}}, nil)
+
+	expectedTopics := "Topical References:\nforgiveness: The pardon of an offense. Verses: Matthew 6:14 (Of injuries); Luke 6:37\n\nkindness: Ephesians 4:32 Be kind to one another"
+
+	mockLLMClient.On("Query", mock.Anything, mock.MatchedBy(func(prompt string) bool {
+		return strings.Contains(prompt, expectedTopics)
+	}), "").Return(`{"text": "Start with Matthew 6."}`, "mock-provider", nil)
+
+	result, err := chatService.Process(context.Background(), req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Start with Matthew 6.", result.Data["text"])
+	mockIndex.AssertExpectations(t)
+	mockProvider.AssertExpectations(t)
+	mockLLMClient.AssertExpectations(t)
+}
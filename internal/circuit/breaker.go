@@ -0,0 +1,302 @@
+package circuit
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrOpen is returned when a call is rejected because the circuit is open.
+var ErrOpen = errors.New("circuit breaker is open")
+
+// State is the state of a circuit breaker.
+type State int
+
+const (
+	// Closed lets all calls through while tracking their outcome.
+	Closed State = iota
+	// HalfOpen lets a limited number of probe calls through to test recovery.
+	HalfOpen
+	// Open rejects all calls until the open timeout elapses.
+	Open
+)
+
+// String returns the lower-case name of the state.
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case HalfOpen:
+		return "half-open"
+	case Open:
+		return "open"
+	default:
+		return "unknown"
+	}
+}
+
+// Config controls when a breaker trips and recovers.
+type Config struct {
+	// Window is the rolling window over which the error rate is computed.
+	Window time.Duration
+	// MinRequests is the number of calls in the window before the error rate can trip the breaker.
+	MinRequests int
+	// ErrorRateThreshold trips the breaker when the failure ratio in the window reaches it.
+	ErrorRateThreshold float64
+	// ConsecutiveFailures trips the breaker after this many failures in a row, regardless of MinRequests.
+	ConsecutiveFailures int
+	// SlowCallThreshold counts successful calls slower than this as failures. Zero disables it.
+	SlowCallThreshold time.Duration
+	// OpenTimeout is how long the breaker stays open before allowing probe calls.
+	OpenTimeout time.Duration
+	// HalfOpenMaxCalls is the number of concurrent probe calls allowed while half-open.
+	HalfOpenMaxCalls int
+	// IsFailure decides whether an error counts against the breaker. Defaults to all non-nil errors.
+	IsFailure func(error) bool
+	// OnStateChange is called whenever the breaker changes state.
+	OnStateChange func(from, to State)
+}
+
+// DefaultConfig returns the configuration used for upstream dependencies.
+func DefaultConfig() Config {
+	return Config{
+		Window:              time.Minute,
+		MinRequests:         5,
+		ErrorRateThreshold:  0.5,
+		ConsecutiveFailures: 5,
+		SlowCallThreshold:   10 * time.Second,
+		OpenTimeout:         30 * time.Second,
+		HalfOpenMaxCalls:    1,
+	}
+}
+
+// ewmaAlpha is the smoothing factor for the latency moving average.
+const ewmaAlpha = 0.2
+
+type outcome struct {
+	at     time.Time
+	failed bool
+}
+
+// Stats is a point-in-time view of a breaker.
+type Stats struct {
+	State               string  `json:"state"`
+	Requests            int     `json:"requests"`
+	Failures            int     `json:"failures"`
+	ErrorRate           float64 `json:"error_rate"`
+	ConsecutiveFailures int     `json:"consecutive_failures"`
+	LatencyEWMAMs       int64   `json:"latency_ewma_ms"`
+	// SuccessLatencyEWMAMs averages the latency of successful calls only.
+	SuccessLatencyEWMAMs int64 `json:"success_latency_ewma_ms"`
+}
+
+// Breaker is a circuit breaker driven by error rate, latency and consecutive failures.
+type Breaker struct {
+	mu sync.Mutex
+
+	cfg Config
+	now func() time.Time
+
+	state            State
+	openedAt         time.Time
+	halfOpenInFlight int
+
+	outcomes            []outcome
+	consecutiveFailures int
+	latencyEWMA         time.Duration
+	successLatencyEWMA  time.Duration
+}
+
+// New creates a Breaker. Zero values in cfg are replaced with defaults.
+func New(cfg Config) *Breaker {
+	def := DefaultConfig()
+	if cfg.Window <= 0 {
+		cfg.Window = def.Window
+	}
+	if cfg.MinRequests <= 0 {
+		cfg.MinRequests = def.MinRequests
+	}
+	if cfg.ErrorRateThreshold <= 0 {
+		cfg.ErrorRateThreshold = def.ErrorRateThreshold
+	}
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = def.OpenTimeout
+	}
+	if cfg.HalfOpenMaxCalls <= 0 {
+		cfg.HalfOpenMaxCalls = def.HalfOpenMaxCalls
+	}
+	if cfg.IsFailure == nil {
+		cfg.IsFailure = func(err error) bool { return err != nil }
+	}
+	return &Breaker{cfg: cfg, now: time.Now}
+}
+
+// State returns the current state. An open breaker whose timeout has elapsed
+// is reported as half-open, since the next call will be let through as a probe.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.currentState()
+}
+
+func (b *Breaker) currentState() State {
+	if b.state == Open && b.now().Sub(b.openedAt) >= b.cfg.OpenTimeout {
+		return HalfOpen
+	}
+	return b.state
+}
+
+// Allow reports whether a call may proceed. Callers that are allowed must
+// report the outcome with Record.
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.currentState() {
+	case Open:
+		return ErrOpen
+	case HalfOpen:
+		if b.state == Open {
+			b.transition(HalfOpen)
+		}
+		if b.halfOpenInFlight >= b.cfg.HalfOpenMaxCalls {
+			return ErrOpen
+		}
+		b.halfOpenInFlight++
+	}
+	return nil
+}
+
+// Release gives back a call allowed by Allow without recording an outcome,
+// for calls abandoned by their caller. A released probe leaves the breaker
+// half-open for the next one.
+func (b *Breaker) Release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == HalfOpen && b.halfOpenInFlight > 0 {
+		b.halfOpenInFlight--
+	}
+}
+
+// Record reports the outcome of a call that was allowed by Allow.
+func (b *Breaker) Record(duration time.Duration, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	failed := b.cfg.IsFailure(err)
+	if !failed && err == nil && b.cfg.SlowCallThreshold > 0 && duration > b.cfg.SlowCallThreshold {
+		failed = true
+	}
+
+	if b.latencyEWMA == 0 {
+		b.latencyEWMA = duration
+	} else {
+		b.latencyEWMA = time.Duration(ewmaAlpha*float64(duration) + (1-ewmaAlpha)*float64(b.latencyEWMA))
+	}
+	if err == nil {
+		if b.successLatencyEWMA == 0 {
+			b.successLatencyEWMA = duration
+		} else {
+			b.successLatencyEWMA = time.Duration(ewmaAlpha*float64(duration) + (1-ewmaAlpha)*float64(b.successLatencyEWMA))
+		}
+	}
+
+	if failed {
+		b.consecutiveFailures++
+	} else {
+		b.consecutiveFailures = 0
+	}
+
+	if b.state == HalfOpen {
+		if b.halfOpenInFlight > 0 {
+			b.halfOpenInFlight--
+		}
+		if failed {
+			b.trip(now)
+		} else {
+			b.outcomes = nil
+			b.transition(Closed)
+		}
+		return
+	}
+
+	b.outcomes = append(b.outcomes, outcome{at: now, failed: failed})
+	b.trim(now)
+
+	if b.state == Closed && b.shouldTrip() {
+		b.trip(now)
+	}
+}
+
+func (b *Breaker) shouldTrip() bool {
+	if b.cfg.ConsecutiveFailures > 0 && b.consecutiveFailures >= b.cfg.ConsecutiveFailures {
+		return true
+	}
+	if len(b.outcomes) < b.cfg.MinRequests {
+		return false
+	}
+	return b.errorRate() >= b.cfg.ErrorRateThreshold
+}
+
+func (b *Breaker) trip(now time.Time) {
+	b.openedAt = now
+	b.halfOpenInFlight = 0
+	b.transition(Open)
+}
+
+func (b *Breaker) transition(to State) {
+	from := b.state
+	b.state = to
+	if from != to && b.cfg.OnStateChange != nil {
+		b.cfg.OnStateChange(from, to)
+	}
+}
+
+func (b *Breaker) trim(now time.Time) {
+	cutoff := now.Add(-b.cfg.Window)
+	i := 0
+	for i < len(b.outcomes) && b.outcomes[i].at.Before(cutoff) {
+		i++
+	}
+	if i > 0 {
+		b.outcomes = append([]outcome(nil), b.outcomes[i:]...)
+	}
+}
+
+func (b *Breaker) errorRate() float64 {
+	if len(b.outcomes) == 0 {
+		return 0
+	}
+	failures := 0
+	for _, o := range b.outcomes {
+		if o.failed {
+			failures++
+		}
+	}
+	return float64(failures) / float64(len(b.outcomes))
+}
+
+// Stats returns a snapshot of the breaker's state and recent outcomes.
+func (b *Breaker) Stats() Stats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trim(b.now())
+	failures := 0
+	for _, o := range b.outcomes {
+		if o.failed {
+			failures++
+		}
+	}
+	return Stats{
+		State:                b.currentState().String(),
+		Requests:             len(b.outcomes),
+		Failures:             failures,
+		ErrorRate:            b.errorRate(),
+		ConsecutiveFailures:  b.consecutiveFailures,
+		LatencyEWMAMs:        b.latencyEWMA.Milliseconds(),
+		SuccessLatencyEWMAMs: b.successLatencyEWMA.Milliseconds(),
+	}
+}
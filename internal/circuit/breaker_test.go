@@ -0,0 +1,175 @@
+package circuit
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var errUpstream = errors.New("upstream failed")
+
+func newTestBreaker(cfg Config) (*Breaker, *time.Time) {
+	b := New(cfg)
+	now := time.Now()
+	b.now = func() time.Time { return now }
+	return b, &now
+}
+
+func TestBreaker_TripsOnErrorRate(t *testing.T) {
+	b, _ := newTestBreaker(Config{MinRequests: 4, ErrorRateThreshold: 0.5, ConsecutiveFailures: 10})
+
+	for _, err := range []error{nil, errUpstream, nil, errUpstream} {
+		if allowErr := b.Allow(); allowErr != nil {
+			t.Fatalf("unexpected rejection: %v", allowErr)
+		}
+		b.Record(time.Millisecond, err)
+	}
+
+	if b.State() != Open {
+		t.Fatalf("expected breaker to be open, got %s", b.State())
+	}
+	if err := b.Allow(); !errors.Is(err, ErrOpen) {
+		t.Errorf("expected ErrOpen, got %v", err)
+	}
+}
+
+func TestBreaker_TripsOnConsecutiveFailures(t *testing.T) {
+	b, _ := newTestBreaker(Config{MinRequests: 100, ConsecutiveFailures: 3})
+
+	for i := 0; i < 3; i++ {
+		b.Allow()
+		b.Record(time.Millisecond, errUpstream)
+	}
+	if b.State() != Open {
+		t.Errorf("expected breaker to be open, got %s", b.State())
+	}
+}
+
+func TestBreaker_SlowCallsCountAsFailures(t *testing.T) {
+	b, _ := newTestBreaker(Config{MinRequests: 2, ErrorRateThreshold: 1, SlowCallThreshold: time.Second})
+
+	b.Allow()
+	b.Record(2*time.Second, nil)
+	b.Allow()
+	b.Record(3*time.Second, nil)
+
+	if b.State() != Open {
+		t.Errorf("expected slow calls to trip breaker, got %s", b.State())
+	}
+}
+
+func TestBreaker_HalfOpenRecovery(t *testing.T) {
+	var transitions []State
+	b, now := newTestBreaker(Config{
+		ConsecutiveFailures: 1,
+		OpenTimeout:         time.Minute,
+		OnStateChange:       func(from, to State) { transitions = append(transitions, to) },
+	})
+
+	b.Allow()
+	b.Record(time.Millisecond, errUpstream)
+	if b.State() != Open {
+		t.Fatalf("expected open, got %s", b.State())
+	}
+
+	*now = now.Add(2 * time.Minute)
+	if b.State() != HalfOpen {
+		t.Fatalf("expected half-open after timeout, got %s", b.State())
+	}
+
+	// Only one probe is allowed at a time
+	if err := b.Allow(); err != nil {
+		t.Fatalf("expected probe to be allowed: %v", err)
+	}
+	if err := b.Allow(); !errors.Is(err, ErrOpen) {
+		t.Errorf("expected second probe to be rejected, got %v", err)
+	}
+
+	b.Record(time.Millisecond, nil)
+	if b.State() != Closed {
+		t.Errorf("expected closed after successful probe, got %s", b.State())
+	}
+
+	want := []State{Open, HalfOpen, Closed}
+	if len(transitions) != len(want) {
+		t.Fatalf("expected transitions %v, got %v", want, transitions)
+	}
+	for i := range want {
+		if transitions[i] != want[i] {
+			t.Errorf("transition %d: expected %s, got %s", i, want[i], transitions[i])
+		}
+	}
+}
+
+func TestBreaker_ReleasedProbeStaysHalfOpen(t *testing.T) {
+	b, now := newTestBreaker(Config{ConsecutiveFailures: 1, OpenTimeout: time.Minute})
+
+	b.Allow()
+	b.Record(time.Millisecond, errUpstream)
+	*now = now.Add(2 * time.Minute)
+
+	if err := b.Allow(); err != nil {
+		t.Fatalf("expected probe to be allowed: %v", err)
+	}
+	b.Release()
+	if b.State() != HalfOpen {
+		t.Errorf("expected released probe to leave breaker half-open, got %s", b.State())
+	}
+	if err := b.Allow(); err != nil {
+		t.Errorf("expected another probe after release: %v", err)
+	}
+	if stats := b.Stats(); stats.Requests != 0 {
+		t.Errorf("expected released probe not to be recorded, got %+v", stats)
+	}
+}
+
+func TestBreaker_HalfOpenFailureReopens(t *testing.T) {
+	b, now := newTestBreaker(Config{ConsecutiveFailures: 1, OpenTimeout: time.Minute})
+
+	b.Allow()
+	b.Record(time.Millisecond, errUpstream)
+	*now = now.Add(2 * time.Minute)
+
+	b.Allow()
+	b.Record(time.Millisecond, errUpstream)
+	if b.State() != Open {
+		t.Errorf("expected failed probe to reopen breaker, got %s", b.State())
+	}
+}
+
+func TestBreaker_IsFailure(t *testing.T) {
+	errNotFound := errors.New("not found")
+	b, _ := newTestBreaker(Config{
+		ConsecutiveFailures: 1,
+		IsFailure:           func(err error) bool { return err != nil && !errors.Is(err, errNotFound) },
+	})
+
+	b.Allow()
+	b.Record(time.Millisecond, errNotFound)
+	if b.State() != Closed {
+		t.Errorf("expected ignored errors to keep breaker closed, got %s", b.State())
+	}
+}
+
+func TestBreaker_Stats(t *testing.T) {
+	b, _ := newTestBreaker(Config{MinRequests: 10, ConsecutiveFailures: 10})
+
+	b.Allow()
+	b.Record(100*time.Millisecond, nil)
+	b.Allow()
+	b.Record(200*time.Millisecond, errUpstream)
+
+	stats := b.Stats()
+	if stats.State != "closed" || stats.Requests != 2 || stats.Failures != 1 || stats.ConsecutiveFailures != 1 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+	if stats.ErrorRate != 0.5 {
+		t.Errorf("expected error rate 0.5, got %v", stats.ErrorRate)
+	}
+	if stats.LatencyEWMAMs != 120 {
+		t.Errorf("expected latency EWMA 120ms, got %d", stats.LatencyEWMAMs)
+	}
+	if stats.SuccessLatencyEWMAMs != 100 {
+		t.Errorf("expected success latency EWMA 100ms, got %d", stats.SuccessLatencyEWMAMs)
+	}
+}
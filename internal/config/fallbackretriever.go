@@ -3,12 +3,13 @@ package config
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 
 	"github.com/thomaspoignant/go-feature-flag/retriever"
 )
 
 // FallbackRetriever is a custom retriever that falls back to a file if the GitHub retriever fails.
+// It is used for both the feature flags and the versions config.
 type FallbackRetriever struct {
 	Primary   retriever.Retriever
 	Secondary retriever.Retriever
@@ -22,24 +23,24 @@ func NewFallbackRetriever(primary, secondary retriever.Retriever) *FallbackRetri
 	}
 }
 
-// Retrieve attempts to get the flags from the primary retriever and falls back to the secondary.
+// Retrieve attempts to get the config from the primary retriever and falls back to the secondary.
 func (r *FallbackRetriever) Retrieve(ctx context.Context) ([]byte, error) {
-	// Try to get the flags from the primary retriever first.
-	flags, err := r.Primary.Retrieve(ctx)
+	// Try to get the config from the primary retriever first.
+	data, err := r.Primary.Retrieve(ctx)
 	if err == nil {
-		log.Println("Successfully retrieved feature flags from primary retriever.")
-		return flags, nil
+		slog.Debug("Successfully retrieved config from primary retriever")
+		return data, nil
 	}
 
 	// If the primary retriever fails, log a warning and try the secondary.
-	log.Printf("Failed to retrieve feature flags from primary retriever: %v. Falling back to secondary.", err)
-	flags, err = r.Secondary.Retrieve(ctx)
+	slog.Warn("Failed to retrieve config from primary retriever, falling back to secondary", "error", err)
+	data, err = r.Secondary.Retrieve(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to retrieve flags from both primary and secondary retrievers: %w", err)
+		return nil, fmt.Errorf("failed to retrieve config from both primary and secondary retrievers: %w", err)
 	}
 
-	log.Println("Successfully retrieved feature flags from secondary retriever.")
-	return flags, nil
+	slog.Debug("Successfully retrieved config from secondary retriever")
+	return data, nil
 }
 
 // Name returns the name of the retriever.
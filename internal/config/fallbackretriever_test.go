@@ -60,7 +60,7 @@ func TestFallbackRetriever_Retrieve(t *testing.T) {
 			secondaryResponse: nil,
 			secondaryError:    errors.New("secondary failed"),
 			expectedResponse:  nil,
-			expectedError:     errors.New("failed to retrieve flags from both primary and secondary retrievers: secondary failed"),
+			expectedError:     errors.New("failed to retrieve config from both primary and secondary retrievers: secondary failed"),
 		},
 	}
 
@@ -1,8 +1,9 @@
 package config
 
 import (
-	"log"
+	"log/slog"
 	"os"
+	"sync/atomic"
 	"time"
 
 	gofeatureflag "github.com/thomaspoignant/go-feature-flag"
@@ -20,20 +21,28 @@ func getPollingInterval() time.Duration {
 
 	duration, err := time.ParseDuration(envVal)
 	if err != nil {
-		log.Printf("Invalid FEATURE_FLAG_POLLING_INTERVAL '%s', defaulting to 5 minutes: %v", envVal, err)
+		slog.Warn("Invalid FEATURE_FLAG_POLLING_INTERVAL, defaulting to 5 minutes", "value", envVal, "error", err)
 		return 300 * time.Second
 	}
 	return duration
 }
 
+// featureFlagsReady records whether go-feature-flag has been initialized.
+var featureFlagsReady atomic.Bool
+
+// FeatureFlagsReady reports whether InitFeatureFlags completed successfully.
+func FeatureFlagsReady() bool {
+	return featureFlagsReady.Load()
+}
+
 func InitFeatureFlags() {
 	githubToken := os.Getenv("GITHUB_TOKEN")
 	if githubToken == "" {
-		log.Println("GITHUB_TOKEN is not set. Feature flags may not be retrieved from GitHub.")
+		slog.Warn("GITHUB_TOKEN is not set. Feature flags may not be retrieved from GitHub.")
 	}
 
 	pollingInterval := getPollingInterval()
-	log.Printf("Feature Flag Polling Interval set to: %v", pollingInterval)
+	slog.Info("Feature flag polling interval configured", "interval", pollingInterval.String())
 
 	flagsConfigPath := os.Getenv("FLAGS_CONFIG_PATH")
 	if flagsConfigPath == "" {
@@ -55,6 +64,8 @@ func InitFeatureFlags() {
 		),
 	})
 	if err != nil {
-		log.Fatalf("Error while initializing go-feature-flag: %v", err)
+		slog.Error("Error while initializing go-feature-flag", "error", err)
+		os.Exit(1)
 	}
+	featureFlagsReady.Store(true)
 }
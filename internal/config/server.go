@@ -0,0 +1,71 @@
+package config
+
+import (
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+)
+
+// ServerConfig holds the HTTP server limits.
+type ServerConfig struct {
+	Port              string
+	ReadTimeout       time.Duration
+	ReadHeaderTimeout time.Duration
+	// WriteTimeout bounds the whole response, so it must leave room for SSE
+	// streams and for every LLM provider in the fallback chain to be tried.
+	WriteTimeout time.Duration
+	IdleTimeout  time.Duration
+	// ShutdownTimeout is how long in-flight requests and streams may take to
+	// drain after SIGTERM. Cloud Run kills the container 10 seconds after
+	// SIGTERM, so the default leaves time for the remaining cleanup.
+	ShutdownTimeout time.Duration
+	MaxBodyBytes    int64
+}
+
+// LoadServerConfig reads the HTTP server configuration from the environment,
+// falling back to defaults for unset or invalid values.
+func LoadServerConfig() ServerConfig {
+	port := os.Getenv("PORT")
+	if port == "" {
+		port = "8080"
+	}
+
+	return ServerConfig{
+		Port:              port,
+		ReadTimeout:       getDuration("HTTP_READ_TIMEOUT", 15*time.Second),
+		ReadHeaderTimeout: getDuration("HTTP_READ_HEADER_TIMEOUT", 5*time.Second),
+		WriteTimeout:      getDuration("HTTP_WRITE_TIMEOUT", 5*time.Minute),
+		IdleTimeout:       getDuration("HTTP_IDLE_TIMEOUT", 120*time.Second),
+		ShutdownTimeout:   getDuration("SHUTDOWN_TIMEOUT", 8*time.Second),
+		MaxBodyBytes:      getBytes("MAX_REQUEST_BODY_BYTES", 1<<20),
+	}
+}
+
+func getDuration(key string, fallback time.Duration) time.Duration {
+	envVal := os.Getenv(key)
+	if envVal == "" {
+		return fallback
+	}
+
+	duration, err := time.ParseDuration(envVal)
+	if err != nil || duration <= 0 {
+		slog.Warn("Invalid duration, using default", "key", key, "value", envVal, "default", fallback.String())
+		return fallback
+	}
+	return duration
+}
+
+func getBytes(key string, fallback int64) int64 {
+	envVal := os.Getenv(key)
+	if envVal == "" {
+		return fallback
+	}
+
+	n, err := strconv.ParseInt(envVal, 10, 64)
+	if err != nil || n <= 0 {
+		slog.Warn("Invalid byte size, using default", "key", key, "value", envVal, "default", fallback)
+		return fallback
+	}
+	return n
+}
@@ -0,0 +1,41 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadServerConfig(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		cfg := LoadServerConfig()
+		assert.Equal(t, "8080", cfg.Port)
+		assert.Equal(t, 15*time.Second, cfg.ReadTimeout)
+		assert.Equal(t, 5*time.Minute, cfg.WriteTimeout)
+		assert.Equal(t, 8*time.Second, cfg.ShutdownTimeout)
+		assert.Equal(t, int64(1<<20), cfg.MaxBodyBytes)
+	})
+
+	t.Run("Overrides", func(t *testing.T) {
+		t.Setenv("PORT", "9090")
+		t.Setenv("HTTP_WRITE_TIMEOUT", "10m")
+		t.Setenv("SHUTDOWN_TIMEOUT", "20s")
+		t.Setenv("MAX_REQUEST_BODY_BYTES", "2048")
+
+		cfg := LoadServerConfig()
+		assert.Equal(t, "9090", cfg.Port)
+		assert.Equal(t, 10*time.Minute, cfg.WriteTimeout)
+		assert.Equal(t, 20*time.Second, cfg.ShutdownTimeout)
+		assert.Equal(t, int64(2048), cfg.MaxBodyBytes)
+	})
+
+	t.Run("Invalid values fall back to defaults", func(t *testing.T) {
+		t.Setenv("HTTP_IDLE_TIMEOUT", "soon")
+		t.Setenv("MAX_REQUEST_BODY_BYTES", "-1")
+
+		cfg := LoadServerConfig()
+		assert.Equal(t, 120*time.Second, cfg.IdleTimeout)
+		assert.Equal(t, int64(1<<20), cfg.MaxBodyBytes)
+	})
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/thomaspoignant/go-feature-flag/retriever/fileretriever"
+	"github.com/thomaspoignant/go-feature-flag/retriever/githubretriever"
+)
+
+// VersionsPollingInterval returns how often the versions config is polled for
+// changes, from VERSIONS_POLLING_INTERVAL. It defaults to 5 minutes, and 0
+// disables reloading.
+func VersionsPollingInterval() time.Duration {
+	envVal := os.Getenv("VERSIONS_POLLING_INTERVAL")
+	if envVal == "" {
+		return 300 * time.Second
+	}
+	duration, err := time.ParseDuration(envVal)
+	if err != nil || duration < 0 {
+		slog.Warn("Invalid VERSIONS_POLLING_INTERVAL, defaulting to 5 minutes", "value", envVal, "error", err)
+		return 300 * time.Second
+	}
+	return duration
+}
+
+// NewVersionsRetriever returns a retriever for the versions config that reads
+// it from GitHub and falls back to the local file, like the feature flags.
+func NewVersionsRetriever(path string) *FallbackRetriever {
+	return NewFallbackRetriever(
+		&githubretriever.Retriever{
+			RepositorySlug: "julwrites/BibleAIAPI",
+			Branch:         "main",
+			FilePath:       "configs/versions.yaml",
+			GithubToken:    os.Getenv("GITHUB_TOKEN"),
+		},
+		&fileretriever.Retriever{
+			Path: path,
+		},
+	)
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thomaspoignant/go-feature-flag/retriever/fileretriever"
+)
+
+func TestVersionsPollingInterval(t *testing.T) {
+	tests := []struct {
+		name     string
+		envVal   string
+		expected time.Duration
+	}{
+		{"Default value", "", 300 * time.Second},
+		{"Valid duration", "30s", 30 * time.Second},
+		{"Disabled", "0", 0},
+		{"Invalid duration", "invalid", 300 * time.Second},
+		{"Negative duration", "-1m", 300 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("VERSIONS_POLLING_INTERVAL", tt.envVal)
+			assert.Equal(t, tt.expected, VersionsPollingInterval())
+		})
+	}
+}
+
+func TestNewVersionsRetriever_FallsBackToFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "versions.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("- code: KJV\n"), 0644))
+
+	r := NewVersionsRetriever(path)
+	assert.Equal(t, path, r.Secondary.(*fileretriever.Retriever).Path)
+
+	// Use a failing primary so the test does not depend on GitHub
+	r.Primary = &mockRetriever{retrieveFunc: func(context.Context) ([]byte, error) {
+		return nil, os.ErrNotExist
+	}}
+	data, err := r.Retrieve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "- code: KJV\n", string(data))
+}
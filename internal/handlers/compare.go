@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"bible-api-service/internal/bible"
+	"bible-api-service/internal/util"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// maxCompareVersions limits the versions a compare query fetches at once.
+const maxCompareVersions = 10
+
+// comparedVersion is a passage as one version of a comparison has it.
+type comparedVersion struct {
+	code   string
+	scheme bible.Versification
+	verses []bible.VerseText
+	err    error
+}
+
+func (h *QueryHandler) handleCompareQuery(w http.ResponseWriter, r *http.Request, request QueryRequest) {
+	compare := request.Query.Compare
+	if request.Options.Stream || request.Options.Format != "" || len(request.Options.Include) > 0 {
+		util.JSONError(w, http.StatusBadRequest, "Only the versification option is supported for compare queries")
+		return
+	}
+	refVersification, err := bible.ParseVersification(request.Options.Versification)
+	if err != nil {
+		util.JSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if len(compare.Versions) == 0 || len(compare.Versions) > maxCompareVersions {
+		util.JSONError(w, http.StatusBadRequest, fmt.Sprintf("Compare needs between 1 and %d versions", maxCompareVersions))
+		return
+	}
+	seen := make(map[string]bool)
+	for _, code := range compare.Versions {
+		if seen[strings.ToUpper(code)] {
+			util.JSONError(w, http.StatusBadRequest, fmt.Sprintf("Duplicate version in compare: %s", code))
+			return
+		}
+		seen[strings.ToUpper(code)] = true
+	}
+
+	book, chapter, verse, err := util.ParseVerseReference(compare.Reference)
+	if err != nil {
+		util.JSONErrorWithCode(w, http.StatusBadRequest, util.ErrorCodeInvalidReference, err.Error())
+		return
+	}
+
+	results := make([]comparedVersion, len(compare.Versions))
+	var wg sync.WaitGroup
+	for i, code := range compare.Versions {
+		wg.Add(1)
+		go func(i int, code string) {
+			defer wg.Done()
+			results[i] = h.fetchComparedVersion(r.Context(), code, book, chapter, verse, refVersification)
+		}(i, code)
+	}
+	wg.Wait()
+
+	verses := make(map[string][]bible.VerseText, len(results))
+	schemes := make(map[string]bible.Versification, len(results))
+	for _, result := range results {
+		if result.err != nil {
+			slog.ErrorContext(r.Context(), "Failed to get verse for comparison",
+				"version", result.code, "reference", compare.Reference, "error", result.err)
+			writeProviderError(w, result.err, "Verse not found", "Failed to get verse")
+			return
+		}
+		verses[result.code] = result.verses
+		schemes[result.code] = result.scheme
+	}
+
+	response := map[string]interface{}{
+		"reference": compare.Reference,
+		"versions":  compare.Versions,
+		"rows":      bible.AlignVerses(verses, schemes, refVersification),
+	}
+	json.NewEncoder(w).Encode(response)
+}
+
+// fetchComparedVersion fetches a passage in one version through its best
+// provider, translating the reference from refVersification into the
+// version's verse numbering.
+func (h *QueryHandler) fetchComparedVersion(ctx context.Context, code, book, chapter, verse string, refVersification bible.Versification) comparedVersion {
+	result := comparedVersion{code: code, scheme: h.VersionManager.Versification(code)}
+
+	providerName, providerVersion, err := h.providerFor(ctx, code, bible.CapabilityVerses)
+	if err != nil {
+		result.err = err
+		return result
+	}
+	p, err := h.ProviderManager.GetProvider(providerName)
+	if err != nil {
+		result.err = err
+		return result
+	}
+
+	if err := h.VersionManager.CheckBook(code, book, chapter); err != nil {
+		result.err = err
+		return result
+	}
+	chapter, verse, err = bible.MapReference(book, chapter, verse, refVersification, result.scheme)
+	if err != nil {
+		result.err = err
+		return result
+	}
+	passage, err := bible.GetPassage(p, book, chapter, verse, providerVersion, bible.VerseOptions{VerseNumbers: true})
+	if err != nil {
+		result.err = err
+		return result
+	}
+	result.verses, result.err = bible.Verses(passage.Text, passageReference(book, chapter, verse))
+	return result
+}
@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"bible-api-service/internal/bible"
+	"bible-api-service/internal/circuit"
+	"bible-api-service/internal/util"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createCompareVersionManager(t *testing.T) *bible.VersionManager {
+	configPath := filepath.Join(t.TempDir(), "versions.yaml")
+	content := `
+- code: ESV
+  name: English Standard Version
+  language: English
+  providers:
+    biblegateway: ESV
+- code: NABRE
+  name: New American Bible (Revised Edition)
+  language: English
+  versification: hebrew
+  canon: catholic
+  providers:
+    biblehub: nabre
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0644))
+	vm, err := bible.NewVersionManager(configPath)
+	require.NoError(t, err)
+	return vm
+}
+
+func TestHandleCompareQuery(t *testing.T) {
+	var mu sync.Mutex
+	requested := make(map[string]string)
+	record := func(book, chapter, verse, version string) {
+		mu.Lock()
+		defer mu.Unlock()
+		requested[version] = fmt.Sprintf("%s %s:%s", book, chapter, verse)
+	}
+
+	gateway := &MockProvider{
+		getVerseFunc: func(book, chapter, verse, version string) (string, error) {
+			record(book, chapter, verse, version)
+			return `<sup>1 </sup>For behold, the day is coming, burning like an oven. <sup>2 </sup>But for you who fear my name`, nil
+		},
+	}
+	hub := &MockProvider{
+		getVerseFunc: func(book, chapter, verse, version string) (string, error) {
+			record(book, chapter, verse, version)
+			return `<sup>19 </sup>For the day is coming, blazing like an oven. <sup>20 </sup>But for you who fear my name`, nil
+		},
+	}
+	pm := bible.NewProviderManager(gateway)
+	pm.RegisterProvider(bible.DefaultProviderName, gateway)
+	pm.RegisterProvider("biblehub", hub)
+	handler := &QueryHandler{ProviderManager: pm, VersionManager: createCompareVersionManager(t)}
+
+	reqBody := `{"query": {"compare": {"reference": "Malachi 4:1-2", "versions": ["ESV", "NABRE"]}}}`
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("POST", "/query", bytes.NewBufferString(reqBody)))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	// NABRE ends Malachi at chapter 3
+	require.Equal(t, map[string]string{"ESV": "Malachi 4:1-2", "nabre": "Malachi 3:19-20"}, requested)
+	require.JSONEq(t, `{
+		"reference": "Malachi 4:1-2",
+		"versions": ["ESV", "NABRE"],
+		"rows": [
+			{"book": "Malachi", "chapter": 4, "verse": 1, "versions": {
+				"ESV": {"book": "Malachi", "chapter": 4, "verse": 1, "text": "For behold, the day is coming, burning like an oven."},
+				"NABRE": {"book": "Malachi", "chapter": 3, "verse": 19, "text": "For the day is coming, blazing like an oven."}
+			}},
+			{"book": "Malachi", "chapter": 4, "verse": 2, "versions": {
+				"ESV": {"book": "Malachi", "chapter": 4, "verse": 2, "text": "But for you who fear my name"},
+				"NABRE": {"book": "Malachi", "chapter": 3, "verse": 20, "text": "But for you who fear my name"}
+			}}
+		]
+	}`, rr.Body.String())
+}
+
+func TestHandleCompareQuery_ProviderError(t *testing.T) {
+	gateway := &MockProvider{
+		getVerseFunc: func(book, chapter, verse, version string) (string, error) {
+			return `<sup>1 </sup>In the beginning`, nil
+		},
+	}
+	hub := &MockProvider{
+		getVerseFunc: func(book, chapter, verse, version string) (string, error) {
+			return "", fmt.Errorf("%w: no such chapter", bible.ErrNotFound)
+		},
+	}
+	pm := bible.NewProviderManager(gateway)
+	pm.RegisterProvider(bible.DefaultProviderName, gateway)
+	pm.RegisterProvider("biblehub", hub)
+	handler := &QueryHandler{ProviderManager: pm, VersionManager: createCompareVersionManager(t)}
+
+	reqBody := `{"query": {"compare": {"reference": "Genesis 1:1", "versions": ["ESV", "NABRE"]}}}`
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("POST", "/query", bytes.NewBufferString(reqBody)))
+	require.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestHandleCompareQuery_OpenCircuit(t *testing.T) {
+	fetched := false
+	gateway := &MockProvider{
+		getVerseFunc: func(book, chapter, verse, version string) (string, error) {
+			fetched = true
+			return `<sup>1 </sup>In the beginning`, nil
+		},
+	}
+	pm := bible.NewProviderManager(gateway)
+	pm.RegisterProvider(bible.DefaultProviderName, gateway)
+	pm.RegisterProvider("biblehub", gateway)
+	vm := createCompareVersionManager(t)
+	vm.SetHealthChecker(stubProviderHealth{bible.DefaultProviderName: circuit.Open})
+	handler := &QueryHandler{ProviderManager: pm, VersionManager: vm}
+
+	// ESV is unavailable rather than retried on the default provider
+	reqBody := `{"query": {"compare": {"reference": "Genesis 1:1", "versions": ["ESV", "NABRE"]}}}`
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("POST", "/query", bytes.NewBufferString(reqBody)))
+	require.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	var resp util.ErrorResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	require.Equal(t, util.ErrorCodeUpstreamUnavailable, resp.Error.ErrorCode)
+
+	// Unknown versions are still tried on the default provider
+	vm.SetHealthChecker(nil)
+	fetched = false
+	reqBody = `{"query": {"compare": {"reference": "Genesis 1:1", "versions": ["XYZ"]}}}`
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("POST", "/query", bytes.NewBufferString(reqBody)))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	require.True(t, fetched)
+}
+
+func TestHandleCompareQuery_InvalidRequest(t *testing.T) {
+	pm := bible.NewProviderManager(&MockProvider{})
+	handler := &QueryHandler{ProviderManager: pm, VersionManager: createCompareVersionManager(t)}
+
+	tests := map[string]string{
+		"no versions":        `{"query": {"compare": {"reference": "John 3:16", "versions": []}}}`,
+		"too many versions":  `{"query": {"compare": {"reference": "John 3:16", "versions": ["A", "B", "C", "D", "E", "F", "G", "H", "I", "J", "K"]}}}`,
+		"duplicate versions": `{"query": {"compare": {"reference": "John 3:16", "versions": ["ESV", "esv"]}}}`,
+		"invalid reference":  `{"query": {"compare": {"reference": "John", "versions": ["ESV"]}}}`,
+		"format":             `{"query": {"compare": {"reference": "John 3:16", "versions": ["ESV"]}}, "options": {"format": "plain"}}`,
+		"with verses":        `{"query": {"verses": ["John 3:16"], "compare": {"reference": "John 3:16", "versions": ["ESV"]}}}`,
+	}
+	for name, reqBody := range tests {
+		t.Run(name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, httptest.NewRequest("POST", "/query", bytes.NewBufferString(reqBody)))
+			require.Equal(t, http.StatusBadRequest, rr.Code)
+
+			var response map[string]interface{}
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"bible-api-service/internal/bible"
+	"bible-api-service/internal/util"
+)
+
+// providerErrorResponse maps a Bible provider error to an HTTP status, a
+// machine-readable error code and a message that is safe to return to
+// clients. Not found errors get the notFound message, naming what was looked
+// up, and unclassified errors become a 500 with the fallback message.
+func providerErrorResponse(err error, notFound, fallback string) (int, string, string) {
+	switch {
+	case errors.Is(err, bible.ErrInvalidReference):
+		return http.StatusBadRequest, util.ErrorCodeInvalidReference, "Invalid verse reference"
+	case errors.Is(err, bible.ErrNotFound):
+		return http.StatusNotFound, util.ErrorCodeNotFound, notFound
+	case errors.Is(err, bible.ErrUnsupportedVersion):
+		return http.StatusBadRequest, util.ErrorCodeUnsupportedVersion, "Not supported for this version"
+	case errors.Is(err, bible.ErrRateLimited):
+		return http.StatusTooManyRequests, util.ErrorCodeRateLimited, "Bible provider is rate limiting requests, try again later"
+	case errors.Is(err, bible.ErrMarkupChanged):
+		return http.StatusBadGateway, util.ErrorCodeMarkupChanged, "Bible provider returned a page that could not be read"
+	case errors.Is(err, bible.ErrUpstreamUnavailable):
+		return http.StatusServiceUnavailable, util.ErrorCodeUpstreamUnavailable, "Bible provider unavailable"
+	default:
+		return http.StatusInternalServerError, util.ErrorCodeInternal, fallback
+	}
+}
+
+// writeProviderError writes the error response for a Bible provider error.
+func writeProviderError(w http.ResponseWriter, err error, notFound, fallback string) {
+	status, code, message := providerErrorResponse(err, notFound, fallback)
+	util.JSONErrorWithCode(w, status, code, message)
+}
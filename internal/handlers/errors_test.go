@@ -0,0 +1,256 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"bible-api-service/internal/bible"
+	"bible-api-service/internal/circuit"
+	"bible-api-service/internal/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleVerseQuery_ProviderErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		status    int
+		errorCode string
+	}{
+		{"invalid reference", fmt.Errorf("%w: unknown book: Hezekiah", bible.ErrInvalidReference), http.StatusBadRequest, util.ErrorCodeInvalidReference},
+		{"not found", fmt.Errorf("verse %w", bible.ErrNotFound), http.StatusNotFound, util.ErrorCodeNotFound},
+		{"upstream 404", fmt.Errorf("failed to fetch chapter 99: %w", &bible.StatusError{Provider: "biblehub", StatusCode: http.StatusNotFound}), http.StatusNotFound, util.ErrorCodeNotFound},
+		{"unsupported version", fmt.Errorf("%w: search not supported on BibleNow", bible.ErrUnsupportedVersion), http.StatusBadRequest, util.ErrorCodeUnsupportedVersion},
+		{"rate limited", &bible.StatusError{Provider: "biblegateway", StatusCode: http.StatusTooManyRequests}, http.StatusTooManyRequests, util.ErrorCodeRateLimited},
+		{"upstream error", &bible.StatusError{Provider: "biblegateway", StatusCode: http.StatusBadGateway}, http.StatusServiceUnavailable, util.ErrorCodeUpstreamUnavailable},
+		{"circuit open", fmt.Errorf("provider biblegateway: %w: %w", bible.ErrUpstreamUnavailable, circuit.ErrOpen), http.StatusServiceUnavailable, util.ErrorCodeUpstreamUnavailable},
+		{"markup changed", &bible.MarkupError{Provider: "biblegateway", Selector: bible.Selector{Name: "passage", Query: ".passage-text"}}, http.StatusBadGateway, util.ErrorCodeMarkupChanged},
+		{"unclassified", errors.New("boom"), http.StatusInternalServerError, util.ErrorCodeInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockP := &MockProvider{
+				getVerseFunc: func(book, chapter, verse, version string) (string, error) {
+					return "", tt.err
+				},
+			}
+			pm := bible.NewProviderManager(mockP)
+			pm.RegisterProvider(bible.DefaultProviderName, mockP)
+			handler := &QueryHandler{ProviderManager: pm, VersionManager: createTestVersionManager(t)}
+
+			req := httptest.NewRequest("POST", "/query", bytes.NewBufferString(`{"query": {"verses": ["John 3:16"]}}`))
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.status, rr.Code)
+			var resp util.ErrorResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			assert.Equal(t, tt.status, resp.Error.Code)
+			assert.Equal(t, tt.errorCode, resp.Error.ErrorCode)
+			assert.NotContains(t, resp.Error.Message, "biblegateway", "upstream details should not leak")
+		})
+	}
+}
+
+func TestHandleVerseQuery_InvalidReferenceFormat(t *testing.T) {
+	pm := bible.NewProviderManager(&MockProvider{})
+	pm.RegisterProvider(bible.DefaultProviderName, &MockProvider{})
+	handler := &QueryHandler{ProviderManager: pm, VersionManager: createTestVersionManager(t)}
+
+	req := httptest.NewRequest("POST", "/query", bytes.NewBufferString(`{"query": {"verses": ["John"]}}`))
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	var resp util.ErrorResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	assert.Equal(t, util.ErrorCodeInvalidReference, resp.Error.ErrorCode)
+}
+
+func TestHandleWordSearchQuery_ProviderErrors(t *testing.T) {
+	mockP := &MockProvider{
+		searchWordsFunc: func(query, version string) ([]bible.SearchResult, error) {
+			return nil, &bible.StatusError{Provider: "biblehub", StatusCode: http.StatusTooManyRequests}
+		},
+	}
+	pm := bible.NewProviderManager(mockP)
+	pm.RegisterProvider(bible.DefaultProviderName, mockP)
+	handler := &QueryHandler{ProviderManager: pm, VersionManager: createTestVersionManager(t)}
+
+	req := httptest.NewRequest("POST", "/query", bytes.NewBufferString(`{"query": {"words": ["grace"]}}`))
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+	var resp util.ErrorResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	assert.Equal(t, util.ErrorCodeRateLimited, resp.Error.ErrorCode)
+}
+
+// verseOnlyProvider is a provider that cannot search.
+type verseOnlyProvider struct {
+	getVerseFunc func(book, chapter, verse, version string) (string, error)
+}
+
+func (m verseOnlyProvider) GetVerse(book, chapter, verse, version string) (string, error) {
+	if m.getVerseFunc != nil {
+		return m.getVerseFunc(book, chapter, verse, version)
+	}
+	return "", nil
+}
+
+func (verseOnlyProvider) GetVersions() ([]bible.ProviderVersion, error) {
+	return nil, nil
+}
+
+func TestHandleWordSearchQuery_NoSearchProvider(t *testing.T) {
+	var searched []string
+	gateway := &MockProvider{
+		searchWordsFunc: func(query, version string) ([]bible.SearchResult, error) {
+			searched = append(searched, version)
+			return []bible.SearchResult{}, nil
+		},
+	}
+	pm := bible.NewProviderManager(gateway)
+	pm.RegisterProvider(bible.DefaultProviderName, gateway)
+	pm.RegisterProvider("biblehub", verseOnlyProvider{})
+	vm := createCompareVersionManager(t)
+	vm.SetCapabilityChecker(pm)
+	handler := &QueryHandler{ProviderManager: pm, VersionManager: vm}
+
+	// NABRE is only mapped by a provider that cannot search, and no other
+	// version is numbered like it
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("POST", "/query", bytes.NewBufferString(`{"query": {"words": ["grace"]}, "context": {"user": {"version": "NABRE"}}}`)))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	var resp util.ErrorResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	assert.Equal(t, util.ErrorCodeUnsupportedVersion, resp.Error.ErrorCode)
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("POST", "/query", bytes.NewBufferString(`{"query": {"words": ["grace"]}, "context": {"user": {"version": "ESV"}}}`)))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, []string{"ESV"}, searched)
+}
+
+func TestHandleVerseQuery_ProviderSelection(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "versions.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+- code: ESV
+  name: English Standard Version
+  language: English
+  providers:
+    biblegateway: ESV
+- code: AMP
+  name: Amplified Bible
+  language: English
+  providers:
+    biblecom: "1588"
+`), 0644))
+	vm, err := bible.NewVersionManager(configPath)
+	require.NoError(t, err)
+
+	var fetched []string
+	fetch := func(name string) *MockProvider {
+		return &MockProvider{
+			getVerseFunc: func(book, chapter, verse, version string) (string, error) {
+				fetched = append(fetched, name+" "+version)
+				return "In the beginning", nil
+			},
+		}
+	}
+	gateway := fetch(bible.DefaultProviderName)
+	pm := bible.NewProviderManager(gateway)
+	pm.RegisterProvider(bible.DefaultProviderName, gateway)
+	pm.RegisterProvider("biblecom", fetch("biblecom"))
+	handler := &QueryHandler{ProviderManager: pm, VersionManager: vm}
+
+	query := func(version string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		body := fmt.Sprintf(`{"query": {"verses": ["Genesis 1:1"]}, "context": {"user": {"version": %q}}}`, version)
+		handler.ServeHTTP(rr, httptest.NewRequest("POST", "/query", bytes.NewBufferString(body)))
+		return rr
+	}
+
+	// Versions only Bible.com carries are fetched from it
+	assert.Equal(t, http.StatusOK, query("AMP").Code)
+	// Unknown versions are tried on the default provider as they are
+	assert.Equal(t, http.StatusOK, query("XYZ").Code)
+	assert.Equal(t, []string{"biblecom 1588", "biblegateway XYZ"}, fetched)
+
+	// A version whose providers all have open circuits is unavailable,
+	// rather than retried on the default provider
+	fetched = nil
+	vm.SetHealthChecker(stubProviderHealth{bible.DefaultProviderName: circuit.Open})
+	rr := query("ESV")
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	var resp util.ErrorResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	assert.Equal(t, util.ErrorCodeUpstreamUnavailable, resp.Error.ErrorCode)
+	assert.Empty(t, fetched)
+}
+
+func TestProviderFor(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "versions.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+- code: ESV
+  name: English Standard Version
+  language: English
+  providers:
+    biblegateway: ESV
+    biblehub: esv
+- code: AMP
+  name: Amplified Bible
+  language: English
+  providers:
+    biblecom: "1588"
+`), 0644))
+	vm, err := bible.NewVersionManager(configPath)
+	require.NoError(t, err)
+	handler := &QueryHandler{VersionManager: vm}
+
+	tests := []struct {
+		name       string
+		version    string
+		capability string
+		open       []string
+		provider   string
+		code       string
+		err        error
+	}{
+		{"preferred provider", "ESV", bible.CapabilityVerses, nil, bible.DefaultProviderName, "ESV", nil},
+		{"only Bible.com", "AMP", bible.CapabilityVerses, nil, "biblecom", "1588", nil},
+		{"unknown version", "XYZ", bible.CapabilityVerses, nil, bible.DefaultProviderName, "XYZ", nil},
+		{"notes fall back to verses", "ESV", bible.CapabilityFootnotes, []string{bible.DefaultProviderName}, "biblehub", "esv", nil},
+		{"all circuits open", "ESV", bible.CapabilityVerses, []string{bible.DefaultProviderName, "biblehub"}, "", "", bible.ErrUpstreamUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			health := stubProviderHealth{}
+			for _, name := range tt.open {
+				health[name] = circuit.Open
+			}
+			vm.SetHealthChecker(health)
+
+			name, code, err := handler.providerFor(context.Background(), tt.version, tt.capability)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.provider, name)
+			assert.Equal(t, tt.code, code)
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"bible-api-service/internal/bible"
+	"bible-api-service/internal/circuit"
+	"bible-api-service/internal/metrics"
+)
+
+// HealthHandler serves the liveness, readiness and dependency status endpoints.
+type HealthHandler struct {
+	VersionManager    *bible.VersionManager
+	ProviderManager   *bible.ProviderManager
+	GetLLMClient      GetLLMClient
+	FeatureFlagsReady func() bool
+	Metrics           *metrics.Registry
+}
+
+// NewHealthHandler creates a new HealthHandler.
+func NewHealthHandler(versionManager *bible.VersionManager, providerManager *bible.ProviderManager, getLLMClient GetLLMClient, featureFlagsReady func() bool) *HealthHandler {
+	return &HealthHandler{
+		VersionManager:    versionManager,
+		ProviderManager:   providerManager,
+		GetLLMClient:      getLLMClient,
+		FeatureFlagsReady: featureFlagsReady,
+		Metrics:           metrics.Default,
+	}
+}
+
+// check is the result of a single readiness check.
+type check struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+// Liveness reports that the process is up. It performs no dependency checks.
+func (h *HealthHandler) Liveness(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, r, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// Readiness reports whether the service can serve traffic: the versions config
+// is loaded, feature flags are initialized and at least one LLM client can be built.
+func (h *HealthHandler) Readiness(w http.ResponseWriter, r *http.Request) {
+	checks := map[string]check{
+		"versions":      h.checkVersions(),
+		"feature_flags": h.checkFeatureFlags(),
+		"llm":           h.checkLLM(),
+	}
+
+	status := "ok"
+	code := http.StatusOK
+	for name, c := range checks {
+		if !c.OK {
+			status = "unavailable"
+			code = http.StatusServiceUnavailable
+			slog.WarnContext(r.Context(), "Readiness check failed", "check", name, "reason", c.Error)
+		}
+	}
+
+	writeJSON(w, r, code, map[string]interface{}{
+		"status": status,
+		"checks": checks,
+	})
+}
+
+// Status reports the recent success rate and latency of every Bible and LLM provider.
+func (h *HealthHandler) Status(w http.ResponseWriter, r *http.Request) {
+	registry := h.Metrics
+	if registry == nil {
+		registry = metrics.Default
+	}
+
+	bibleStats := registry.Snapshot(metrics.ComponentBible)
+	if h.ProviderManager != nil {
+		// Report registered providers even if they have not served traffic yet
+		for _, name := range h.ProviderManager.ProviderNames() {
+			if _, ok := bibleStats[name]; !ok {
+				bibleStats[name] = metrics.Stats{}
+			}
+		}
+	}
+
+	body := map[string]interface{}{
+		"window":          registry.Window().String(),
+		"bible_providers": bibleStats,
+		"llm_providers":   registry.Snapshot(metrics.ComponentLLM),
+	}
+	if health := h.llmHealth(); health != nil {
+		body["llm_health"] = health
+	}
+
+	writeJSON(w, r, http.StatusOK, body)
+}
+
+// llmHealth returns the per-provider health tracked by the LLM client, if it tracks any.
+func (h *HealthHandler) llmHealth() map[string]circuit.Stats {
+	if h.GetLLMClient == nil {
+		return nil
+	}
+	client, err := h.GetLLMClient()
+	if err != nil || client == nil {
+		return nil
+	}
+	tracker, ok := client.(interface {
+		Health() map[string]circuit.Stats
+	})
+	if !ok {
+		return nil
+	}
+	return tracker.Health()
+}
+
+func (h *HealthHandler) checkVersions() check {
+	if h.VersionManager == nil {
+		return check{Error: "version manager not initialized"}
+	}
+	if len(h.VersionManager.GetAll()) == 0 {
+		return check{Error: "no versions loaded"}
+	}
+	return check{OK: true}
+}
+
+func (h *HealthHandler) checkFeatureFlags() check {
+	if h.FeatureFlagsReady == nil || !h.FeatureFlagsReady() {
+		return check{Error: "feature flags not initialized"}
+	}
+	return check{OK: true}
+}
+
+func (h *HealthHandler) checkLLM() check {
+	if h.GetLLMClient == nil {
+		return check{Error: "llm client not configured"}
+	}
+	if _, err := h.GetLLMClient(); err != nil {
+		// Readiness is unauthenticated, so keep configuration details out of the response
+		slog.Warn("No LLM client could be built", "error", err)
+		return check{Error: "no LLM client could be built"}
+	}
+	return check{OK: true}
+}
+
+func writeJSON(w http.ResponseWriter, r *http.Request, code int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		slog.ErrorContext(r.Context(), "Failed to encode response", "error", err)
+	}
+}
@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"bible-api-service/internal/bible"
+	"bible-api-service/internal/llm"
+	"bible-api-service/internal/llm/provider"
+	"bible-api-service/internal/metrics"
+)
+
+func TestHealthHandler_Liveness(t *testing.T) {
+	h := &HealthHandler{}
+	rr := httptest.NewRecorder()
+	h.Liveness(rr, httptest.NewRequest("GET", "/healthz", nil))
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+}
+
+func TestHealthHandler_Readiness(t *testing.T) {
+	vm := createTestVersionManager(t)
+	okLLM := func() (provider.LLMClient, error) { return nil, nil }
+	failLLM := func() (provider.LLMClient, error) { return nil, errors.New("OPENAI_API_KEY not set") }
+	ready := func() bool { return true }
+
+	tests := []struct {
+		name       string
+		handler    *HealthHandler
+		wantStatus int
+		failing    string
+	}{
+		{
+			name:       "all checks pass",
+			handler:    NewHealthHandler(vm, nil, okLLM, ready),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "llm unavailable",
+			handler:    NewHealthHandler(vm, nil, failLLM, ready),
+			wantStatus: http.StatusServiceUnavailable,
+			failing:    "llm",
+		},
+		{
+			name:       "feature flags not initialized",
+			handler:    NewHealthHandler(vm, nil, okLLM, func() bool { return false }),
+			wantStatus: http.StatusServiceUnavailable,
+			failing:    "feature_flags",
+		},
+		{
+			name:       "versions not loaded",
+			handler:    NewHealthHandler(nil, nil, okLLM, ready),
+			wantStatus: http.StatusServiceUnavailable,
+			failing:    "versions",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			tt.handler.Readiness(rr, httptest.NewRequest("GET", "/readyz", nil))
+
+			if rr.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rr.Code)
+			}
+
+			var body struct {
+				Status string           `json:"status"`
+				Checks map[string]check `json:"checks"`
+			}
+			if err := json.NewDecoder(rr.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if tt.failing != "" && body.Checks[tt.failing].OK {
+				t.Errorf("expected check %s to fail", tt.failing)
+			}
+			if body.Checks["llm"].Error != "" && body.Checks["llm"].Error != "no LLM client could be built" {
+				t.Errorf("expected llm error details to be hidden, got %q", body.Checks["llm"].Error)
+			}
+		})
+	}
+}
+
+func TestHealthHandler_ReadinessRetriesLLMBuildWithBackoff(t *testing.T) {
+	now := time.Unix(0, 0)
+	builds := 0
+	getLLM := cachedLLMClient(func() (provider.LLMClient, error) {
+		builds++
+		return nil, errors.New("LLM_CONFIG secret not found")
+	}, func() time.Time { return now })
+	h := NewHealthHandler(createTestVersionManager(t), nil, getLLM, func() bool { return true })
+
+	probe := func() int {
+		rr := httptest.NewRecorder()
+		h.Readiness(rr, httptest.NewRequest("GET", "/readyz", nil))
+		return rr.Code
+	}
+
+	for i := 0; i < 5; i++ {
+		if code := probe(); code != http.StatusServiceUnavailable {
+			t.Fatalf("expected status %d, got %d", http.StatusServiceUnavailable, code)
+		}
+	}
+	if builds != 1 {
+		t.Errorf("expected the failed build to be reused until its retry, got %d builds", builds)
+	}
+
+	now = now.Add(llmClientMinRetry)
+	probe()
+	if builds != 2 {
+		t.Errorf("expected a retry after %s, got %d builds", llmClientMinRetry, builds)
+	}
+	// The delay doubles after each failure
+	now = now.Add(llmClientMinRetry)
+	probe()
+	if builds != 2 {
+		t.Errorf("expected no retry before %s, got %d builds", 2*llmClientMinRetry, builds)
+	}
+	now = now.Add(llmClientMinRetry)
+	probe()
+	if builds != 3 {
+		t.Errorf("expected a retry after %s, got %d builds", 2*llmClientMinRetry, builds)
+	}
+}
+
+func TestCachedLLMClient_KeepsBuiltClient(t *testing.T) {
+	builds := 0
+	getLLM := cachedLLMClient(func() (provider.LLMClient, error) {
+		builds++
+		return llm.NewFallbackClientWithProviders(nil), nil
+	}, time.Now)
+
+	for i := 0; i < 3; i++ {
+		if client, err := getLLM(); err != nil || client == nil {
+			t.Fatalf("expected a client, got %v, %v", client, err)
+		}
+	}
+	if builds != 1 {
+		t.Errorf("expected one build, got %d", builds)
+	}
+}
+
+func TestHealthHandler_Status(t *testing.T) {
+	registry := metrics.NewRegistry(time.Minute)
+	registry.Observe(metrics.ComponentBible, "biblegateway", 50*time.Millisecond, nil)
+	registry.Observe(metrics.ComponentLLM, "openai", time.Second, errors.New("timeout"))
+
+	pm := bible.NewProviderManager(nil)
+	pm.RegisterProvider("biblegateway", &MockProvider{})
+	pm.RegisterProvider("biblehub", &MockProvider{})
+
+	h := &HealthHandler{ProviderManager: pm, Metrics: registry}
+	rr := httptest.NewRecorder()
+	h.Status(rr, httptest.NewRequest("GET", "/status", nil))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+
+	var body struct {
+		BibleProviders map[string]metrics.Stats `json:"bible_providers"`
+		LLMProviders   map[string]metrics.Stats `json:"llm_providers"`
+	}
+	if err := json.NewDecoder(rr.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if body.BibleProviders["biblegateway"].Requests != 1 {
+		t.Errorf("expected biblegateway stats, got %+v", body.BibleProviders)
+	}
+	if _, ok := body.BibleProviders["biblehub"]; !ok {
+		t.Error("expected idle registered provider to be listed")
+	}
+	if body.LLMProviders["openai"].Errors != 1 {
+		t.Errorf("expected openai error to be reported, got %+v", body.LLMProviders)
+	}
+}
@@ -7,17 +7,23 @@ import (
 	"bible-api-service/internal/bible/providers/biblehub"
 	"bible-api-service/internal/bible/providers/biblenow"
 	"bible-api-service/internal/chat"
+	"bible-api-service/internal/circuit"
 	"bible-api-service/internal/llm"
 	"bible-api-service/internal/llm/provider"
+	"bible-api-service/internal/logging"
+	"bible-api-service/internal/metrics"
 	"bible-api-service/internal/secrets"
 	"bible-api-service/internal/util"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 )
 
 // QueryHandler is the main handler for the /query endpoint.
@@ -31,11 +37,12 @@ type QueryHandler struct {
 
 // NewQueryHandler creates a new QueryHandler with default clients.
 func NewQueryHandler(secretsClient secrets.Client, versionManager *bible.VersionManager) *QueryHandler {
-	// Initialize providers
-	gatewayProvider := biblegateway.NewScraper()
-	hubProvider := biblehub.NewScraper()
-	nowProvider := biblenow.NewScraper()
-	comProvider := biblecom.NewScraper()
+	// Initialize providers, recording call outcomes for health reporting and
+	// guarding each with a circuit breaker so that a dead upstream fails fast
+	gatewayProvider := bible.NewObservedProvider(bible.DefaultProviderName, biblegateway.NewScraper(), metrics.Default).WithBreaker(circuit.DefaultConfig())
+	hubProvider := bible.NewObservedProvider("biblehub", biblehub.NewScraper(), metrics.Default).WithBreaker(circuit.DefaultConfig())
+	nowProvider := bible.NewObservedProvider("biblenow", biblenow.NewScraper(), metrics.Default).WithBreaker(circuit.DefaultConfig())
+	comProvider := bible.NewObservedProvider("biblecom", biblecom.NewScraper(), metrics.Default).WithBreaker(circuit.DefaultConfig())
 
 	// Initialize ProviderManager with default/primary (gateway)
 	bibleManager := bible.NewProviderManager(gatewayProvider)
@@ -46,44 +53,88 @@ func NewQueryHandler(secretsClient secrets.Client, versionManager *bible.Version
 	bibleManager.RegisterProvider("biblenow", nowProvider)
 	bibleManager.RegisterProvider("biblecom", comProvider)
 
-	var (
-		llmClient provider.LLMClient
-		mu        sync.Mutex
-	)
-
-	getLLMClient := func() (provider.LLMClient, error) {
-		mu.Lock()
-		defer mu.Unlock()
+	// Skip providers with open circuits, or without what a query needs, when
+	// resolving versions
+	if versionManager != nil {
+		versionManager.SetHealthChecker(bibleManager)
+		versionManager.SetCapabilityChecker(bibleManager)
+	}
 
-		if llmClient != nil {
-			return llmClient, nil
+	getLLMClient := cachedLLMClient(func() (provider.LLMClient, error) {
+		client, err := llm.NewFallbackClient(context.Background(), secretsClient)
+		if err != nil {
+			return nil, err
 		}
-
-		var err error
-		llmClient, err = llm.NewFallbackClient(context.Background(), secretsClient)
-		return llmClient, err
-	}
+		return client, nil
+	}, time.Now)
 	return &QueryHandler{
 		ProviderManager: bibleManager,
 		GetLLMClient:    getLLMClient,
 		FFClient:        &GoFeatureFlagClient{},
-		ChatService:     chat.NewChatService(bibleManager, getLLMClient),
+		ChatService:     chat.NewChatService(bibleManager, chat.GetLLMClient(getLLMClient)),
 		VersionManager:  versionManager,
 	}
 }
 
+// Bounds of the delay before a failed LLM client build is retried.
+const (
+	llmClientMinRetry = time.Second
+	llmClientMaxRetry = 5 * time.Minute
+)
+
+// cachedLLMClient returns a GetLLMClient that builds the client once. A failed
+// build is returned as is until a retry delay has passed, so that readiness
+// probes and queries do not repeat the secret lookups of a broken
+// configuration on every call. The delay doubles after each failure, up to
+// llmClientMaxRetry.
+func cachedLLMClient(build GetLLMClient, now func() time.Time) GetLLMClient {
+	var (
+		mu      sync.Mutex
+		client  provider.LLMClient
+		err     error
+		delay   time.Duration
+		retryAt time.Time
+	)
+	return func() (provider.LLMClient, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if client != nil {
+			return client, nil
+		}
+		if err != nil && now().Before(retryAt) {
+			return nil, err
+		}
+		client, err = build()
+		if err != nil {
+			client = nil
+			delay = min(max(2*delay, llmClientMinRetry), llmClientMaxRetry)
+			retryAt = now().Add(delay)
+		}
+		return client, err
+	}
+}
+
 // ServeHTTP handles the HTTP request.
 func (h *QueryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	var request QueryRequest
 	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			util.JSONError(w, http.StatusRequestEntityTooLarge, "Request body too large")
+			return
+		}
 		util.JSONError(w, http.StatusBadRequest, "Invalid request payload")
 		return
 	}
 
-	// Validate exactly one of verses, words, or prompt is present
+	// Validate exactly one of verses, words, topics, prompt, compare, or word_study is present
 	hasVerses := len(request.Query.Verses) > 0
 	hasWords := len(request.Query.Words) > 0
+	hasTopics := len(request.Query.Topics) > 0
 	hasPrompt := request.Query.Prompt != ""
+	hasCompare := request.Query.Compare != nil
+	hasWordStudy := request.Query.WordStudy != nil
 
 	// Count true values
 	count := 0
@@ -93,12 +144,21 @@ func (h *QueryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if hasWords {
 		count++
 	}
+	if hasTopics {
+		count++
+	}
 	if hasPrompt {
 		count++
 	}
+	if hasCompare {
+		count++
+	}
+	if hasWordStudy {
+		count++
+	}
 
 	if count != 1 {
-		util.JSONError(w, http.StatusBadRequest, "Query must contain exactly one of: verses, words, or prompt")
+		util.JSONError(w, http.StatusBadRequest, "Query must contain exactly one of: verses, words, topics, prompt, compare, or word_study")
 		return
 	}
 
@@ -107,7 +167,10 @@ func (h *QueryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		hasContext := len(request.Context.History) > 0 ||
 			request.Context.Schema != "" ||
 			len(request.Context.Verses) > 0 ||
-			len(request.Context.Words) > 0
+			len(request.Context.Words) > 0 ||
+			len(request.Context.Interlinear) > 0 ||
+			len(request.Context.Strongs) > 0 ||
+			len(request.Context.Topics) > 0
 
 		if hasContext {
 			util.JSONError(w, http.StatusBadRequest, "Context object (excluding user preferences) is only valid with a prompt query")
@@ -115,38 +178,140 @@ func (h *QueryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	ctx := r.Context()
+	switch {
+	case hasPrompt:
+		logging.Set(ctx, logging.KeyQueryType, "prompt")
+	case hasVerses:
+		logging.Set(ctx, logging.KeyQueryType, "verses")
+	case hasWords:
+		logging.Set(ctx, logging.KeyQueryType, "words")
+	case hasTopics:
+		logging.Set(ctx, logging.KeyQueryType, "topics")
+	case hasCompare:
+		logging.Set(ctx, logging.KeyQueryType, "compare")
+		// Each version picks its own provider
+		h.handleCompareQuery(w, r, request)
+		return
+	case hasWordStudy:
+		logging.Set(ctx, logging.KeyQueryType, "word_study")
+		// Original-language data does not depend on the version
+		h.handleWordStudyQuery(w, r, request)
+		return
+	}
+
 	if request.Context.User.Version == "" {
 		request.Context.User.Version = "ESV"
 	}
 
-	// Dynamic Provider Selection
-	providerName, providerVersion, err := h.VersionManager.SelectProvider(request.Context.User.Version, nil)
+	// The version's verse numbering and canon are looked up by its unified
+	// code, so keep it before switching to the provider-specific code
+	version := request.Context.User.Version
+
+	// Dynamic Provider Selection, among the providers that can serve the query
+	capability := queryCapability(request)
+	providerName, providerVersion, err := h.providerFor(ctx, version, capability)
 	if err != nil {
-		log.Printf("Provider selection failed for %s: %v. Falling back to %s.", request.Context.User.Version, err, bible.DefaultProviderName)
-		// Fallback
-		providerName = bible.DefaultProviderName
-		providerVersion = request.Context.User.Version
+		slog.WarnContext(ctx, "Provider selection failed", "version", version, "error", err)
+		writeProviderError(w, err, "Verse not found", "Failed to select a provider")
+		return
 	}
+	logging.Set(ctx, logging.KeyProvider, providerName)
 
 	// Update the version in request context to the provider-specific code
 	request.Context.User.Version = providerVersion
 
 	if hasPrompt {
-		h.handlePromptQuery(w, r, request, providerName)
+		h.handlePromptQuery(w, r, request, providerName, version)
 	} else if hasVerses {
-		h.handleVerseQuery(w, r, request, providerName)
+		h.handleVerseQuery(w, r, request, providerName, version)
 	} else if hasWords {
-		h.handleWordSearchQuery(w, r, request, providerName)
+		h.handleWordSearchQuery(w, r, request, providerName, version)
+	} else if hasTopics {
+		h.handleTopicsQuery(w, r, request, providerName, version)
+	}
+}
+
+// providerFor selects the provider of version for a query that needs a
+// capability, returning its name and its code for the version. Notes are
+// optional, so without a provider that can supply them the verses come from
+// any provider. A version none of the preferred providers can serve is
+// fetched from any of its providers, Bible.com included; versions that are
+// not configured are tried on the default provider as they are. Other
+// selection errors, such as every provider having an open circuit, are
+// returned rather than retried through the default provider.
+func (h *QueryHandler) providerFor(ctx context.Context, version, capability string) (string, string, error) {
+	providerName, providerVersion, err := h.VersionManager.SelectProviderFor(version, capability, nil)
+	if err != nil && capability == bible.CapabilityFootnotes {
+		providerName, providerVersion, err = h.VersionManager.SelectProviderFor(version, bible.CapabilityVerses, nil)
 	}
+	_, known := h.VersionManager.Get(version)
+	if known && errors.Is(err, bible.ErrUnsupportedVersion) {
+		var configs []bible.ProviderConfig
+		if configs, err = h.VersionManager.GetPrioritizedProviders(version, nil); err == nil {
+			providerName, providerVersion = configs[0].Name, configs[0].VersionCode
+		}
+	}
+	if err != nil && !known {
+		slog.WarnContext(ctx, "Provider selection failed, falling back to default provider",
+			"version", version, "fallback", bible.DefaultProviderName, "error", err)
+		return bible.DefaultProviderName, version, nil
+	}
+	return providerName, providerVersion, err
 }
 
-func (h *QueryHandler) handlePromptQuery(w http.ResponseWriter, r *http.Request, request QueryRequest, providerName string) {
+// queryCapability returns the capability the provider of a query needs.
+func queryCapability(request QueryRequest) string {
+	switch {
+	case len(request.Query.Words) > 0:
+		return bible.CapabilitySearch
+	case len(request.Query.Verses) > 0 && len(request.Options.Include) > 0:
+		return bible.CapabilityFootnotes
+	default:
+		return bible.CapabilityVerses
+	}
+}
+
+func (h *QueryHandler) handlePromptQuery(w http.ResponseWriter, r *http.Request, request QueryRequest, providerName, version string) {
 	// Validation: Stream and Schema are mutually exclusive
 	if request.Options.Stream && request.Context.Schema != "" {
 		util.JSONError(w, http.StatusBadRequest, "Stream and Schema are mutually exclusive")
 		return
 	}
 
+	format, err := bible.ParseFormat(request.Options.Format)
+	if err != nil {
+		util.JSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	formatName, ok := chat.FormatName(format)
+	if !ok {
+		util.JSONError(w, http.StatusBadRequest, fmt.Sprintf("Format %s is only supported for verse queries", format))
+		return
+	}
+	refVersification, err := bible.ParseVersification(request.Options.Versification)
+	if err != nil {
+		util.JSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	for _, verseRef := range request.Context.Verses {
+		// The chat service reports malformed references
+		book, chapter, _, err := util.ParseVerseReference(verseRef)
+		if err != nil {
+			continue
+		}
+		if err := h.VersionManager.CheckBook(version, book, chapter); err != nil {
+			writeProviderError(w, err, "Verse not found", "Failed to get verse")
+			return
+		}
+	}
+	for _, number := range request.Context.Strongs {
+		if _, err := bible.ParseStrongs(number); err != nil {
+			util.JSONErrorWithCode(w, http.StatusBadRequest, util.ErrorCodeInvalidReference, err.Error())
+			return
+		}
+	}
+
 	// Determine schema. If not provided in Context, use default "Open Query" schema.
 	// Default schema is ONLY injected if NOT streaming.
 	schema := request.Context.Schema
@@ -159,7 +324,7 @@ func (h *QueryHandler) handlePromptQuery(w http.ResponseWriter, r *http.Request,
 				"properties": {
 					"text": {
 						"type": "string",
-						"description": "The response to the query in semantic HTML format."
+						"description": "The response to the query in ` + formatName + ` format."
 					},
 					"references": {
 						"type": "array",
@@ -192,15 +357,33 @@ func (h *QueryHandler) handlePromptQuery(w http.ResponseWriter, r *http.Request,
 		AIProvider: request.Context.User.AIProvider,
 		Stream:     request.Options.Stream,
 		History:    request.Context.History,
+		Format:     format,
+
+		Versification:    h.VersionManager.Versification(version),
+		RefVersification: refVersification,
+
+		Interlinear: request.Context.Interlinear,
+		Strongs:     request.Context.Strongs,
+		Topics:      request.Context.Topics,
 	}
 
 	result, err := h.ChatService.Process(r.Context(), chatReq)
 	if err != nil {
-		log.Printf("ChatService.Process failed: %v", err)
+		slog.ErrorContext(r.Context(), "ChatService.Process failed", "error", err)
 		util.JSONError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 
+	if aiProvider, ok := result.Meta["ai_provider"].(string); ok {
+		logging.Set(r.Context(), logging.KeyAIProvider, aiProvider)
+	}
+	if requestID := logging.RequestID(r.Context()); requestID != "" {
+		if result.Meta == nil {
+			result.Meta = make(map[string]interface{})
+		}
+		result.Meta["request_id"] = requestID
+	}
+
 	if result.IsStream {
 		w.Header().Set("Content-Type", "text/event-stream")
 		w.Header().Set("Cache-Control", "no-cache")
@@ -240,53 +423,154 @@ func (h *QueryHandler) handlePromptQuery(w http.ResponseWriter, r *http.Request,
 	}
 }
 
-func (h *QueryHandler) handleVerseQuery(w http.ResponseWriter, r *http.Request, request QueryRequest, providerName string) {
+func (h *QueryHandler) handleVerseQuery(w http.ResponseWriter, r *http.Request, request QueryRequest, providerName, version string) {
+	opts, err := verseOptions(request.Options.Include)
+	if err != nil {
+		util.JSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	format, err := bible.ParseFormat(request.Options.Format)
+	if err != nil {
+		util.JSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	// Verses are numbered for every provider once a format is requested;
+	// without one, the text is what the provider returns.
+	opts.VerseNumbers = request.Options.Format != ""
+	refVersification, err := bible.ParseVersification(request.Options.Versification)
+	if err != nil {
+		util.JSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	versification := h.VersionManager.Versification(version)
+
 	p, err := h.ProviderManager.GetProvider(providerName)
 	if err != nil {
-		log.Printf("Failed to get provider %s: %v", providerName, err)
+		slog.ErrorContext(r.Context(), "Failed to get provider", "error", err)
 		util.JSONError(w, http.StatusInternalServerError, "Provider configuration error")
 		return
 	}
 
 	var verseText []string
-	for _, verseRef := range request.Query.Verses {
+	verses := []bible.VerseText{}
+	footnotes := []bible.Footnote{}
+	crossRefs := []bible.CrossReference{}
+	// The passages requested first and last, for the links to the chapters
+	// around them
+	var firstBook, lastBook string
+	var firstChapter, lastChapter int
+	for i, verseRef := range request.Query.Verses {
 		book, chapter, verseNum, err := util.ParseVerseReference(verseRef)
 		if err != nil {
-			util.JSONError(w, http.StatusBadRequest, err.Error())
+			util.JSONErrorWithCode(w, http.StatusBadRequest, util.ErrorCodeInvalidReference, err.Error())
+			return
+		}
+		if err := h.VersionManager.CheckBook(version, book, chapter); err != nil {
+			writeProviderError(w, err, "Verse not found", "Failed to get verse")
+			return
+		}
+		start, end := chapterSpan(chapter, verseNum)
+		if i == 0 {
+			firstBook, firstChapter = book, start
+		}
+		lastBook, lastChapter = book, end
+		chapter, verseNum, err = bible.MapReference(book, chapter, verseNum, refVersification, versification)
+		if err != nil {
+			util.JSONErrorWithCode(w, http.StatusBadRequest, util.ErrorCodeInvalidReference, err.Error())
 			return
 		}
 
-		verse, err := p.GetVerse(book, chapter, verseNum, request.Context.User.Version)
+		passage, err := bible.GetPassage(p, book, chapter, verseNum, request.Context.User.Version, opts)
 		if err != nil {
-			log.Printf("Provider %s GetVerse failed for %s %s:%s: %v", providerName, book, chapter, verseNum, err)
-			util.JSONError(w, http.StatusInternalServerError, "Failed to get verse")
+			slog.ErrorContext(r.Context(), "Provider GetVerse failed",
+				"book", book, "chapter", chapter, "verse", verseNum, "error", err)
+			writeProviderError(w, err, "Verse not found", "Failed to get verse")
 			return
 		}
-		verseText = append(verseText, verse)
+		ref := passageReference(book, chapter, verseNum)
+		if format == bible.FormatJSONVerses {
+			split, err := bible.Verses(passage.Text, ref)
+			if err != nil {
+				slog.ErrorContext(r.Context(), "Failed to split verses", "error", err)
+				util.JSONError(w, http.StatusInternalServerError, "Failed to render verse")
+				return
+			}
+			verses = append(verses, split...)
+		} else {
+			text, err := bible.Render(passage.Text, format, ref)
+			if err != nil {
+				slog.ErrorContext(r.Context(), "Failed to render verse", "format", format, "error", err)
+				util.JSONError(w, http.StatusInternalServerError, "Failed to render verse")
+				return
+			}
+			verseText = append(verseText, text)
+		}
+		footnotes = append(footnotes, passage.Footnotes...)
+		crossRefs = append(crossRefs, passage.CrossReferences...)
+	}
+
+	response := map[string]interface{}{}
+	if format == bible.FormatJSONVerses {
+		response["verses"] = verses
+	} else {
+		// Markdown and plain text need a blank line to keep passages apart
+		separator := "\n"
+		if format != bible.FormatHTML {
+			separator = "\n\n"
+		}
+		response["verse"] = strings.Join(verseText, separator)
 	}
-	json.NewEncoder(w).Encode(map[string]string{"verse": strings.Join(verseText, "\n")})
+	if opts.Footnotes {
+		response["footnotes"] = footnotes
+	}
+	if opts.CrossReferences {
+		response["crossrefs"] = crossRefs
+	}
+	// Links are numbered as the references were, so they can be sent back
+	v, _ := h.VersionManager.Get(version)
+	books := bible.CanonBooks(v.Canon, refVersification)
+	if prev, _ := bible.AdjacentChapters(books, firstBook, firstChapter); prev != "" {
+		response["prev"] = prev
+	}
+	if _, next := bible.AdjacentChapters(books, lastBook, lastChapter); next != "" {
+		response["next"] = next
+	}
+	json.NewEncoder(w).Encode(response)
 }
 
-func (h *QueryHandler) handleWordSearchQuery(w http.ResponseWriter, r *http.Request, request QueryRequest, providerName string) {
-	log.Printf("Handling word search query for words: %v using provider: %s", request.Query.Words, providerName)
+// chapterSpan returns the chapters a passage starts and ends in, or 0 if the
+// chapter is malformed.
+func chapterSpan(chapter, verse string) (int, int) {
+	start, _ := strconv.Atoi(chapter)
+	if parsed, err := util.ParseVerseRange(verse); err == nil && parsed.IsCrossChapter {
+		return start, parsed.EndChapter
+	}
+	return start, start
+}
 
-	p, err := h.ProviderManager.GetProvider(providerName)
-	if err != nil {
-		log.Printf("Failed to get provider %s: %v", providerName, err)
-		util.JSONError(w, http.StatusInternalServerError, "Provider configuration error")
-		return
+// passageReference returns where a passage starts, for the formats that
+// number its verses. Whole chapters start at verse 1.
+func passageReference(book, chapter, verse string) bible.Reference {
+	ref := bible.Reference{Book: book, Verse: 1}
+	ref.Chapter, _ = strconv.Atoi(chapter)
+	if parsed, err := util.ParseVerseRange(verse); err == nil && parsed.StartVerse > 0 {
+		ref.Verse = parsed.StartVerse
 	}
+	return ref
+}
 
-	allResults := make([]bible.SearchResult, 0)
-	for _, word := range request.Query.Words {
-		results, err := p.SearchWords(word, request.Context.User.Version)
-		if err != nil {
-			log.Printf("Error searching words '%s' with provider %s: %v", word, providerName, err)
-			util.JSONError(w, http.StatusInternalServerError, "Failed to search words")
-			return
+// verseOptions maps options.include to the notes requested from the provider.
+func verseOptions(include []string) (bible.VerseOptions, error) {
+	var opts bible.VerseOptions
+	for _, item := range include {
+		switch item {
+		case "footnotes":
+			opts.Footnotes = true
+		case "crossrefs":
+			opts.CrossReferences = true
+		default:
+			return opts, fmt.Errorf("unsupported include option: %q", item)
 		}
-		log.Printf("Found %d results for word '%s'", len(results), word)
-		allResults = append(allResults, results...)
 	}
-	json.NewEncoder(w).Encode(allResults)
+	return opts, nil
 }
@@ -3,7 +3,10 @@ package handlers
 import (
 	"bible-api-service/internal/bible"
 	"bible-api-service/internal/chat"
+	"bible-api-service/internal/circuit"
+	"bible-api-service/internal/logging"
 	"bible-api-service/internal/secrets"
+	"bible-api-service/internal/util"
 	"bytes"
 	"context"
 	"encoding/json"
@@ -283,6 +286,49 @@ func TestHandlePromptQuery_Stream(t *testing.T) {
 	}
 }
 
+func TestHandlePromptQuery_RequestIDInMeta(t *testing.T) {
+	vm := createTestVersionManager(t)
+	pm := bible.NewProviderManager(nil)
+
+	handler := &QueryHandler{
+		ChatService: &mockChatService{
+			processFunc: func(ctx context.Context, req chat.Request) (*chat.Result, error) {
+				return &chat.Result{
+					Data: chat.Response{"text": "ok"},
+					Meta: map[string]interface{}{"ai_provider": "openai"},
+				}, nil
+			},
+		},
+		VersionManager:  vm,
+		ProviderManager: pm,
+	}
+
+	reqBody := `{"query": {"prompt": "Hello"}}`
+	req := httptest.NewRequest("POST", "/query", bytes.NewBufferString(reqBody))
+	ctx := logging.NewContext(req.Context())
+	logging.Set(ctx, logging.KeyRequestID, "req-42")
+	req = req.WithContext(ctx)
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	var response struct {
+		Meta map[string]interface{} `json:"meta"`
+	}
+	if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Meta["request_id"] != "req-42" {
+		t.Errorf("expected request_id in meta, got %v", response.Meta)
+	}
+	if got := logging.Get(ctx, logging.KeyAIProvider); got != "openai" {
+		t.Errorf("expected ai_provider log attribute, got %q", got)
+	}
+	if got := logging.Get(ctx, logging.KeyQueryType); got != "prompt" {
+		t.Errorf("expected query_type log attribute, got %q", got)
+	}
+}
+
 func TestHandlePromptQuery_WithContext(t *testing.T) {
 	vm := createTestVersionManager(t)
 	pm := bible.NewProviderManager(nil)
@@ -380,6 +426,22 @@ func TestInvalidRequest_NoQuery(t *testing.T) {
 	}
 }
 
+func TestInvalidRequest_BodyTooLarge(t *testing.T) {
+	handler := &QueryHandler{}
+
+	reqBody := `{"query": {"prompt": "` + strings.Repeat("a", 2048) + `"}}`
+	req := httptest.NewRequest("POST", "/query", bytes.NewBufferString(reqBody))
+	rr := httptest.NewRecorder()
+	req.Body = http.MaxBytesReader(rr, req.Body, 1024)
+
+	handler.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusRequestEntityTooLarge {
+		t.Errorf("handler returned wrong status code: got %v want %v",
+			status, http.StatusRequestEntityTooLarge)
+	}
+}
+
 func TestHandleVerseQuery_BookWithSpaces(t *testing.T) {
 	vm := createTestVersionManager(t)
 	mockP := &MockProvider{
@@ -554,3 +616,359 @@ func TestInvalidRequestPayload(t *testing.T) {
 			status, http.StatusBadRequest)
 	}
 }
+
+// notesProvider is a MockProvider that also returns notes.
+type notesProvider struct {
+	MockProvider
+	passage bible.Passage
+}
+
+func (p *notesProvider) GetPassage(book, chapter, verse, version string, opts bible.VerseOptions) (bible.Passage, error) {
+	return p.passage, nil
+}
+
+func TestHandleVerseQuery_Include(t *testing.T) {
+	vm := createTestVersionManager(t)
+
+	notesP := &notesProvider{passage: bible.Passage{
+		Text:            "For God so loved the world...",
+		Footnotes:       []bible.Footnote{{Verse: "John 3:16", Marker: "a", Text: "Or For this is how God loved the world"}},
+		CrossReferences: []bible.CrossReference{{Verse: "John 3:16", Marker: "A", Targets: []string{"Romans 5:8"}}},
+	}}
+	plainP := &MockProvider{
+		getVerseFunc: func(book, chapter, verse, version string) (string, error) {
+			return "For God so loved the world...", nil
+		},
+	}
+
+	query := func(p bible.Provider, include string) *httptest.ResponseRecorder {
+		pm := bible.NewProviderManager(p)
+		pm.RegisterProvider(bible.DefaultProviderName, p)
+		handler := &QueryHandler{ProviderManager: pm, VersionManager: vm}
+		reqBody := `{"query": {"verses": ["John 3:16"]}, "options": {"include": ` + include + `}}`
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest("POST", "/query", bytes.NewBufferString(reqBody)))
+		return rr
+	}
+
+	t.Run("returns requested notes", func(t *testing.T) {
+		rr := query(notesP, `["footnotes", "crossrefs"]`)
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.JSONEq(t, `{
+			"verse": "For God so loved the world...",
+			"footnotes": [{"verse": "John 3:16", "marker": "a", "text": "Or For this is how God loved the world"}],
+			"crossrefs": [{"verse": "John 3:16", "marker": "A", "targets": ["Romans 5:8"]}],
+			"prev": "John 2",
+			"next": "John 4"
+		}`, rr.Body.String())
+	})
+
+	t.Run("omits notes that were not requested", func(t *testing.T) {
+		rr := query(notesP, `["crossrefs"]`)
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.NotContains(t, rr.Body.String(), "footnotes")
+		require.Contains(t, rr.Body.String(), "crossrefs")
+	})
+
+	t.Run("providers without notes return empty lists", func(t *testing.T) {
+		rr := query(plainP, `["footnotes", "crossrefs"]`)
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.JSONEq(t, `{"verse": "For God so loved the world...", "footnotes": [], "crossrefs": [], "prev": "John 2", "next": "John 4"}`, rr.Body.String())
+	})
+
+	t.Run("rejects unknown options", func(t *testing.T) {
+		rr := query(notesP, `["audio"]`)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestHandleVerseQuery_IncludeWithoutNotesProvider(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "versions.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+- code: ESV
+  name: English Standard Version
+  language: English
+  providers:
+    biblegateway: ESV
+    biblehub: esv
+`), 0644))
+	vm, err := bible.NewVersionManager(configPath)
+	require.NoError(t, err)
+
+	gateway := &notesProvider{passage: bible.Passage{Text: "For God so loved the world..."}}
+	hub := &MockProvider{
+		getVerseFunc: func(book, chapter, verse, version string) (string, error) {
+			return "For God so loved the world, " + version, nil
+		},
+	}
+	pm := bible.NewProviderManager(gateway)
+	pm.RegisterProvider(bible.DefaultProviderName, gateway)
+	pm.RegisterProvider("biblehub", hub)
+	vm.SetCapabilityChecker(pm)
+	vm.SetHealthChecker(stubProviderHealth{bible.DefaultProviderName: circuit.Open})
+	handler := &QueryHandler{ProviderManager: pm, VersionManager: vm}
+
+	// The only provider with notes is open, so the verse comes from BibleHub
+	reqBody := `{"query": {"verses": ["John 3:16"]}, "options": {"include": ["footnotes", "crossrefs"]}}`
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("POST", "/query", bytes.NewBufferString(reqBody)))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	require.JSONEq(t, `{"verse": "For God so loved the world, esv", "footnotes": [], "crossrefs": [], "prev": "John 2", "next": "John 4"}`, rr.Body.String())
+}
+
+func TestHandleVerseQuery_Format(t *testing.T) {
+	vm := createTestVersionManager(t)
+
+	mockP := &MockProvider{
+		getVerseFunc: func(book, chapter, verse, version string) (string, error) {
+			return `<h3>The Lord Is My Shepherd</h3><p><span class="line"><sup>1 </sup>The <span class="divine-name">Lord</span> is my shepherd;</span></p>`, nil
+		},
+	}
+	pm := bible.NewProviderManager(mockP)
+	pm.RegisterProvider(bible.DefaultProviderName, mockP)
+	handler := &QueryHandler{ProviderManager: pm, VersionManager: vm}
+
+	query := func(format string) *httptest.ResponseRecorder {
+		reqBody := `{"query": {"verses": ["Psalm 23:1", "Psalm 23:1"]}, "options": {"format": "` + format + `"}}`
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest("POST", "/query", bytes.NewBufferString(reqBody)))
+		return rr
+	}
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"", `<h3>The Lord Is My Shepherd</h3><p><span class="line"><sup>1 </sup>The <span class="divine-name">Lord</span> is my shepherd;</span></p>` + "\n" + `<h3>The Lord Is My Shepherd</h3><p><span class="line"><sup>1 </sup>The <span class="divine-name">Lord</span> is my shepherd;</span></p>`},
+		{"plain", "The Lord Is My Shepherd\n\n1 The LORD is my shepherd;\n\nThe Lord Is My Shepherd\n\n1 The LORD is my shepherd;"},
+		{"markdown", "### The Lord Is My Shepherd\n\n**1** The LORD is my shepherd;\n\n### The Lord Is My Shepherd\n\n**1** The LORD is my shepherd;"},
+	}
+	for _, tt := range tests {
+		t.Run("format "+tt.format, func(t *testing.T) {
+			rr := query(tt.format)
+			require.Equal(t, http.StatusOK, rr.Code)
+			var response map[string]string
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+			require.Equal(t, tt.want, response["verse"])
+		})
+	}
+
+	t.Run("rejects unknown formats", func(t *testing.T) {
+		rr := query("pdf")
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+// numberedProvider numbers its verses only when asked to.
+type numberedProvider struct {
+	MockProvider
+}
+
+func (p *numberedProvider) GetNumberedVerse(book, chapter, verse, version string) (string, error) {
+	return "<sup>1 </sup>The Lord is my shepherd;", nil
+}
+
+func TestHandleVerseQuery_VerseNumbers(t *testing.T) {
+	vm := createTestVersionManager(t)
+
+	mockP := &numberedProvider{MockProvider{
+		getVerseFunc: func(book, chapter, verse, version string) (string, error) {
+			return "The Lord is my shepherd;", nil
+		},
+	}}
+	pm := bible.NewProviderManager(mockP)
+	pm.RegisterProvider(bible.DefaultProviderName, mockP)
+	handler := &QueryHandler{ProviderManager: pm, VersionManager: vm}
+
+	for options, want := range map[string]string{
+		`{}`:                  "The Lord is my shepherd;",
+		`{"format": "html"}`:  "<sup>1 </sup>The Lord is my shepherd;",
+		`{"format": "plain"}`: "1 The Lord is my shepherd;",
+	} {
+		reqBody := `{"query": {"verses": ["Psalm 23:1"]}, "options": ` + options + `}`
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest("POST", "/query", bytes.NewBufferString(reqBody)))
+		require.Equal(t, http.StatusOK, rr.Code, options)
+		var response map[string]string
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+		require.Equal(t, want, response["verse"], options)
+	}
+}
+
+func TestHandleVerseQuery_StructuredFormats(t *testing.T) {
+	vm := createTestVersionManager(t)
+
+	mockP := &MockProvider{
+		getVerseFunc: func(book, chapter, verse, version string) (string, error) {
+			return `<sup>36 </sup>Whoever believes in the Son has eternal life. <sup>1 </sup>Now when Jesus learned`, nil
+		},
+	}
+	pm := bible.NewProviderManager(mockP)
+	pm.RegisterProvider(bible.DefaultProviderName, mockP)
+	handler := &QueryHandler{ProviderManager: pm, VersionManager: vm}
+
+	query := func(format string) *httptest.ResponseRecorder {
+		reqBody := `{"query": {"verses": ["John 3:36-4:1"]}, "options": {"format": "` + format + `"}}`
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest("POST", "/query", bytes.NewBufferString(reqBody)))
+		return rr
+	}
+
+	t.Run("json-verses", func(t *testing.T) {
+		rr := query("json-verses")
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.JSONEq(t, `{"verses": [
+			{"book": "John", "chapter": 3, "verse": 36, "text": "Whoever believes in the Son has eternal life."},
+			{"book": "John", "chapter": 4, "verse": 1, "text": "Now when Jesus learned"}
+		], "prev": "John 2", "next": "John 5"}`, rr.Body.String())
+	})
+
+	t.Run("usfm", func(t *testing.T) {
+		rr := query("usfm")
+		require.Equal(t, http.StatusOK, rr.Code)
+		var response map[string]string
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+		require.Equal(t, "\\c 3\n\\v 36 Whoever believes in the Son has eternal life.\n\\c 4\n\\p\n\\v 1 Now when Jesus learned", response["verse"])
+	})
+}
+
+func TestHandlePromptQuery_StructuredFormatRejected(t *testing.T) {
+	vm := createTestVersionManager(t)
+	handler := &QueryHandler{VersionManager: vm, ProviderManager: bible.NewProviderManager(&MockProvider{})}
+
+	reqBody := `{"query": {"prompt": "Explain grace"}, "options": {"format": "json-verses"}}`
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("POST", "/query", bytes.NewBufferString(reqBody)))
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestHandleVerseQuery_Versification(t *testing.T) {
+	requested := ""
+	mockP := &MockProvider{
+		getVerseFunc: func(book, chapter, verse, version string) (string, error) {
+			requested = book + " " + chapter + ":" + verse
+			return "Lo, I will send you Elijah the prophet", nil
+		},
+	}
+	pm := bible.NewProviderManager(mockP)
+	pm.RegisterProvider(bible.DefaultProviderName, mockP)
+	pm.RegisterProvider("biblehub", mockP)
+	handler := &QueryHandler{ProviderManager: pm, VersionManager: createCompareVersionManager(t)}
+
+	tests := []struct {
+		name    string
+		reqBody string
+		want    string
+	}{
+		{
+			name:    "english reference to hebrew version",
+			reqBody: `{"query": {"verses": ["Malachi 4:5"]}, "context": {"user": {"version": "NABRE"}}}`,
+			want:    "Malachi 3:23",
+		},
+		{
+			name:    "reference in the version's numbering",
+			reqBody: `{"query": {"verses": ["Malachi 3:23"]}, "context": {"user": {"version": "NABRE"}}, "options": {"versification": "hebrew"}}`,
+			want:    "Malachi 3:23",
+		},
+		{
+			name:    "hebrew reference to english version",
+			reqBody: `{"query": {"verses": ["Malachi 3:23"]}, "context": {"user": {"version": "ESV"}}, "options": {"versification": "hebrew"}}`,
+			want:    "Malachi 4:5",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, httptest.NewRequest("POST", "/query", bytes.NewBufferString(tt.reqBody)))
+			require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+			require.Equal(t, tt.want, requested)
+		})
+	}
+
+	t.Run("unsupported versification", func(t *testing.T) {
+		reqBody := `{"query": {"verses": ["Malachi 4:5"]}, "options": {"versification": "masoretic"}}`
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest("POST", "/query", bytes.NewBufferString(reqBody)))
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestHandleQuery_BookNotInCanon(t *testing.T) {
+	mockP := &MockProvider{
+		getVerseFunc: func(book, chapter, verse, version string) (string, error) {
+			return "<sup>1 </sup>This book tells the story of Tobit", nil
+		},
+	}
+	pm := bible.NewProviderManager(mockP)
+	pm.RegisterProvider(bible.DefaultProviderName, mockP)
+	pm.RegisterProvider("biblehub", mockP)
+	handler := &QueryHandler{ProviderManager: pm, VersionManager: createCompareVersionManager(t)}
+
+	t.Run("catholic version", func(t *testing.T) {
+		reqBody := `{"query": {"verses": ["Tobit 1:1"]}, "context": {"user": {"version": "NABRE"}}}`
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest("POST", "/query", bytes.NewBufferString(reqBody)))
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	})
+
+	rejected := map[string]string{
+		"verses":  `{"query": {"verses": ["Tobit 1:1"]}, "context": {"user": {"version": "ESV"}}}`,
+		"prompt":  `{"query": {"prompt": "Who was Tobit?"}, "context": {"verses": ["Tobit 1:1"], "user": {"version": "ESV"}}}`,
+		"compare": `{"query": {"compare": {"reference": "Tobit 1:1", "versions": ["NABRE", "ESV"]}}}`,
+	}
+	for name, reqBody := range rejected {
+		t.Run(name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, httptest.NewRequest("POST", "/query", bytes.NewBufferString(reqBody)))
+			require.Equal(t, http.StatusBadRequest, rr.Code)
+
+			var response util.ErrorResponse
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+			require.Equal(t, util.ErrorCodeUnsupportedVersion, response.Error.ErrorCode)
+		})
+	}
+}
+
+func TestHandleVerseQuery_ChapterLinks(t *testing.T) {
+	mockP := &MockProvider{
+		getVerseFunc: func(book, chapter, verse, version string) (string, error) {
+			return "<sup>1 </sup>The oracle of the word of the Lord", nil
+		},
+	}
+	pm := bible.NewProviderManager(mockP)
+	pm.RegisterProvider(bible.DefaultProviderName, mockP)
+	pm.RegisterProvider("biblehub", mockP)
+	handler := &QueryHandler{ProviderManager: pm, VersionManager: createCompareVersionManager(t)}
+
+	tests := []struct {
+		name       string
+		reqBody    string
+		prev, next string
+	}{
+		{"whole chapter", `{"query": {"verses": ["Malachi 1"]}}`, "Zechariah 14", "Malachi 2"},
+		{"several passages", `{"query": {"verses": ["Genesis 1:1", "John 3:16"]}}`, "", "John 4"},
+		{"last chapter", `{"query": {"verses": ["Revelation 22:21"]}}`, "Revelation 21", ""},
+		{"version numbering", `{"query": {"verses": ["Malachi 3:20"]}, "context": {"user": {"version": "NABRE"}}, "options": {"versification": "hebrew"}}`, "Malachi 2", "Matthew 1"},
+		{"catholic canon", `{"query": {"verses": ["Nehemiah 13:31"]}, "context": {"user": {"version": "NABRE"}}}`, "Nehemiah 12", "Tobit 1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, httptest.NewRequest("POST", "/query", bytes.NewBufferString(tt.reqBody)))
+			require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+			var response map[string]interface{}
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+			if tt.prev == "" {
+				require.NotContains(t, response, "prev")
+			} else {
+				require.Equal(t, tt.prev, response["prev"])
+			}
+			if tt.next == "" {
+				require.NotContains(t, response, "next")
+			} else {
+				require.Equal(t, tt.next, response["next"])
+			}
+		})
+	}
+}
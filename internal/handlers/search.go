@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"bible-api-service/internal/bible"
+	"bible-api-service/internal/util"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+)
+
+const (
+	// maxSearchSources limits the equivalent versions searched in place of a
+	// version no provider can search.
+	maxSearchSources = 2
+	// maxFederatedResults limits the verses found in equivalent versions that
+	// are fetched in the requested version.
+	maxFederatedResults = 25
+)
+
+// handleWordSearchQuery searches for the words of a query through the
+// version's provider, or in equivalent versions when that provider cannot
+// search, merging the results of every search.
+func (h *QueryHandler) handleWordSearchQuery(w http.ResponseWriter, r *http.Request, request QueryRequest, providerName, version string) {
+	p, err := h.ProviderManager.GetProvider(providerName)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to get provider", "error", err)
+		util.JSONError(w, http.StatusInternalServerError, "Provider configuration error")
+		return
+	}
+	searchElsewhere := !bible.HasCapability(p, bible.CapabilitySearch)
+	slog.DebugContext(r.Context(), "Handling word search query", "words", len(request.Query.Words), "search_elsewhere", searchElsewhere)
+
+	var results []bible.SearchResult
+	if searchElsewhere {
+		results, err = h.searchEquivalents(r.Context(), request.Query.Words, version)
+		if err == nil {
+			results = h.addSearchSnippets(r.Context(), p, results, request.Context.User.Version, version)
+		}
+	} else {
+		results, err = searchWords(p, request.Query.Words, request.Context.User.Version)
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error searching words", "error", err)
+		writeProviderError(w, err, "Not found", "Failed to search words")
+		return
+	}
+	slog.DebugContext(r.Context(), "Word search completed", "results", len(results))
+	json.NewEncoder(w).Encode(results)
+}
+
+// searchWords searches for each word in version through p and merges the results.
+func searchWords(p bible.Provider, words []string, version string) ([]bible.SearchResult, error) {
+	lists := make([][]bible.SearchResult, 0, len(words))
+	for _, word := range words {
+		results, err := bible.Search(p, word, version)
+		if err != nil {
+			return nil, err
+		}
+		lists = append(lists, results)
+	}
+	return bible.MergeSearchResults(lists...), nil
+}
+
+// searchEquivalents searches for words in the versions equivalent to version
+// concurrently, and merges the results in the order of the versions. Versions
+// whose search fails are skipped, unless the search fails for all of them.
+func (h *QueryHandler) searchEquivalents(ctx context.Context, words []string, version string) ([]bible.SearchResult, error) {
+	equivalents := h.VersionManager.EquivalentVersions(version, bible.CapabilitySearch)
+	if len(equivalents) == 0 {
+		return nil, fmt.Errorf("%w: no provider can search %s or an equivalent version", bible.ErrUnsupportedVersion, version)
+	}
+	if len(equivalents) > maxSearchSources {
+		equivalents = equivalents[:maxSearchSources]
+	}
+
+	lists := make([][]bible.SearchResult, len(equivalents))
+	errs := make([]error, len(equivalents))
+	var wg sync.WaitGroup
+	for i, v := range equivalents {
+		wg.Add(1)
+		go func(i int, code string) {
+			defer wg.Done()
+			lists[i], errs[i] = h.searchVersion(words, code)
+			if errs[i] != nil {
+				slog.WarnContext(ctx, "Failed to search equivalent version", "version", version, "equivalent", code, "error", errs[i])
+			}
+		}(i, v.Code)
+	}
+	wg.Wait()
+
+	failed := 0
+	for _, err := range errs {
+		if err != nil {
+			failed++
+		}
+	}
+	if failed == len(errs) {
+		return nil, errs[0]
+	}
+	results := bible.MergeSearchResults(lists...)
+	if len(results) > maxFederatedResults {
+		results = results[:maxFederatedResults]
+	}
+	return results, nil
+}
+
+// searchVersion searches for words in a version through the best provider
+// that can search it.
+func (h *QueryHandler) searchVersion(words []string, code string) ([]bible.SearchResult, error) {
+	name, providerVersion, err := h.VersionManager.SelectProviderFor(code, bible.CapabilitySearch, nil)
+	if err != nil {
+		return nil, err
+	}
+	p, err := h.ProviderManager.GetProvider(name)
+	if err != nil {
+		return nil, err
+	}
+	return searchWords(p, words, providerVersion)
+}
+
+// addSearchSnippets replaces the text of results found in other versions by
+// the text of the verses in the requested version, fetched concurrently.
+// Verses the version does not have are dropped; verses that cannot be fetched
+// otherwise keep their reference without text.
+func (h *QueryHandler) addSearchSnippets(ctx context.Context, p bible.Provider, results []bible.SearchResult, providerVersion, version string) []bible.SearchResult {
+	// Equivalent versions share the version's numbering
+	versification := h.VersionManager.Versification(version)
+	missing := make([]bool, len(results))
+	var wg sync.WaitGroup
+	for i := range results {
+		// Links lead to the other version
+		results[i] = bible.SearchResult{Verse: bible.NormalizeReference(results[i].Verse)}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			text, err := h.fetchSnippet(p, results[i].Verse, providerVersion, version, versification, versification)
+			if err != nil {
+				slog.WarnContext(ctx, "Failed to fetch search result", "reference", results[i].Verse, "error", err)
+				missing[i] = errors.Is(err, bible.ErrNotFound) || errors.Is(err, bible.ErrUnsupportedVersion)
+				return
+			}
+			results[i].Text = text
+		}(i)
+	}
+	wg.Wait()
+
+	found := make([]bible.SearchResult, 0, len(results))
+	for i, result := range results {
+		if !missing[i] {
+			found = append(found, result)
+		}
+	}
+	return found
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"bible-api-service/internal/bible"
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// createFederatedVersionManager maps the NIVUK to Bible.com only, which
+// cannot search, along with English versions Bible Gateway can search.
+func createFederatedVersionManager(t *testing.T, pm *bible.ProviderManager) *bible.VersionManager {
+	configPath := filepath.Join(t.TempDir(), "versions.yaml")
+	content := `
+- code: NIVUK
+  name: New International Version (Anglicised)
+  language: English
+  language_code: eng
+  providers:
+    biblecom: "113"
+- code: KJV
+  name: King James Version
+  language: English
+  language_code: eng
+  providers:
+    biblegateway: KJV
+- code: ESV
+  name: English Standard Version
+  language: English
+  language_code: eng
+  providers:
+    biblegateway: ESV
+- code: RSV
+  name: Revised Standard Version
+  language: English
+  language_code: eng
+  providers:
+    biblegateway: RSV
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0644))
+	vm, err := bible.NewVersionManager(configPath)
+	require.NoError(t, err)
+	vm.SetCapabilityChecker(pm)
+	return vm
+}
+
+func TestHandleWordSearchQuery_MergesWords(t *testing.T) {
+	gateway := &MockProvider{
+		searchWordsFunc: func(query, version string) ([]bible.SearchResult, error) {
+			if query == "kind" {
+				return []bible.SearchResult{{Verse: "Eph 4:32", Text: "Be kind"}, {Verse: "Luke 6:35", Text: "he is kind"}}, nil
+			}
+			return []bible.SearchResult{{Verse: "Ephesians 4:32", Text: "tenderhearted"}}, nil
+		},
+	}
+	pm := bible.NewProviderManager(gateway)
+	pm.RegisterProvider(bible.DefaultProviderName, gateway)
+	handler := &QueryHandler{ProviderManager: pm, VersionManager: createTestVersionManager(t)}
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("POST", "/query", bytes.NewBufferString(`{"query": {"words": ["kind", "tenderhearted"]}}`)))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	require.JSONEq(t, `[
+		{"verse": "Eph 4:32", "text": "Be kind", "url": ""},
+		{"verse": "Luke 6:35", "text": "he is kind", "url": ""}
+	]`, rr.Body.String())
+}
+
+func TestHandleWordSearchQuery_EquivalentVersions(t *testing.T) {
+	var mu sync.Mutex
+	var searched []string
+	gateway := &MockProvider{
+		searchWordsFunc: func(query, version string) ([]bible.SearchResult, error) {
+			mu.Lock()
+			searched = append(searched, version)
+			mu.Unlock()
+			if version == "KJV" {
+				return []bible.SearchResult{
+					{Verse: "Eph 4:32", Text: "be ye kind", URL: "https://example.com/kjv"},
+					{Verse: "Tobit 4:7", Text: "Give alms"},
+					{Verse: "John 99:1", Text: "kind"},
+				}, nil
+			}
+			return []bible.SearchResult{{Verse: "Ephesians 4:32", Text: "Be kind"}, {Verse: "Col 3:12", Text: "kindness"}}, nil
+		},
+	}
+	var fetched []string
+	com := verseOnlyProvider{getVerseFunc: func(book, chapter, verse, version string) (string, error) {
+		mu.Lock()
+		fetched = append(fetched, fmt.Sprintf("%s %s:%s in %s", book, chapter, verse, version))
+		mu.Unlock()
+		if chapter == "99" {
+			return "", fmt.Errorf("chapter %w", bible.ErrNotFound)
+		}
+		return fmt.Sprintf("<sup>%s </sup>%s %s:%s in the NIVUK", verse, book, chapter, verse), nil
+	}}
+	pm := bible.NewProviderManager(gateway)
+	pm.RegisterProvider(bible.DefaultProviderName, gateway)
+	pm.RegisterProvider("biblecom", com)
+	handler := &QueryHandler{ProviderManager: pm, VersionManager: createFederatedVersionManager(t, pm)}
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("POST", "/query", bytes.NewBufferString(`{"query": {"words": ["kind"]}, "context": {"user": {"version": "NIVUK"}}}`)))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	// Only the first two equivalent versions are searched, and the verses
+	// found are fetched from Bible.com. Tobit is not in the NIVUK.
+	sort.Strings(searched)
+	require.Equal(t, []string{"ESV", "KJV"}, searched)
+	sort.Strings(fetched)
+	require.Equal(t, []string{"Colossians 3:12 in 113", "Ephesians 4:32 in 113", "John 99:1 in 113"}, fetched)
+	require.JSONEq(t, `[
+		{"verse": "Ephesians 4:32", "text": "Ephesians 4:32 in the NIVUK", "url": ""},
+		{"verse": "Colossians 3:12", "text": "Colossians 3:12 in the NIVUK", "url": ""}
+	]`, rr.Body.String())
+}
+
+func TestHandleWordSearchQuery_EquivalentVersionsFail(t *testing.T) {
+	gateway := &MockProvider{
+		searchWordsFunc: func(query, version string) ([]bible.SearchResult, error) {
+			return nil, fmt.Errorf("search %s: %w", version, bible.ErrUpstreamUnavailable)
+		},
+	}
+	pm := bible.NewProviderManager(gateway)
+	pm.RegisterProvider(bible.DefaultProviderName, gateway)
+	pm.RegisterProvider("biblecom", verseOnlyProvider{})
+	handler := &QueryHandler{ProviderManager: pm, VersionManager: createFederatedVersionManager(t, pm)}
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("POST", "/query", bytes.NewBufferString(`{"query": {"words": ["kind"]}, "context": {"user": {"version": "NIVUK"}}}`)))
+	require.Equal(t, http.StatusServiceUnavailable, rr.Code, rr.Body.String())
+	require.False(t, strings.Contains(rr.Body.String(), "search KJV"), "provider errors are not returned")
+}
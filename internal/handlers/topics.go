@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"bible-api-service/internal/bible"
+	"bible-api-service/internal/util"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+const (
+	// maxTopics limits the topics a topics query looks up at once.
+	maxTopics = 5
+	// maxTopicVerses is the number of best ranked verses returned per topic.
+	maxTopicVerses = 10
+)
+
+func (h *QueryHandler) handleTopicsQuery(w http.ResponseWriter, r *http.Request, request QueryRequest, providerName, version string) {
+	if request.Options.Stream || request.Options.Format != "" || len(request.Options.Include) > 0 || request.Options.Versification != "" {
+		util.JSONError(w, http.StatusBadRequest, "Options are not supported for topics queries")
+		return
+	}
+	if len(request.Query.Topics) > maxTopics {
+		util.JSONError(w, http.StatusBadRequest, fmt.Sprintf("Topics queries look up at most %d topics", maxTopics))
+		return
+	}
+
+	p, err := h.ProviderManager.GetProvider(providerName)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to get provider", "error", err)
+		util.JSONError(w, http.StatusInternalServerError, "Provider configuration error")
+		return
+	}
+	// Without a topical index, topics are searched for
+	index, err := h.ProviderManager.ProviderWith(bible.CapabilityTopics)
+	if err != nil {
+		index = nil
+	}
+
+	search := func(topic string) ([]bible.SearchResult, error) {
+		return bible.Search(p, topic, request.Context.User.Version)
+	}
+	if !bible.HasCapability(p, bible.CapabilitySearch) {
+		search = func(topic string) ([]bible.SearchResult, error) {
+			return h.searchTopicEquivalents(r.Context(), p, topic, request.Context.User.Version, version)
+		}
+	}
+
+	topics := make([]bible.Topic, 0, len(request.Query.Topics))
+	for _, name := range request.Query.Topics {
+		topic, err := bible.FindTopic(index, search, name)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Failed to look up topic", "topic", name, "error", err)
+			writeProviderError(w, err, "Topic not found", "Failed to look up topic")
+			return
+		}
+		if len(topic.Verses) > maxTopicVerses {
+			topic.Verses = topic.Verses[:maxTopicVerses]
+		}
+		h.addTopicSnippets(r.Context(), p, topic.Verses, request.Context.User.Version, version)
+		topics = append(topics, topic)
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{"topics": topics})
+}
+
+// searchTopicEquivalents searches for a topic in the versions equivalent to
+// version, as word searches do when the version's provider cannot search.
+// The verses found are quoted in the version and numbered in the English
+// numbering, like those of a topical index.
+func (h *QueryHandler) searchTopicEquivalents(ctx context.Context, p bible.Provider, topic, providerVersion, version string) ([]bible.SearchResult, error) {
+	results, err := h.searchEquivalents(ctx, []string{topic}, version)
+	if err != nil {
+		return nil, err
+	}
+	results = h.addSearchSnippets(ctx, p, results, providerVersion, version)
+	versification := h.VersionManager.Versification(version)
+	if versification == bible.VersificationEnglish {
+		return results, nil
+	}
+	english := make([]bible.SearchResult, 0, len(results))
+	for _, result := range results {
+		book, chapter, verse, err := util.ParseVerseReference(result.Verse)
+		if err != nil {
+			continue
+		}
+		chapter, verse, err = bible.MapReference(book, chapter, verse, versification, bible.VersificationEnglish)
+		if err != nil {
+			continue
+		}
+		result.Verse = fmt.Sprintf("%s %s:%s", book, chapter, verse)
+		english = append(english, result)
+	}
+	return english, nil
+}
+
+// addTopicSnippets sets the text of the verses of a topic to plain text in
+// the requested version. Topical indexes only list references, so their
+// verses are fetched concurrently; search results are rendered as plain
+// text. A verse that cannot be fetched is left without text.
+func (h *QueryHandler) addTopicSnippets(ctx context.Context, p bible.Provider, verses []bible.TopicVerse, providerVersion, version string) {
+	versification := h.VersionManager.Versification(version)
+	var wg sync.WaitGroup
+	for i := range verses {
+		if verses[i].Text != "" {
+			text, err := bible.Render(verses[i].Text, bible.FormatPlain, bible.Reference{})
+			if err == nil {
+				verses[i].Text = text
+			}
+			continue
+		}
+		wg.Add(1)
+		go func(v *bible.TopicVerse) {
+			defer wg.Done()
+			text, err := h.fetchSnippet(p, v.Reference, providerVersion, version, bible.VersificationEnglish, versification)
+			if err != nil {
+				slog.WarnContext(ctx, "Failed to fetch topic verse", "reference", v.Reference, "error", err)
+				return
+			}
+			v.Text = text
+		}(&verses[i])
+	}
+	wg.Wait()
+}
+
+// fetchSnippet fetches a verse numbered in the scheme from as plain text. to
+// is the numbering of the version.
+func (h *QueryHandler) fetchSnippet(p bible.Provider, ref, providerVersion, version string, from, to bible.Versification) (string, error) {
+	book, chapter, verse, err := util.ParseVerseReference(ref)
+	if err != nil {
+		return "", err
+	}
+	if err := h.VersionManager.CheckBook(version, book, chapter); err != nil {
+		return "", err
+	}
+	chapter, verse, err = bible.MapReference(book, chapter, verse, from, to)
+	if err != nil {
+		return "", err
+	}
+	passage, err := bible.GetPassage(p, book, chapter, verse, providerVersion, bible.VerseOptions{VerseNumbers: true})
+	if err != nil {
+		return "", err
+	}
+	split, err := bible.Verses(passage.Text, passageReference(book, chapter, verse))
+	if err != nil {
+		return "", err
+	}
+	texts := make([]string, len(split))
+	for i, v := range split {
+		texts[i] = v.Text
+	}
+	return strings.Join(texts, " "), nil
+}
@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"bible-api-service/internal/bible"
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// mockTopicProvider is a provider with a topical index.
+type mockTopicProvider struct {
+	MockProvider
+	lookupTopicFunc func(topic string) (bible.Topic, error)
+}
+
+func (m *mockTopicProvider) LookupTopic(topic string) (bible.Topic, error) {
+	return m.lookupTopicFunc(topic)
+}
+
+func TestHandleTopicsQuery(t *testing.T) {
+	gateway := &mockTopicProvider{
+		MockProvider: MockProvider{
+			getVerseFunc: func(book, chapter, verse, version string) (string, error) {
+				if book == "Luke" {
+					return "", fmt.Errorf("verses %w", bible.ErrNotFound)
+				}
+				return fmt.Sprintf(`<sup>%s </sup>For if you forgive others their trespasses`, verse), nil
+			},
+		},
+		lookupTopicFunc: func(topic string) (bible.Topic, error) {
+			return bible.Topic{Topic: topic, Source: bible.TopicSourceIndex, Verses: []bible.TopicVerse{
+				{Reference: "Matthew 6:14", Note: "Of injuries", Rank: 1},
+				{Reference: "Luke 6:37", Rank: 2},
+			}}, nil
+		},
+	}
+	pm := bible.NewProviderManager(gateway)
+	pm.RegisterProvider(bible.DefaultProviderName, gateway)
+	handler := &QueryHandler{ProviderManager: pm, VersionManager: createTestVersionManager(t)}
+
+	reqBody := `{"query": {"topics": ["forgiveness"]}, "context": {"user": {"version": "ESV"}}}`
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("POST", "/query", bytes.NewBufferString(reqBody)))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	// Verses that cannot be fetched keep their reference
+	require.JSONEq(t, `{"topics": [{
+		"topic": "forgiveness",
+		"source": "index",
+		"verses": [
+			{"reference": "Matthew 6:14", "note": "Of injuries", "text": "For if you forgive others their trespasses", "rank": 1},
+			{"reference": "Luke 6:37", "rank": 2}
+		]
+	}]}`, rr.Body.String())
+}
+
+func TestHandleTopicsQuery_SearchFallback(t *testing.T) {
+	gateway := &MockProvider{}
+	var searched string
+	hub := &MockProvider{
+		searchWordsFunc: func(query, version string) ([]bible.SearchResult, error) {
+			searched = query + " in " + version
+			return []bible.SearchResult{{Verse: "Eph 4:32", Text: "Be <b>kind</b> to one another"}}, nil
+		},
+	}
+	pm := bible.NewProviderManager(gateway)
+	pm.RegisterProvider(bible.DefaultProviderName, gateway)
+	pm.RegisterProvider("biblehub", hub)
+	handler := &QueryHandler{ProviderManager: pm, VersionManager: createCompareVersionManager(t)}
+
+	reqBody := `{"query": {"topics": ["kindness"]}, "context": {"user": {"version": "NABRE"}}}`
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("POST", "/query", bytes.NewBufferString(reqBody)))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	require.Equal(t, "kindness in nabre", searched)
+	require.JSONEq(t, `{"topics": [{
+		"topic": "kindness",
+		"source": "search",
+		"verses": [{"reference": "Ephesians 4:32", "text": "Be kind to one another", "rank": 1}]
+	}]}`, rr.Body.String())
+}
+
+func TestHandleTopicsQuery_EquivalentVersions(t *testing.T) {
+	var mu sync.Mutex
+	var searched []string
+	gateway := &MockProvider{
+		searchWordsFunc: func(query, version string) ([]bible.SearchResult, error) {
+			mu.Lock()
+			searched = append(searched, query+" in "+version)
+			mu.Unlock()
+			return []bible.SearchResult{{Verse: "Eph 4:32", Text: "be ye kind"}, {Verse: "Tobit 4:7", Text: "Give alms"}}, nil
+		},
+	}
+	com := verseOnlyProvider{getVerseFunc: func(book, chapter, verse, version string) (string, error) {
+		return fmt.Sprintf("<sup>%s </sup>%s %s:%s in the NIVUK", verse, book, chapter, verse), nil
+	}}
+	pm := bible.NewProviderManager(gateway)
+	pm.RegisterProvider(bible.DefaultProviderName, gateway)
+	pm.RegisterProvider("biblecom", com)
+	handler := &QueryHandler{ProviderManager: pm, VersionManager: createFederatedVersionManager(t, pm)}
+
+	reqBody := `{"query": {"topics": ["kindness"]}, "context": {"user": {"version": "NIVUK"}}}`
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("POST", "/query", bytes.NewBufferString(reqBody)))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	// Bible.com cannot search, so the topic is searched for in equivalent
+	// versions and quoted in the NIVUK. Tobit is not in the NIVUK.
+	sort.Strings(searched)
+	require.Equal(t, []string{"kindness in ESV", "kindness in KJV"}, searched)
+	require.JSONEq(t, `{"topics": [{
+		"topic": "kindness",
+		"source": "search",
+		"verses": [{"reference": "Ephesians 4:32", "text": "Ephesians 4:32 in the NIVUK", "rank": 1}]
+	}]}`, rr.Body.String())
+}
+
+func TestHandleTopicsQuery_Errors(t *testing.T) {
+	gateway := &mockTopicProvider{lookupTopicFunc: func(topic string) (bible.Topic, error) {
+		return bible.Topic{}, fmt.Errorf("%w: topical index", bible.ErrUpstreamUnavailable)
+	}}
+	pm := bible.NewProviderManager(gateway)
+	pm.RegisterProvider(bible.DefaultProviderName, gateway)
+	handler := &QueryHandler{ProviderManager: pm, VersionManager: createTestVersionManager(t)}
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{"too many topics", `{"query": {"topics": ["a", "b", "c", "d", "e", "f"]}}`, http.StatusBadRequest},
+		{"options", `{"query": {"topics": ["grace"]}, "options": {"format": "plain"}}`, http.StatusBadRequest},
+		{"topics and words", `{"query": {"topics": ["grace"], "words": ["grace"]}}`, http.StatusBadRequest},
+		{"upstream unavailable", `{"query": {"topics": ["grace"]}}`, http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, httptest.NewRequest("POST", "/query", bytes.NewBufferString(tt.body)))
+			require.Equal(t, tt.status, rr.Code, rr.Body.String())
+		})
+	}
+}
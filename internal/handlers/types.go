@@ -6,18 +6,61 @@ type QueryRequest struct {
 		Verses []string `json:"verses,omitempty"`
 		Words  []string `json:"words,omitempty"`
 		Prompt string   `json:"prompt,omitempty"`
+		// Compare fetches a passage in several versions, aligned verse by verse.
+		Compare *CompareQuery `json:"compare,omitempty"`
+		// Topics looks topics up in a topical index, e.g. "forgiveness".
+		Topics []string `json:"topics,omitempty"`
+		// WordStudy fetches the original text of passages and lexicon entries.
+		WordStudy *WordStudyQuery `json:"word_study,omitempty"`
 	} `json:"query"`
 	Context struct {
 		History []string `json:"history,omitempty"`
 		Schema  string   `json:"schema,omitempty"`
 		Verses  []string `json:"verses,omitempty"`
 		Words   []string `json:"words,omitempty"`
-		User    struct {
+		// Interlinear and Strongs add the original text of passages and
+		// lexicon entries to the prompt, as a word_study query returns them.
+		Interlinear []string `json:"interlinear,omitempty"`
+		Strongs     []string `json:"strongs,omitempty"`
+		// Topics adds the verses listed for topics to the prompt.
+		Topics []string `json:"topics,omitempty"`
+		User   struct {
 			Version    string `json:"version"`
 			AIProvider string `json:"ai_provider,omitempty"`
 		} `json:"user"`
 	} `json:"context,omitempty"`
 	Options struct {
 		Stream bool `json:"stream,omitempty"`
+		// Include lists the notes returned with verse queries: "footnotes" and "crossrefs".
+		Include []string `json:"include,omitempty"`
+		// Format renders verse queries as "html", "markdown", "plain" or "usfm"
+		// text, or as "json-verses", an array of numbered verses; every
+		// provider numbers its verses once a format is set. Without one,
+		// verses are the provider's HTML as is, unnumbered for Bible.com,
+		// BibleHub and BibleNow. Prompt queries take "html" (their default),
+		// "markdown" or "plain" as the format the LLM answers in.
+		Format string `json:"format,omitempty"`
+		// Versification is the verse numbering of the references in the query:
+		// "english" (the default), "hebrew", "vulgate" or "septuagint". They are
+		// translated to the numbering of the version before they are fetched.
+		Versification string `json:"versification,omitempty"`
 	} `json:"options,omitempty"`
 }
+
+// CompareQuery is a passage to fetch in several versions side by side.
+type CompareQuery struct {
+	// Reference is numbered as options.versification says, by default as in
+	// English versions, e.g. "Malachi 4:1-2". So are the rows returned.
+	Reference string   `json:"reference"`
+	Versions  []string `json:"versions"`
+}
+
+// WordStudyQuery asks for original-language data, which does not depend on
+// the version.
+type WordStudyQuery struct {
+	// Interlinear lists passages to return word by word, numbered as
+	// options.versification says.
+	Interlinear []string `json:"interlinear,omitempty"`
+	// Strongs lists Strong's numbers to return lexicon entries for, e.g. "G25".
+	Strongs []string `json:"strongs,omitempty"`
+}
@@ -1,14 +1,19 @@
 package handlers
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
-	"log"
+	"fmt"
+	"log/slog"
 	"net/http"
 	"sort"
 	"strconv"
 	"strings"
 
 	"bible-api-service/internal/bible"
+	"bible-api-service/internal/circuit"
+	"bible-api-service/internal/util"
 )
 
 // VersionsHandler handles requests for Bible versions.
@@ -31,7 +36,11 @@ func (h *VersionsHandler) ListVersions(w http.ResponseWriter, r *http.Request) {
 	versions := h.manager.GetAll()
 
 	// Filter
-	filtered := h.filterVersions(versions, r)
+	filtered, err := h.filterVersions(versions, r)
+	if err != nil {
+		util.JSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 
 	// Sort
 	h.sortVersions(filtered, r)
@@ -46,11 +55,7 @@ func (h *VersionsHandler) ListVersions(w http.ResponseWriter, r *http.Request) {
 		"limit": limit,
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(response); err != nil {
-		log.Printf("Failed to encode response: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
-	}
+	writeCacheableJSON(w, r, response)
 }
 
 // ServeHTTP implements http.Handler.
@@ -58,28 +63,263 @@ func (h *VersionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	h.ListVersions(w, r)
 }
 
-func (h *VersionsHandler) filterVersions(versions []bible.Version, r *http.Request) []bible.Version {
-	nameFilter := strings.ToLower(r.URL.Query().Get("name"))
-	languageFilter := strings.ToLower(r.URL.Query().Get("language"))
+// languageCount is an entry of the languages facet.
+type languageCount struct {
+	Language     string `json:"language"`
+	LanguageCode string `json:"language_code,omitempty"`
+	Count        int    `json:"count"`
+}
+
+// ListLanguages handles GET requests for the languages facet: each language
+// with the number of versions in it. The list filters other than language apply.
+func (h *VersionsHandler) ListLanguages(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	query.Del("language")
+	query.Del("language_code")
+	r.URL.RawQuery = query.Encode()
+
+	filtered, err := h.filterVersions(h.manager.GetAll(), r)
+	if err != nil {
+		util.JSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Versions are grouped by language code, and by name when the code is
+	// unknown. The most common name is reported for each code.
+	counts := make(map[string]*languageCount)
+	names := make(map[string]map[string]int)
+	for _, v := range filtered {
+		key := v.LanguageCode
+		if key == "" {
+			key = "name:" + strings.ToLower(v.Language)
+		}
+		c, ok := counts[key]
+		if !ok {
+			c = &languageCount{LanguageCode: v.LanguageCode}
+			counts[key] = c
+			names[key] = make(map[string]int)
+		}
+		c.Count++
+		names[key][v.Language]++
+		if n := names[key][v.Language]; n > names[key][c.Language] || (n == names[key][c.Language] && v.Language < c.Language) {
+			c.Language = v.Language
+		}
+	}
+
+	languages := make([]languageCount, 0, len(counts))
+	for _, c := range counts {
+		languages = append(languages, *c)
+	}
+	sort.Slice(languages, func(i, j int) bool {
+		if languages[i].Count != languages[j].Count {
+			return languages[i].Count > languages[j].Count
+		}
+		return languages[i].Language < languages[j].Language
+	})
+
+	writeCacheableJSON(w, r, map[string]interface{}{
+		"data":  languages,
+		"total": len(languages),
+	})
+}
+
+// versionProvider is a provider mapping of a version with its live health.
+type versionProvider struct {
+	Name     string   `json:"name"`
+	Code     string   `json:"code"`
+	State    string   `json:"state"`
+	Features []string `json:"features"`
+}
 
-	if nameFilter == "" && languageFilter == "" {
-		// Return a copy to avoid modifying the original slice during sort
-		dst := make([]bible.Version, len(versions))
-		copy(dst, versions)
-		return dst
+// versionDetail is the full description of a single version.
+type versionDetail struct {
+	bible.Version
+	ProviderDetails []versionProvider `json:"provider_details"`
+	// Features are the capabilities offered by at least one provider whose circuit is not open.
+	Features []string `json:"features"`
+}
+
+// GetVersion handles GET requests for a single version by code, taken from
+// the {code} path value.
+func (h *VersionsHandler) GetVersion(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	code := r.PathValue("code")
+	v, ok := h.manager.Get(code)
+	if !ok {
+		util.JSONErrorWithCode(w, http.StatusNotFound, util.ErrorCodeNotFound, fmt.Sprintf("Version not found: %s", code))
+		return
+	}
+
+	detail := versionDetail{Version: v, ProviderDetails: []versionProvider{}, Features: []string{}}
+	names := make([]string, 0, len(v.Providers))
+	for name, providerCode := range v.Providers {
+		if providerCode != "" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	working := make(map[string]bool)
+	for _, name := range names {
+		state := h.manager.ProviderState(name)
+		features := h.manager.ProviderCapabilities(name)
+		if features == nil {
+			features = []string{}
+		}
+		detail.ProviderDetails = append(detail.ProviderDetails, versionProvider{
+			Name:     name,
+			Code:     v.Providers[name],
+			State:    state.String(),
+			Features: features,
+		})
+		if state != circuit.Open {
+			for _, f := range features {
+				working[f] = true
+			}
+		}
+	}
+	for _, f := range bible.AllCapabilities() {
+		if working[f] {
+			detail.Features = append(detail.Features, f)
+		}
+	}
+
+	writeCacheableJSON(w, r, detail)
+}
+
+// ListBooks handles GET requests for the books of the version with the
+// {code} path value, in order, with their chapter counts. Chapters are
+// numbered as the version numbers them.
+func (h *VersionsHandler) ListBooks(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	code := r.PathValue("code")
+	v, ok := h.manager.Get(code)
+	if !ok {
+		util.JSONErrorWithCode(w, http.StatusNotFound, util.ErrorCodeNotFound, fmt.Sprintf("Version not found: %s", code))
+		return
 	}
 
-	var filtered []bible.Version
+	books := bible.CanonBooks(v.Canon, h.manager.Versification(code))
+	for i := range books {
+		// Verse counts are only listed for a single book
+		books[i].Verses = nil
+	}
+	writeCacheableJSON(w, r, map[string]interface{}{
+		"data":  books,
+		"total": len(books),
+	})
+}
+
+// GetBook handles GET requests for a book of a version, taken from the {code}
+// and {book} path values, with the number of verses of each chapter. The book
+// may be given by name, abbreviation or USFM identifier.
+func (h *VersionsHandler) GetBook(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	code := r.PathValue("code")
+	v, ok := h.manager.Get(code)
+	if !ok {
+		util.JSONErrorWithCode(w, http.StatusNotFound, util.ErrorCodeNotFound, fmt.Sprintf("Version not found: %s", code))
+		return
+	}
+	book, ok := bible.LookupBook(r.PathValue("book"))
+	if !ok || !v.HasBook(book) {
+		util.JSONErrorWithCode(w, http.StatusNotFound, util.ErrorCodeNotFound, fmt.Sprintf("Book not found in %s: %s", v.Code, r.PathValue("book")))
+		return
+	}
+
+	writeCacheableJSON(w, r, book.Structure(h.manager.Versification(code)))
+}
+
+// filterVersions applies the name, language, language_code, provider, feature
+// and testament filters. provider matches versions mapped by any of the listed
+// providers; feature requires all of the listed features.
+func (h *VersionsHandler) filterVersions(versions []bible.Version, r *http.Request) ([]bible.Version, error) {
+	query := r.URL.Query()
+	nameFilter := strings.ToLower(query.Get("name"))
+	languageFilter := strings.ToLower(query.Get("language"))
+	languageCodeFilter := strings.ToLower(query.Get("language_code"))
+	providerFilter := splitList(query.Get("provider"))
+	featureFilter := splitList(query.Get("feature"))
+	testamentFilter := strings.ToLower(query.Get("testament"))
+
+	for _, f := range featureFilter {
+		if !bible.IsCapability(f) {
+			return nil, fmt.Errorf("unknown feature: %s", f)
+		}
+	}
+	if testamentFilter != "" && testamentFilter != bible.CoverageOT && testamentFilter != bible.CoverageNT {
+		return nil, fmt.Errorf("unknown testament: %s", testamentFilter)
+	}
+
+	filtered := []bible.Version{}
 	for _, v := range versions {
 		if nameFilter != "" && !strings.Contains(strings.ToLower(v.Name), nameFilter) {
 			continue
 		}
-		if languageFilter != "" && !strings.Contains(strings.ToLower(v.Language), languageFilter) {
+		if languageFilter != "" && !strings.Contains(strings.ToLower(v.Language), languageFilter) && v.LanguageCode != languageFilter {
+			continue
+		}
+		if languageCodeFilter != "" && v.LanguageCode != languageCodeFilter {
+			continue
+		}
+		if len(providerFilter) > 0 && !mappedByAny(v, providerFilter) {
+			continue
+		}
+		if !h.supportsAll(v, featureFilter) {
 			continue
 		}
+		if testamentFilter != "" && !v.HasTestament(testamentFilter) {
+			continue
+		}
+		// Copied so that sorting does not reorder the manager's slice
 		filtered = append(filtered, v)
 	}
-	return filtered
+	return filtered, nil
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(strings.ToLower(value), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func mappedByAny(v bible.Version, providers []string) bool {
+	for _, p := range providers {
+		if v.Providers[p] != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *VersionsHandler) supportsAll(v bible.Version, features []string) bool {
+	for _, f := range features {
+		if !h.manager.Supports(v, f) {
+			return false
+		}
+	}
+	return true
 }
 
 func (h *VersionsHandler) sortVersions(versions []bible.Version, r *http.Request) {
@@ -128,3 +368,39 @@ func (h *VersionsHandler) paginateVersions(versions []bible.Version, r *http.Req
 
 	return versions[start:end], total, page, limit
 }
+
+// writeCacheableJSON writes body as JSON with an ETag computed from its
+// content, and answers 304 Not Modified when the client already has it.
+func writeCacheableJSON(w http.ResponseWriter, r *http.Request, body interface{}) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to encode response", "error", err)
+		util.JSONError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	sum := sha256.Sum256(data)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	w.Header().Set("ETag", etag)
+	// Clients may cache but must revalidate, since versions can be reloaded
+	w.Header().Set("Cache-Control", "private, no-cache")
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(append(data, '\n'))
+}
+
+// etagMatches reports whether an If-None-Match header matches etag, using the
+// weak comparison that RFC 9110 requires for If-None-Match.
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"bible-api-service/internal/bible"
+	"bible-api-service/internal/circuit"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -55,7 +56,12 @@ func TestVersionsHandler_ListVersions(t *testing.T) {
   language: English
 - name: Reina-Valera 1960
   code: RVR1960
+  abbreviation: RVR1960
+  publisher: Sociedades Bíblicas Unidas
   language: Spanish
+  language_code: spa
+  direction: ltr
+  coverage: full
 - name: La Bible du Semeur
   code: BDS
   language: French
@@ -124,6 +130,26 @@ func TestVersionsHandler_ListVersions(t *testing.T) {
 		assert.Equal(t, "RVR1960", data[0].(map[string]interface{})["code"])
 	})
 
+	t.Run("FilterByLanguageCode", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/versions?language=spa", nil)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp map[string]interface{}
+		err := json.Unmarshal(w.Body.Bytes(), &resp)
+		require.NoError(t, err)
+
+		assert.Equal(t, float64(1), resp["total"])
+		version := resp["data"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, "RVR1960", version["code"])
+		assert.Equal(t, "RVR1960", version["abbreviation"])
+		assert.Equal(t, "Sociedades Bíblicas Unidas", version["publisher"])
+		assert.Equal(t, "spa", version["language_code"])
+		assert.Equal(t, "ltr", version["direction"])
+		assert.Equal(t, "full", version["coverage"])
+	})
+
 	t.Run("SortByName", func(t *testing.T) {
 		req := httptest.NewRequest(http.MethodGet, "/versions?sort=name", nil)
 		w := httptest.NewRecorder()
@@ -213,3 +239,259 @@ func TestVersionsHandler_ListVersions(t *testing.T) {
 		assert.Equal(t, float64(20), resp["limit"])
 	})
 }
+
+type stubProviderHealth map[string]circuit.State
+
+func (s stubProviderHealth) ProviderState(name string) circuit.State {
+	return s[name]
+}
+
+type stubProviderCapabilities map[string][]string
+
+func (s stubProviderCapabilities) Capabilities(name string) []string {
+	return s[name]
+}
+
+func newCapabilitiesHandler(t *testing.T) *VersionsHandler {
+	content := `
+- name: English Standard Version
+  code: ESV
+  language: English
+  language_code: eng
+  providers:
+    biblegateway: ESV
+    biblenow: english-standard-version
+- name: Nuevo Testamento Viviente
+  code: NTV
+  language: Español
+  language_code: spa
+  coverage: nt
+  providers:
+    biblenow: es/biblia/nuevo-testamento-viviente
+- name: Reina-Valera 1960
+  code: RVR1960
+  language: Spanish
+  language_code: spa
+  coverage: full
+  providers:
+    biblegateway: RVR1960
+- name: Abanyom LP New Testament Portions
+  code: ABM
+  language: Unknown
+  coverage: portions
+  providers:
+    biblecom: "3421"
+`
+	configPath := filepath.Join(t.TempDir(), "versions.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0644))
+	vm, err := bible.NewVersionManager(configPath)
+	require.NoError(t, err)
+	vm.SetCapabilityChecker(stubProviderCapabilities{
+		"biblegateway": {bible.CapabilityVerses, bible.CapabilitySearch, bible.CapabilityFootnotes},
+		"biblenow":     {bible.CapabilityVerses, bible.CapabilityChapterStructure},
+		"biblecom":     {bible.CapabilityVerses, bible.CapabilityAudio},
+	})
+	return NewVersionsHandler(vm)
+}
+
+func listCodes(t *testing.T, h *VersionsHandler, query string) []string {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/bible-versions?"+query, nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp struct {
+		Data []bible.Version `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	codes := []string{}
+	for _, v := range resp.Data {
+		codes = append(codes, v.Code)
+	}
+	return codes
+}
+
+func TestVersionsHandler_CapabilityFilters(t *testing.T) {
+	h := newCapabilitiesHandler(t)
+
+	assert.Equal(t, []string{"ESV", "NTV"}, listCodes(t, h, "provider=biblenow"))
+	assert.Equal(t, []string{"ABM", "ESV", "RVR1960"}, listCodes(t, h, "provider=biblecom,%20BibleGateway"))
+	assert.Equal(t, []string{"NTV", "RVR1960"}, listCodes(t, h, "language_code=SPA"))
+	assert.Equal(t, []string{}, listCodes(t, h, "language_code=sp"))
+	assert.Equal(t, []string{"ESV", "RVR1960"}, listCodes(t, h, "feature=search"))
+	assert.Equal(t, []string{"ESV", "RVR1960"}, listCodes(t, h, "testament=ot"))
+	assert.Equal(t, []string{"ESV", "NTV", "RVR1960"}, listCodes(t, h, "testament=nt"))
+	assert.Equal(t, []string{"RVR1960"}, listCodes(t, h, "feature=search&language_code=spa"))
+	assert.Equal(t, []string{"ABM"}, listCodes(t, h, "feature=audio"))
+	assert.Equal(t, []string{"ESV"}, listCodes(t, h, "feature=footnotes,chapter_structure"))
+
+	for _, query := range []string{"feature=video", "testament=apocrypha"} {
+		req := httptest.NewRequest(http.MethodGet, "/bible-versions?"+query, nil)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
+
+func TestVersionsHandler_ListLanguages(t *testing.T) {
+	h := newCapabilitiesHandler(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/bible-versions/languages?language=english", nil)
+	w := httptest.NewRecorder()
+	h.ListLanguages(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp struct {
+		Data  []languageCount `json:"data"`
+		Total int             `json:"total"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	// The language filter does not apply to the facet
+	assert.Equal(t, 3, resp.Total)
+	assert.Equal(t, languageCount{Language: "Español", LanguageCode: "spa", Count: 2}, resp.Data[0])
+	assert.Equal(t, languageCount{Language: "English", LanguageCode: "eng", Count: 1}, resp.Data[1])
+	assert.Equal(t, languageCount{Language: "Unknown", Count: 1}, resp.Data[2])
+
+	req = httptest.NewRequest(http.MethodGet, "/bible-versions/languages?feature=search", nil)
+	w = httptest.NewRecorder()
+	h.ListLanguages(w, req)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 2, resp.Total)
+}
+
+func TestVersionsHandler_GetVersion(t *testing.T) {
+	h := newCapabilitiesHandler(t)
+	h.manager.SetHealthChecker(stubProviderHealth{"biblegateway": circuit.Open})
+
+	t.Run("Found", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/bible-versions/esv", nil)
+		req.SetPathValue("code", "esv")
+		w := httptest.NewRecorder()
+		h.GetVersion(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var detail versionDetail
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &detail))
+		assert.Equal(t, "ESV", detail.Code)
+		assert.Equal(t, map[string]string{"biblegateway": "ESV", "biblenow": "english-standard-version"}, detail.Providers)
+		assert.Equal(t, []versionProvider{
+			{Name: "biblegateway", Code: "ESV", State: "open", Features: []string{bible.CapabilityVerses, bible.CapabilitySearch, bible.CapabilityFootnotes}},
+			{Name: "biblenow", Code: "english-standard-version", State: "closed", Features: []string{bible.CapabilityVerses, bible.CapabilityChapterStructure}},
+		}, detail.ProviderDetails)
+		// Search and footnotes are only offered by the provider whose circuit is open
+		assert.Equal(t, []string{bible.CapabilityVerses, bible.CapabilityChapterStructure}, detail.Features)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/bible-versions/XYZ", nil)
+		req.SetPathValue("code", "XYZ")
+		w := httptest.NewRecorder()
+		h.GetVersion(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestVersionsHandler_ETag(t *testing.T) {
+	h := newCapabilitiesHandler(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/bible-versions?language_code=spa", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	etag := w.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	t.Run("NotModified", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/bible-versions?language_code=spa", nil)
+		req.Header.Set("If-None-Match", `"other", W/`+etag)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotModified, w.Code)
+		assert.Empty(t, w.Body.Bytes())
+		assert.Equal(t, etag, w.Header().Get("ETag"))
+	})
+
+	t.Run("DifferentQuery", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/bible-versions?language_code=eng", nil)
+		req.Header.Set("If-None-Match", etag)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotEqual(t, etag, w.Header().Get("ETag"))
+	})
+}
+
+func TestVersionsHandler_ListBooks(t *testing.T) {
+	h := NewVersionsHandler(createCompareVersionManager(t))
+
+	t.Run("Protestant", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/bible-versions/esv/books", nil)
+		req.SetPathValue("code", "esv")
+		w := httptest.NewRecorder()
+		h.ListBooks(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Data  []bible.BookStructure `json:"data"`
+			Total int                   `json:"total"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, 66, response.Total)
+		assert.Equal(t, bible.BookStructure{Book: bible.Book{Name: "Genesis", USFM: "GEN", Canon: bible.CanonProtestant}, Chapters: 50}, response.Data[0])
+		assert.Equal(t, "Revelation", response.Data[65].Name)
+	})
+
+	t.Run("Catholic with Hebrew numbering", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/bible-versions/NABRE/books", nil)
+		req.SetPathValue("code", "NABRE")
+		w := httptest.NewRecorder()
+		h.ListBooks(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Data []bible.BookStructure `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		chapters := make(map[string]int)
+		for _, b := range response.Data {
+			chapters[b.Name] = b.Chapters
+		}
+		assert.Equal(t, 14, chapters["Tobit"])
+		assert.Equal(t, 3, chapters["Malachi"])
+		assert.NotContains(t, chapters, "3 Maccabees")
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/bible-versions/XYZ/books", nil)
+		req.SetPathValue("code", "XYZ")
+		w := httptest.NewRecorder()
+		h.ListBooks(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestVersionsHandler_GetBook(t *testing.T) {
+	h := NewVersionsHandler(createCompareVersionManager(t))
+
+	get := func(code, book string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/bible-versions/"+code+"/books/"+book, nil)
+		req.SetPathValue("code", code)
+		req.SetPathValue("book", book)
+		w := httptest.NewRecorder()
+		h.GetBook(w, req)
+		return w
+	}
+
+	w := get("NABRE", "mal")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"name": "Malachi", "usfm": "MAL", "canon": "protestant", "chapters": 3, "verses": [14, 17, 24]}`, w.Body.String())
+
+	w = get("ESV", "Malachi")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"name": "Malachi", "usfm": "MAL", "canon": "protestant", "chapters": 4, "verses": [14, 17, 18, 6]}`, w.Body.String())
+
+	assert.Equal(t, http.StatusNotFound, get("ESV", "Tobit").Code)
+	assert.Equal(t, http.StatusNotFound, get("ESV", "Hezekiah").Code)
+	assert.Equal(t, http.StatusNotFound, get("XYZ", "John").Code)
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"bible-api-service/internal/bible"
+	"bible-api-service/internal/util"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+)
+
+// maxWordStudyItems limits the passages and lexicon entries a word study fetches at once.
+const maxWordStudyItems = 20
+
+func (h *QueryHandler) handleWordStudyQuery(w http.ResponseWriter, r *http.Request, request QueryRequest) {
+	study := request.Query.WordStudy
+	if request.Options.Stream || request.Options.Format != "" || len(request.Options.Include) > 0 {
+		util.JSONError(w, http.StatusBadRequest, "Only the versification option is supported for word study queries")
+		return
+	}
+	refVersification, err := bible.ParseVersification(request.Options.Versification)
+	if err != nil {
+		util.JSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	items := len(study.Interlinear) + len(study.Strongs)
+	if items == 0 || items > maxWordStudyItems {
+		util.JSONError(w, http.StatusBadRequest, fmt.Sprintf("Word study needs between 1 and %d passages and Strong's numbers", maxWordStudyItems))
+		return
+	}
+	strongs := make([]string, len(study.Strongs))
+	for i, number := range study.Strongs {
+		if strongs[i], err = bible.ParseStrongs(number); err != nil {
+			util.JSONErrorWithCode(w, http.StatusBadRequest, util.ErrorCodeInvalidReference, err.Error())
+			return
+		}
+	}
+
+	interlinear := []bible.InterlinearVerse{}
+	for _, verseRef := range study.Interlinear {
+		book, chapter, verseNum, err := util.ParseVerseReference(verseRef)
+		if err != nil {
+			util.JSONErrorWithCode(w, http.StatusBadRequest, util.ErrorCodeInvalidReference, err.Error())
+			return
+		}
+		// Interlinear text is numbered as in English versions
+		chapter, verseNum, err = bible.MapReference(book, chapter, verseNum, refVersification, bible.VersificationEnglish)
+		if err != nil {
+			util.JSONErrorWithCode(w, http.StatusBadRequest, util.ErrorCodeInvalidReference, err.Error())
+			return
+		}
+		p, err := h.ProviderManager.ProviderWith(bible.CapabilityInterlinear)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "No interlinear provider", "error", err)
+			writeProviderError(w, err, "Verse not found", "Failed to get interlinear text")
+			return
+		}
+		verses, err := bible.GetInterlinear(p, book, chapter, verseNum)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Provider GetInterlinear failed",
+				"book", book, "chapter", chapter, "verse", verseNum, "error", err)
+			writeProviderError(w, err, "Verse not found", "Failed to get interlinear text")
+			return
+		}
+		interlinear = append(interlinear, verses...)
+	}
+
+	lexicon := []bible.LexiconEntry{}
+	for _, number := range strongs {
+		p, err := h.ProviderManager.ProviderWith(bible.CapabilityLexicon)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "No lexicon provider", "error", err)
+			writeProviderError(w, err, "Lexicon entry not found", "Failed to get lexicon entry")
+			return
+		}
+		entry, err := bible.GetLexiconEntry(p, number)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Provider GetLexiconEntry failed", "strongs", number, "error", err)
+			writeProviderError(w, err, "Lexicon entry not found", "Failed to get lexicon entry")
+			return
+		}
+		lexicon = append(lexicon, entry)
+	}
+
+	response := map[string]interface{}{
+		"interlinear": interlinear,
+		"lexicon":     lexicon,
+	}
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"bible-api-service/internal/bible"
+	"bible-api-service/internal/chat"
+	"bible-api-service/internal/util"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// mockWordStudyProvider is a provider that also offers interlinear text and lexicon entries.
+type mockWordStudyProvider struct {
+	MockProvider
+	getInterlinearFunc  func(book, chapter, verse string) ([]bible.InterlinearVerse, error)
+	getLexiconEntryFunc func(strongs string) (bible.LexiconEntry, error)
+}
+
+func (m *mockWordStudyProvider) GetInterlinear(book, chapter, verse string) ([]bible.InterlinearVerse, error) {
+	return m.getInterlinearFunc(book, chapter, verse)
+}
+
+func (m *mockWordStudyProvider) GetLexiconEntry(strongs string) (bible.LexiconEntry, error) {
+	return m.getLexiconEntryFunc(strongs)
+}
+
+func createWordStudyHandler(hub bible.Provider) *QueryHandler {
+	gateway := &MockProvider{}
+	pm := bible.NewProviderManager(gateway)
+	pm.RegisterProvider(bible.DefaultProviderName, gateway)
+	pm.RegisterProvider("biblehub", hub)
+	return &QueryHandler{ProviderManager: pm}
+}
+
+func TestHandleWordStudyQuery(t *testing.T) {
+	var requested []string
+	hub := &mockWordStudyProvider{
+		getInterlinearFunc: func(book, chapter, verse string) ([]bible.InterlinearVerse, error) {
+			requested = append(requested, fmt.Sprintf("%s %s:%s", book, chapter, verse))
+			return []bible.InterlinearVerse{{Reference: "Malachi 4:5", Words: []bible.InterlinearWord{
+				{Original: "הִנֵּ֤ה", Transliteration: "hin·nêh", Strongs: "H2009", Parsing: "Interjection", Gloss: "Behold"},
+			}}}, nil
+		},
+		getLexiconEntryFunc: func(strongs string) (bible.LexiconEntry, error) {
+			requested = append(requested, strongs)
+			return bible.LexiconEntry{Strongs: strongs, Lemma: "ἀγαπάω", Transliteration: "agapaó", Definition: "to love"}, nil
+		},
+	}
+	handler := createWordStudyHandler(bible.NewObservedProvider("biblehub", hub, nil))
+
+	reqBody := `{"query": {"word_study": {"interlinear": ["Malachi 3:23"], "strongs": ["g0025"]}}, "options": {"versification": "hebrew"}}`
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("POST", "/query", bytes.NewBufferString(reqBody)))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	// Interlinear pages are numbered like English versions
+	require.Equal(t, []string{"Malachi 4:5", "G25"}, requested)
+	require.JSONEq(t, `{
+		"interlinear": [{"reference": "Malachi 4:5", "words": [
+			{"original": "הִנֵּ֤ה", "transliteration": "hin·nêh", "strongs": "H2009", "parsing": "Interjection", "gloss": "Behold"}
+		]}],
+		"lexicon": [{"strongs": "G25", "lemma": "ἀγαπάω", "transliteration": "agapaó", "definition": "to love"}]
+	}`, rr.Body.String())
+}
+
+func TestHandleWordStudyQuery_Errors(t *testing.T) {
+	hub := &mockWordStudyProvider{
+		getInterlinearFunc: func(book, chapter, verse string) ([]bible.InterlinearVerse, error) {
+			return nil, fmt.Errorf("interlinear verses %w", bible.ErrNotFound)
+		},
+	}
+	tests := []struct {
+		name     string
+		provider bible.Provider
+		body     string
+		status   int
+	}{
+		{"empty", hub, `{"query": {"word_study": {}}}`, http.StatusBadRequest},
+		{"invalid strongs", hub, `{"query": {"word_study": {"strongs": ["love"]}}}`, http.StatusBadRequest},
+		{"invalid reference", hub, `{"query": {"word_study": {"interlinear": ["John"]}}}`, http.StatusBadRequest},
+		{"format", hub, `{"query": {"word_study": {"strongs": ["G25"]}}, "options": {"format": "plain"}}`, http.StatusBadRequest},
+		{"not found", hub, `{"query": {"word_study": {"interlinear": ["John 30:1"]}}}`, http.StatusNotFound},
+		{"unsupported provider", &MockProvider{}, `{"query": {"word_study": {"strongs": ["G25"]}}}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := createWordStudyHandler(tt.provider)
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, httptest.NewRequest("POST", "/query", bytes.NewBufferString(tt.body)))
+			require.Equal(t, tt.status, rr.Code, rr.Body.String())
+		})
+	}
+}
+
+func TestHandleWordStudyQuery_NotFoundMessage(t *testing.T) {
+	hub := &mockWordStudyProvider{
+		getLexiconEntryFunc: func(strongs string) (bible.LexiconEntry, error) {
+			return bible.LexiconEntry{}, fmt.Errorf("lexicon entry %w", bible.ErrNotFound)
+		},
+	}
+	handler := createWordStudyHandler(hub)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("POST", "/query", bytes.NewBufferString(`{"query": {"word_study": {"strongs": ["G99999"]}}}`)))
+	require.Equal(t, http.StatusNotFound, rr.Code)
+	var resp util.ErrorResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	require.Equal(t, util.ErrorCodeNotFound, resp.Error.ErrorCode)
+	require.Equal(t, "Lexicon entry not found", resp.Error.Message)
+}
+
+func TestHandlePromptQuery_WordStudyContext(t *testing.T) {
+	var got chat.Request
+	handler := &QueryHandler{
+		ChatService: &mockChatService{
+			processFunc: func(ctx context.Context, req chat.Request) (*chat.Result, error) {
+				got = req
+				return &chat.Result{Data: chat.Response{"text": "ok"}}, nil
+			},
+		},
+		VersionManager:  createTestVersionManager(t),
+		ProviderManager: bible.NewProviderManager(nil),
+	}
+
+	reqBody := `{"query": {"prompt": "What does agape mean?"}, "context": {"interlinear": ["John 3:16"], "strongs": ["G26"]}}`
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("POST", "/query", bytes.NewBufferString(reqBody)))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	require.Equal(t, []string{"John 3:16"}, got.Interlinear)
+	require.Equal(t, []string{"G26"}, got.Strongs)
+
+	reqBody = `{"query": {"prompt": "What does agape mean?"}, "context": {"strongs": ["agape"]}}`
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("POST", "/query", bytes.NewBufferString(reqBody)))
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+
+	var response map[string]map[string]interface{}
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+	require.Equal(t, "invalid_reference", response["error"]["error_code"])
+}
@@ -4,24 +4,33 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
+	"log/slog"
 	"reflect"
 	"strings"
+	"sync"
 	"time"
 
+	"bible-api-service/internal/circuit"
+
 	"bible-api-service/internal/llm/deepseek"
 	"bible-api-service/internal/llm/gemini"
 	"bible-api-service/internal/llm/openai"
 	"bible-api-service/internal/llm/openapicustom"
 	"bible-api-service/internal/llm/openrouter"
 	"bible-api-service/internal/llm/provider"
+	"bible-api-service/internal/metrics"
 	"bible-api-service/internal/secrets"
 )
 
 // FallbackClient is a client that tries a list of providers in order until one succeeds.
+// Providers that keep failing are skipped for a cooldown period.
 type FallbackClient struct {
 	clients    []provider.LLMClient
 	clientsMap map[string]provider.LLMClient
+	options    Options
+
+	mu       sync.Mutex
+	breakers map[string]*circuit.Breaker
 }
 
 // parseLLMConfig parses the LLM configuration from environment variable or secret.
@@ -32,10 +41,10 @@ func parseLLMConfig(ctx context.Context, secretsClient secrets.Client) (map[stri
 		// Fall back to LLM_PROVIDERS for backward compatibility
 		providerNames, err := secrets.Get(ctx, secretsClient, "LLM_PROVIDERS")
 		if err != nil {
-			log.Printf("LLM_CONFIG and LLM_PROVIDERS not set, defaulting to {\"deepseek\":\"deepseek-chat\"}: %v", err)
+			slog.InfoContext(ctx, "LLM_CONFIG and LLM_PROVIDERS not set, defaulting to {\"deepseek\":\"deepseek-chat\"}", "error", err)
 			return map[string]string{"deepseek": "deepseek-chat"}, []string{"deepseek"}, nil
 		}
-		log.Printf("WARNING: LLM_PROVIDERS is deprecated, use LLM_CONFIG JSON instead")
+		slog.WarnContext(ctx, "LLM_PROVIDERS is deprecated, use LLM_CONFIG JSON instead")
 		// Convert comma-separated list to map with empty model names
 		providers := strings.Split(providerNames, ",")
 		config := make(map[string]string, len(providers))
@@ -106,7 +115,7 @@ func NewFallbackClient(ctx context.Context, secretsClient secrets.Client) (*Fall
 	}
 
 	clientsMap := make(map[string]provider.LLMClient)
-	log.Printf("LLM provider order: %v", providerOrder)
+	slog.InfoContext(ctx, "LLM provider order", "providers", providerOrder)
 
 	clients := make([]provider.LLMClient, 0, len(providerConfig))
 	var configErrors []string
@@ -128,23 +137,23 @@ func NewFallbackClient(ctx context.Context, secretsClient secrets.Client) (*Fall
 		case "openrouter":
 			client, err = openrouter.NewClient(ctx, secretsClient, modelName)
 		default:
-			log.Printf("WARNING: unsupported provider '%s' in LLM_CONFIG, skipping", providerName)
+			slog.WarnContext(ctx, "Unsupported provider in LLM_CONFIG, skipping", "ai_provider", providerName)
 			continue
 		}
 
 		if err == nil && client != nil {
 			if modelName != "" {
-				log.Printf("Successfully initialized provider '%s' with model '%s'", providerName, modelName)
+				slog.InfoContext(ctx, "Successfully initialized provider", "ai_provider", providerName, "model", modelName)
 			} else {
-				log.Printf("Successfully initialized provider '%s' with default model", providerName)
+				slog.InfoContext(ctx, "Successfully initialized provider with default model", "ai_provider", providerName)
 			}
 			clients = append(clients, client)
 			clientsMap[client.Name()] = client
 		} else if err != nil {
-			log.Printf("Failed to initialize provider '%s': %v", providerName, err)
+			slog.ErrorContext(ctx, "Failed to initialize provider", "ai_provider", providerName, "error", err)
 			configErrors = append(configErrors, fmt.Sprintf("%s: %v", providerName, err))
 		} else {
-			log.Printf("Failed to initialize provider '%s': unknown error", providerName)
+			slog.ErrorContext(ctx, "Failed to initialize provider: unknown error", "ai_provider", providerName)
 			configErrors = append(configErrors, fmt.Sprintf("%s: failed to initialize client (unknown error)", providerName))
 		}
 	}
@@ -153,7 +162,7 @@ func NewFallbackClient(ctx context.Context, secretsClient secrets.Client) (*Fall
 		return nil, fmt.Errorf("no valid LLM clients could be created. Errors: %s", strings.Join(configErrors, "; "))
 	}
 
-	return &FallbackClient{clients: clients, clientsMap: clientsMap}, nil
+	return &FallbackClient{clients: clients, clientsMap: clientsMap, options: loadOptions()}, nil
 }
 
 // NewFallbackClientWithProviders creates a new FallbackClient with the given providers.
@@ -163,79 +172,71 @@ func NewFallbackClientWithProviders(clients []provider.LLMClient) *FallbackClien
 	for _, client := range clients {
 		clientsMap[client.Name()] = client
 	}
-	return &FallbackClient{clients: clients, clientsMap: clientsMap}
+	return &FallbackClient{clients: clients, clientsMap: clientsMap, options: loadOptions()}
 }
 
-// Query tries each client in order until one succeeds.
+// Query tries each healthy client in order until one succeeds.
 func (c *FallbackClient) Query(ctx context.Context, prompt string, schema string) (string, string, error) {
 	var lastErr error
 
-	preferredName, _ := ctx.Value(provider.PreferredProviderKey).(string)
-	triedPreferred := false
-
-	if preferredName != "" {
-		if client, ok := c.clientsMap[preferredName]; ok {
-			ctxWithTimeout, cancel := context.WithTimeout(ctx, 1*time.Minute)
-			result, providerName, err := client.Query(ctxWithTimeout, prompt, schema)
-			cancel()
-			if err == nil {
-				return result, providerName, nil
-			}
-			log.Printf("Preferred provider %s failed: %v", client.Name(), err)
-			lastErr = err
-			triedPreferred = true
-		}
-	}
-
-	for _, client := range c.clients {
-		if triedPreferred && client.Name() == preferredName {
+	for _, client := range c.candidates(ctx) {
+		breaker := c.breakerFor(client.Name())
+		if err := breaker.Allow(); err != nil {
+			slog.DebugContext(ctx, "Skipping LLM provider in cooldown", "ai_provider", client.Name())
+			lastErr = fmt.Errorf("%s: %w", client.Name(), err)
 			continue
 		}
-		providerType := reflect.TypeOf(client).String()
-		log.Printf("Attempting LLM provider: %s", providerType)
-
-		ctxWithTimeout, cancel := context.WithTimeout(ctx, 1*time.Minute)
+		slog.DebugContext(ctx, "Attempting LLM provider", "ai_provider", client.Name(), "type", reflect.TypeOf(client).String())
 
+		ctxWithTimeout, cancel := context.WithTimeout(ctx, c.timeoutFor(client.Name()))
+		start := time.Now()
 		result, providerName, err := client.Query(ctxWithTimeout, prompt, schema)
+		elapsed := time.Since(start)
 		cancel()
+		if ctx.Err() != nil {
+			// The caller gave up, which says nothing about the provider
+			breaker.Release()
+			return "", "", err
+		}
+		breaker.Record(elapsed, err)
+		metrics.Default.Observe(metrics.ComponentLLM, client.Name(), elapsed, err)
 		if err == nil {
 			return result, providerName, nil
 		}
-		log.Printf("Provider %s failed: %v", client.Name(), err)
+		slog.WarnContext(ctx, "Provider failed", "ai_provider", client.Name(), "error", err)
 		lastErr = err
 	}
 
 	return "", "", fmt.Errorf("all LLM providers failed: %w", lastErr)
 }
 
-// Stream tries each client in order until one succeeds.
+// Stream tries each healthy client in order until one succeeds.
 func (c *FallbackClient) Stream(ctx context.Context, prompt string) (<-chan string, string, error) {
 	var lastErr error
 
-	preferredName, _ := ctx.Value(provider.PreferredProviderKey).(string)
-	triedPreferred := false
-
-	if preferredName != "" {
-		if client, ok := c.clientsMap[preferredName]; ok {
-			ch, providerName, err := client.Stream(ctx, prompt)
-			if err == nil {
-				return ch, providerName, nil
-			}
-			log.Printf("Preferred provider %s stream failed: %v", client.Name(), err)
-			lastErr = err
-			triedPreferred = true
-		}
-	}
-
-	for _, client := range c.clients {
-		if triedPreferred && client.Name() == preferredName {
+	for _, client := range c.candidates(ctx) {
+		breaker := c.breakerFor(client.Name())
+		if err := breaker.Allow(); err != nil {
+			slog.DebugContext(ctx, "Skipping LLM provider in cooldown", "ai_provider", client.Name())
+			lastErr = fmt.Errorf("%s: %w", client.Name(), err)
 			continue
 		}
+
+		// Streams outlive this call, so they are not given a timeout here
+		start := time.Now()
 		ch, providerName, err := client.Stream(ctx, prompt)
+		elapsed := time.Since(start)
+		if ctx.Err() != nil {
+			// The caller gave up, which says nothing about the provider
+			breaker.Release()
+			return nil, "", err
+		}
+		breaker.Record(elapsed, err)
+		metrics.Default.Observe(metrics.ComponentLLM, client.Name(), elapsed, err)
 		if err == nil {
 			return ch, providerName, nil
 		}
-		log.Printf("Provider %s stream failed: %v", client.Name(), err)
+		slog.WarnContext(ctx, "Provider stream failed", "ai_provider", client.Name(), "error", err)
 		lastErr = err
 	}
 
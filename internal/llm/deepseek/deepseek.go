@@ -4,7 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"log"
+	"log/slog"
 
 	"bible-api-service/internal/llm/provider"
 	"bible-api-service/internal/secrets"
@@ -100,7 +100,7 @@ func (c *DeepseekClient) Stream(ctx context.Context, prompt string) (<-chan stri
 				return nil
 			}),
 		); err != nil {
-			log.Printf("deepseek: stream generation failed: %v", err)
+			slog.ErrorContext(ctx, "Stream generation failed", "ai_provider", "deepseek", "error", err)
 		}
 	}()
 
@@ -4,7 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"log"
+	"log/slog"
 
 	"bible-api-service/internal/llm/provider"
 	"bible-api-service/internal/secrets"
@@ -98,7 +98,7 @@ func (c *GeminiClient) Stream(ctx context.Context, prompt string) (<-chan string
 				return nil
 			}),
 		); err != nil {
-			log.Printf("gemini: stream generation failed: %v", err)
+			slog.ErrorContext(ctx, "Stream generation failed", "ai_provider", "gemini", "error", err)
 		}
 	}()
 
@@ -0,0 +1,183 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"bible-api-service/internal/circuit"
+	"bible-api-service/internal/llm/provider"
+	"bible-api-service/internal/metrics"
+)
+
+const (
+	defaultQueryTimeout = 1 * time.Minute
+	defaultCooldown     = 1 * time.Minute
+)
+
+// Options tunes timeouts and health tracking for a FallbackClient.
+type Options struct {
+	// DefaultTimeout applies to providers without an entry in Timeouts.
+	DefaultTimeout time.Duration
+	// Timeouts holds per-provider query timeouts keyed by provider name.
+	Timeouts map[string]time.Duration
+	// Cooldown is how long a failing provider is skipped before it is probed again.
+	Cooldown time.Duration
+	// AdaptiveOrder tries faster providers first based on their observed latency.
+	AdaptiveOrder bool
+}
+
+// loadOptions reads the FallbackClient options from the environment:
+// LLM_TIMEOUT (e.g. "45s"), LLM_TIMEOUTS (JSON, e.g. {"openai":"30s"}),
+// LLM_COOLDOWN (e.g. "2m") and LLM_ADAPTIVE_ORDER ("true").
+func loadOptions() Options {
+	opts := Options{
+		DefaultTimeout: parseDurationEnv("LLM_TIMEOUT", defaultQueryTimeout),
+		Cooldown:       parseDurationEnv("LLM_COOLDOWN", defaultCooldown),
+		AdaptiveOrder:  strings.EqualFold(os.Getenv("LLM_ADAPTIVE_ORDER"), "true"),
+		Timeouts:       make(map[string]time.Duration),
+	}
+
+	if raw := os.Getenv("LLM_TIMEOUTS"); raw != "" {
+		var timeouts map[string]string
+		if err := json.Unmarshal([]byte(raw), &timeouts); err != nil {
+			slog.Warn("Invalid LLM_TIMEOUTS JSON, ignoring", "error", err)
+		}
+		for name, value := range timeouts {
+			d, err := time.ParseDuration(value)
+			if err != nil || d <= 0 {
+				slog.Warn("Invalid timeout in LLM_TIMEOUTS, ignoring", "ai_provider", name, "value", value)
+				continue
+			}
+			opts.Timeouts[name] = d
+		}
+	}
+
+	return opts
+}
+
+func parseDurationEnv(key string, fallback time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		slog.Warn("Invalid duration, using default", "key", key, "value", value, "default", fallback.String())
+		return fallback
+	}
+	return d
+}
+
+// timeoutFor returns the query timeout for the named provider.
+func (c *FallbackClient) timeoutFor(name string) time.Duration {
+	if d, ok := c.options.Timeouts[name]; ok {
+		return d
+	}
+	if c.options.DefaultTimeout > 0 {
+		return c.options.DefaultTimeout
+	}
+	return defaultQueryTimeout
+}
+
+// breakerFor returns the health tracker of the named provider, creating it on first use.
+func (c *FallbackClient) breakerFor(name string) *circuit.Breaker {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.breakers == nil {
+		c.breakers = make(map[string]*circuit.Breaker)
+	}
+	if b, ok := c.breakers[name]; ok {
+		return b
+	}
+
+	cooldown := c.options.Cooldown
+	if cooldown <= 0 {
+		cooldown = defaultCooldown
+	}
+	b := circuit.New(circuit.Config{
+		Window:              5 * time.Minute,
+		MinRequests:         5,
+		ErrorRateThreshold:  0.5,
+		ConsecutiveFailures: 3,
+		OpenTimeout:         cooldown,
+		HalfOpenMaxCalls:    1,
+		OnStateChange: func(from, to circuit.State) {
+			slog.Warn("LLM provider health changed", "ai_provider", name, "from", from.String(), "to", to.String())
+			metrics.Default.SetState(metrics.ComponentLLM, name, to.String())
+		},
+	})
+	c.breakers[name] = b
+	return b
+}
+
+// candidates returns the clients to try, in order: the preferred provider from
+// the context first, then the remaining providers in configured order, or by
+// adaptiveScore when adaptive ordering is enabled.
+func (c *FallbackClient) candidates(ctx context.Context) []provider.LLMClient {
+	preferredName, _ := ctx.Value(provider.PreferredProviderKey).(string)
+
+	var ordered []provider.LLMClient
+	preferred, hasPreferred := c.clientsMap[preferredName]
+	if preferredName != "" && hasPreferred {
+		ordered = append(ordered, preferred)
+	}
+
+	rest := make([]provider.LLMClient, 0, len(c.clients))
+	for _, client := range c.clients {
+		if hasPreferred && client.Name() == preferredName {
+			continue
+		}
+		rest = append(rest, client)
+	}
+
+	if c.options.AdaptiveOrder {
+		scores := make(map[string]float64, len(rest))
+		for _, client := range rest {
+			scores[client.Name()] = adaptiveScore(c.breakerFor(client.Name()).Stats())
+		}
+		sort.SliceStable(rest, func(i, j int) bool {
+			return scores[rest[i].Name()] < scores[rest[j].Name()]
+		})
+	}
+
+	return append(ordered, rest...)
+}
+
+// adaptiveScore ranks a provider for adaptive ordering, lowest first: the
+// latency of its successful calls, scaled up by its error rate so that a
+// provider failing fast is not promoted. Providers without calls in the
+// window keep their last success latency, or score 0 so that they get
+// sampled; providers whose calls all failed go last.
+func adaptiveScore(stats circuit.Stats) float64 {
+	latency := float64(stats.SuccessLatencyEWMAMs)
+	if stats.Requests == 0 {
+		return latency
+	}
+	if stats.ErrorRate >= 1 {
+		return math.Inf(1)
+	}
+	return latency / (1 - stats.ErrorRate)
+}
+
+// Health returns the tracked health of every provider that has been used.
+func (c *FallbackClient) Health() map[string]circuit.Stats {
+	c.mu.Lock()
+	breakers := make(map[string]*circuit.Breaker, len(c.breakers))
+	for name, b := range c.breakers {
+		breakers[name] = b
+	}
+	c.mu.Unlock()
+
+	health := make(map[string]circuit.Stats, len(breakers))
+	for name, b := range breakers {
+		health[name] = b.Stats()
+	}
+	return health
+}
@@ -0,0 +1,233 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"bible-api-service/internal/circuit"
+	"bible-api-service/internal/llm/provider"
+)
+
+func namedClient(name string, queryFunc func(ctx context.Context, prompt string, schema string) (string, string, error)) *mockLLMClient {
+	return &mockLLMClient{
+		nameFunc:  func() string { return name },
+		queryFunc: queryFunc,
+	}
+}
+
+func TestFallbackClient_SkipsProviderInCooldown(t *testing.T) {
+	primaryCalls := 0
+	primary := namedClient("primary", func(ctx context.Context, prompt string, schema string) (string, string, error) {
+		primaryCalls++
+		return "", "", errors.New("primary down")
+	})
+	secondary := namedClient("secondary", func(ctx context.Context, prompt string, schema string) (string, string, error) {
+		return "ok", "secondary", nil
+	})
+
+	client := NewFallbackClientWithProviders([]provider.LLMClient{primary, secondary})
+	client.options = Options{Cooldown: time.Hour}
+
+	for i := 0; i < 5; i++ {
+		result, providerName, err := client.Query(context.Background(), "prompt", "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result != "ok" || providerName != "secondary" {
+			t.Errorf("expected secondary to answer, got %q from %q", result, providerName)
+		}
+	}
+
+	if primaryCalls != 3 {
+		t.Errorf("expected primary to be skipped after 3 failures, got %d calls", primaryCalls)
+	}
+	if state := client.Health()["primary"].State; state != circuit.Open.String() {
+		t.Errorf("expected primary to be %q, got %q", circuit.Open, state)
+	}
+}
+
+func TestFallbackClient_AllProvidersInCooldown(t *testing.T) {
+	failing := namedClient("failing", func(ctx context.Context, prompt string, schema string) (string, string, error) {
+		return "", "", errors.New("down")
+	})
+	client := NewFallbackClientWithProviders([]provider.LLMClient{failing})
+	client.options = Options{Cooldown: time.Hour}
+
+	for i := 0; i < 3; i++ {
+		client.Query(context.Background(), "prompt", "")
+	}
+
+	_, _, err := client.Query(context.Background(), "prompt", "")
+	if !errors.Is(err, circuit.ErrOpen) {
+		t.Errorf("expected circuit.ErrOpen, got %v", err)
+	}
+}
+
+func TestFallbackClient_CanceledQueryIsNotRecorded(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	canceled := namedClient("canceled", func(ctx context.Context, prompt string, schema string) (string, string, error) {
+		cancel()
+		return "", "", ctx.Err()
+	})
+	next := namedClient("next", func(ctx context.Context, prompt string, schema string) (string, string, error) {
+		return "ok", "next", nil
+	})
+	client := NewFallbackClientWithProviders([]provider.LLMClient{canceled, next})
+	client.options = Options{Cooldown: time.Millisecond}
+
+	// A cancelled probe neither closes nor reopens the breaker
+	breaker := client.breakerFor("canceled")
+	for i := 0; i < 3; i++ {
+		breaker.Allow()
+		breaker.Record(time.Millisecond, errors.New("down"))
+	}
+	open := breaker.Stats()
+	time.Sleep(5 * time.Millisecond)
+
+	if _, _, err := client.Query(ctx, "prompt", ""); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	stats := client.Health()["canceled"]
+	if stats.State != circuit.HalfOpen.String() || stats.Requests != open.Requests {
+		t.Errorf("expected the cancelled probe not to be recorded, got %+v", stats)
+	}
+	if _, ok := client.Health()["next"]; ok {
+		t.Error("expected no provider to be tried after the caller cancelled")
+	}
+}
+
+func TestFallbackClient_PerProviderTimeout(t *testing.T) {
+	slow := namedClient("slow", func(ctx context.Context, prompt string, schema string) (string, string, error) {
+		<-ctx.Done()
+		return "", "", ctx.Err()
+	})
+	fast := namedClient("fast", func(ctx context.Context, prompt string, schema string) (string, string, error) {
+		return "ok", "fast", nil
+	})
+
+	client := NewFallbackClientWithProviders([]provider.LLMClient{slow, fast})
+	client.options = Options{
+		DefaultTimeout: time.Hour,
+		Timeouts:       map[string]time.Duration{"slow": 10 * time.Millisecond},
+	}
+
+	start := time.Now()
+	_, providerName, err := client.Query(context.Background(), "prompt", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if providerName != "fast" {
+		t.Errorf("expected fast provider, got %q", providerName)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected slow provider to time out quickly, took %v", elapsed)
+	}
+}
+
+func TestFallbackClient_AdaptiveOrder(t *testing.T) {
+	var order []string
+	record := func(name string) func(ctx context.Context, prompt string, schema string) (string, string, error) {
+		return func(ctx context.Context, prompt string, schema string) (string, string, error) {
+			order = append(order, name)
+			return "", "", errors.New("fail")
+		}
+	}
+	a := namedClient("a", record("a"))
+	b := namedClient("b", record("b"))
+	c := namedClient("c", record("c"))
+
+	client := NewFallbackClientWithProviders([]provider.LLMClient{a, b, c})
+	client.options = Options{AdaptiveOrder: true}
+	client.breakerFor("a").Record(900*time.Millisecond, nil)
+	client.breakerFor("b").Record(100*time.Millisecond, nil)
+	client.breakerFor("c").Record(500*time.Millisecond, nil)
+
+	t.Run("orders by latency", func(t *testing.T) {
+		order = nil
+		client.Query(context.Background(), "prompt", "")
+		expected := []string{"b", "c", "a"}
+		if len(order) != len(expected) {
+			t.Fatalf("expected %v, got %v", expected, order)
+		}
+		for i := range expected {
+			if order[i] != expected[i] {
+				t.Fatalf("expected %v, got %v", expected, order)
+			}
+		}
+	})
+
+	t.Run("preferred provider stays first", func(t *testing.T) {
+		order = nil
+		ctx := context.WithValue(context.Background(), provider.PreferredProviderKey, "a")
+		client.Query(ctx, "prompt", "")
+		if len(order) == 0 || order[0] != "a" {
+			t.Errorf("expected preferred provider first, got %v", order)
+		}
+	})
+}
+
+func TestFallbackClient_AdaptiveOrderPenalizesFailures(t *testing.T) {
+	var order []string
+	record := func(name string) func(ctx context.Context, prompt string, schema string) (string, string, error) {
+		return func(ctx context.Context, prompt string, schema string) (string, string, error) {
+			order = append(order, name)
+			return "", "", errors.New("fail")
+		}
+	}
+	names := []string{"failsFast", "flaky", "steady", "new"}
+	clients := make([]provider.LLMClient, len(names))
+	for i, name := range names {
+		clients[i] = namedClient(name, record(name))
+	}
+
+	client := NewFallbackClientWithProviders(clients)
+	client.options = Options{AdaptiveOrder: true}
+	// Fast failures count neither as latency nor in the provider's favour
+	client.breakerFor("failsFast").Record(5*time.Millisecond, errors.New("fail"))
+	// 100ms when it works, but half its calls fail
+	client.breakerFor("flaky").Record(100*time.Millisecond, nil)
+	client.breakerFor("flaky").Record(5*time.Millisecond, errors.New("fail"))
+	client.breakerFor("steady").Record(150*time.Millisecond, nil)
+
+	client.Query(context.Background(), "prompt", "")
+	expected := []string{"new", "steady", "flaky", "failsFast"}
+	if len(order) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, order)
+	}
+	for i := range expected {
+		if order[i] != expected[i] {
+			t.Fatalf("expected %v, got %v", expected, order)
+		}
+	}
+}
+
+func TestLoadOptions(t *testing.T) {
+	t.Setenv("LLM_TIMEOUT", "45s")
+	t.Setenv("LLM_TIMEOUTS", `{"openai":"30s","gemini":"bogus"}`)
+	t.Setenv("LLM_COOLDOWN", "2m")
+	t.Setenv("LLM_ADAPTIVE_ORDER", "true")
+
+	opts := loadOptions()
+	if opts.DefaultTimeout != 45*time.Second {
+		t.Errorf("expected default timeout 45s, got %v", opts.DefaultTimeout)
+	}
+	if opts.Timeouts["openai"] != 30*time.Second {
+		t.Errorf("expected openai timeout 30s, got %v", opts.Timeouts["openai"])
+	}
+	if _, ok := opts.Timeouts["gemini"]; ok {
+		t.Error("expected invalid gemini timeout to be ignored")
+	}
+	if opts.Cooldown != 2*time.Minute {
+		t.Errorf("expected cooldown 2m, got %v", opts.Cooldown)
+	}
+	if !opts.AdaptiveOrder {
+		t.Error("expected adaptive order to be enabled")
+	}
+
+	t.Setenv("LLM_TIMEOUT", "nonsense")
+	if got := loadOptions().DefaultTimeout; got != defaultQueryTimeout {
+		t.Errorf("expected invalid LLM_TIMEOUT to fall back to %v, got %v", defaultQueryTimeout, got)
+	}
+}
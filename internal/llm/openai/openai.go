@@ -4,7 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"log"
+	"log/slog"
 
 	"bible-api-service/internal/llm/provider"
 	"bible-api-service/internal/secrets"
@@ -101,7 +101,7 @@ func (c *OpenAIClient) Stream(ctx context.Context, prompt string) (<-chan string
 				return nil
 			}),
 		); err != nil {
-			log.Printf("openai: stream generation failed: %v", err)
+			slog.ErrorContext(ctx, "Stream generation failed", "ai_provider", "openai", "error", err)
 		}
 	}()
 
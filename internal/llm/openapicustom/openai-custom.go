@@ -4,7 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"log"
+	"log/slog"
 
 	"bible-api-service/internal/llm/provider"
 	"bible-api-service/internal/secrets"
@@ -103,7 +103,7 @@ func (c *OpenAICustomClient) Stream(ctx context.Context, prompt string) (<-chan
 				return nil
 			}),
 		); err != nil {
-			log.Printf("openai-custom: stream generation failed: %v", err)
+			slog.ErrorContext(ctx, "Stream generation failed", "ai_provider", "openai-custom", "error", err)
 		}
 	}()
 
@@ -4,7 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"log"
+	"log/slog"
 
 	"bible-api-service/internal/llm/provider"
 	"bible-api-service/internal/secrets"
@@ -103,7 +103,7 @@ func (c *OpenRouterClient) Stream(ctx context.Context, prompt string) (<-chan st
 				return nil
 			}),
 		); err != nil {
-			log.Printf("openrouter: stream generation failed: %v", err)
+			slog.ErrorContext(ctx, "Stream generation failed", "ai_provider", "openrouter", "error", err)
 		}
 	}()
 
@@ -0,0 +1,149 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Attribute keys attached to every log line emitted within a request.
+const (
+	KeyRequestID  = "request_id"
+	KeyClientID   = "client_id"
+	KeyQueryType  = "query_type"
+	KeyProvider   = "provider"
+	KeyAIProvider = "ai_provider"
+)
+
+// requestKeys is the order in which request-scoped attributes are emitted.
+var requestKeys = []string{KeyRequestID, KeyClientID, KeyQueryType, KeyProvider, KeyAIProvider}
+
+type contextKey string
+
+const fieldsKey contextKey = "log_fields"
+
+// fields holds request-scoped attributes. It is stored in the context as a
+// pointer so that values set further down the middleware chain (e.g. the
+// client ID after authentication) are visible to the access log.
+type fields struct {
+	mu     sync.RWMutex
+	values map[string]string
+}
+
+// NewContext returns a context that carries a fresh set of request-scoped log attributes.
+func NewContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, fieldsKey, &fields{values: make(map[string]string)})
+}
+
+// Set records a request-scoped attribute. It is a no-op if the context was not
+// created with NewContext.
+func Set(ctx context.Context, key, value string) {
+	f, ok := ctx.Value(fieldsKey).(*fields)
+	if !ok {
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.values[key] = value
+}
+
+// Get returns a request-scoped attribute, or an empty string if it is not set.
+func Get(ctx context.Context, key string) string {
+	f, ok := ctx.Value(fieldsKey).(*fields)
+	if !ok {
+		return ""
+	}
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.values[key]
+}
+
+// RequestID returns the request ID associated with the context.
+func RequestID(ctx context.Context) string {
+	return Get(ctx, KeyRequestID)
+}
+
+func attrsFromContext(ctx context.Context) []slog.Attr {
+	if ctx == nil {
+		return nil
+	}
+	f, ok := ctx.Value(fieldsKey).(*fields)
+	if !ok {
+		return nil
+	}
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	var attrs []slog.Attr
+	for _, key := range requestKeys {
+		if v := f.values[key]; v != "" {
+			attrs = append(attrs, slog.String(key, v))
+		}
+	}
+	return attrs
+}
+
+// contextHandler decorates log records with the request-scoped attributes
+// found in the record's context.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	r.AddAttrs(attrsFromContext(ctx)...)
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
+
+// NewHandler creates a JSON slog handler writing to w at the given level,
+// enriched with request-scoped attributes.
+func NewHandler(w io.Writer, level slog.Leveler) slog.Handler {
+	return contextHandler{slog.NewJSONHandler(w, &slog.HandlerOptions{Level: level})}
+}
+
+// ParseLevel converts a level name (debug, info, warn, error) into a slog.Level.
+func ParseLevel(s string) (slog.Level, error) {
+	var level slog.Level
+	if s == "" {
+		return slog.LevelInfo, nil
+	}
+	if err := level.UnmarshalText([]byte(strings.ToUpper(s))); err != nil {
+		return slog.LevelInfo, fmt.Errorf("invalid log level %q: %w", s, err)
+	}
+	return level, nil
+}
+
+// Init installs the structured JSON logger as the process default.
+// The level is read from LOG_LEVEL and defaults to info.
+func Init() {
+	level, err := ParseLevel(os.Getenv("LOG_LEVEL"))
+	slog.SetDefault(slog.New(NewHandler(os.Stderr, level)))
+	if err != nil {
+		slog.Warn("Falling back to info log level", "error", err)
+	}
+}
+
+// promptsEnabled reports whether prompt content may be written to logs.
+// Prompts are redacted unless LOG_PROMPTS is set to "true".
+func promptsEnabled() bool {
+	return strings.EqualFold(os.Getenv("LOG_PROMPTS"), "true")
+}
+
+// Prompt returns a log attribute for prompt content, redacted by default.
+func Prompt(prompt string) slog.Attr {
+	if promptsEnabled() {
+		return slog.String("prompt", prompt)
+	}
+	return slog.String("prompt", fmt.Sprintf("[redacted %d chars]", len(prompt)))
+}
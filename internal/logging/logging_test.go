@@ -0,0 +1,100 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestHandlerAddsRequestAttributes(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewHandler(&buf, slog.LevelInfo))
+
+	ctx := NewContext(context.Background())
+	Set(ctx, KeyRequestID, "req-123")
+	Set(ctx, KeyClientID, "clientA")
+	Set(ctx, KeyQueryType, "verses")
+	Set(ctx, KeyProvider, "biblegateway")
+
+	logger.InfoContext(ctx, "hello")
+
+	var line map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatalf("failed to parse log line %q: %v", buf.String(), err)
+	}
+
+	expected := map[string]string{
+		KeyRequestID: "req-123",
+		KeyClientID:  "clientA",
+		KeyQueryType: "verses",
+		KeyProvider:  "biblegateway",
+	}
+	for k, v := range expected {
+		if line[k] != v {
+			t.Errorf("expected %s=%q, got %v", k, v, line[k])
+		}
+	}
+	if _, ok := line[KeyAIProvider]; ok {
+		t.Errorf("expected unset %s to be omitted", KeyAIProvider)
+	}
+}
+
+func TestHandlerWithoutRequestContext(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewHandler(&buf, slog.LevelInfo))
+
+	logger.Info("hello")
+	if strings.Contains(buf.String(), KeyRequestID) {
+		t.Errorf("did not expect request attributes, got %s", buf.String())
+	}
+
+	// Set on a plain context must not panic
+	Set(context.Background(), KeyRequestID, "ignored")
+	if got := RequestID(context.Background()); got != "" {
+		t.Errorf("expected empty request id, got %q", got)
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    slog.Level
+		wantErr bool
+	}{
+		{"", slog.LevelInfo, false},
+		{"debug", slog.LevelDebug, false},
+		{"WARN", slog.LevelWarn, false},
+		{"error", slog.LevelError, false},
+		{"verbose", slog.LevelInfo, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseLevel(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseLevel(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseLevel(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPromptRedaction(t *testing.T) {
+	os.Unsetenv("LOG_PROMPTS")
+	attr := Prompt("What does John 3:16 mean?")
+	if strings.Contains(attr.Value.String(), "John") {
+		t.Errorf("expected prompt to be redacted, got %q", attr.Value.String())
+	}
+
+	os.Setenv("LOG_PROMPTS", "true")
+	defer os.Unsetenv("LOG_PROMPTS")
+	attr = Prompt("What does John 3:16 mean?")
+	if attr.Value.String() != "What does John 3:16 mean?" {
+		t.Errorf("expected prompt to be logged verbatim, got %q", attr.Value.String())
+	}
+}
@@ -0,0 +1,212 @@
+package metrics
+
+import (
+	"net/url"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Component names used to group observations.
+const (
+	ComponentBible = "bible"
+	ComponentLLM   = "llm"
+)
+
+// DefaultWindow is the rolling window used by the Default registry.
+const DefaultWindow = 5 * time.Minute
+
+// maxSamples bounds the memory used per tracked dependency.
+const maxSamples = 1000
+
+// Default is the process-wide registry.
+var Default = NewRegistry(DefaultWindow)
+
+type sample struct {
+	at       time.Time
+	duration time.Duration
+	failed   bool
+}
+
+// Stats summarises the observations for a dependency within the rolling window.
+type Stats struct {
+	Requests     int     `json:"requests"`
+	Errors       int     `json:"errors"`
+	SuccessRate  float64 `json:"success_rate"`
+	AvgLatencyMs int64   `json:"avg_latency_ms"`
+	P95LatencyMs int64   `json:"p95_latency_ms"`
+	LastError    string  `json:"last_error,omitempty"`
+	LastErrorAt  string  `json:"last_error_at,omitempty"`
+	// State is the circuit breaker state of the dependency, if it has one.
+	State       string `json:"state,omitempty"`
+	Transitions int    `json:"state_transitions,omitempty"`
+	// MarkupChanges counts calls that failed because the dependency's page
+	// markup no longer matched; LastMarkupChange names the selector that broke.
+	MarkupChanges    int    `json:"markup_changes,omitempty"`
+	LastMarkupChange string `json:"last_markup_change,omitempty"`
+}
+
+type series struct {
+	samples     []sample
+	lastError   string
+	lastErrorAt time.Time
+	state       string
+	transitions int
+
+	markupChanges    int
+	lastMarkupChange string
+}
+
+// Registry records call outcomes for upstream dependencies over a rolling window.
+type Registry struct {
+	mu     sync.Mutex
+	window time.Duration
+	now    func() time.Time
+	series map[string]map[string]*series
+}
+
+// NewRegistry creates a new Registry with the given rolling window.
+func NewRegistry(window time.Duration) *Registry {
+	return &Registry{
+		window: window,
+		now:    time.Now,
+		series: make(map[string]map[string]*series),
+	}
+}
+
+// Window returns the length of the rolling window.
+func (r *Registry) Window() time.Duration {
+	return r.window
+}
+
+// Observe records the outcome of a single call to the named dependency.
+func (r *Registry) Observe(component, name string, duration time.Duration, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s := r.get(component, name)
+
+	now := r.now()
+	s.samples = append(s.samples, sample{at: now, duration: duration, failed: err != nil})
+	if err != nil {
+		s.lastError = redact(err.Error())
+		s.lastErrorAt = now
+	}
+	r.trim(s, now)
+}
+
+// urlPattern matches the URLs quoted in error messages, such as those of net/http.
+var urlPattern = regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9+.-]*://[^\s"'<>]+`)
+
+// redact reduces the URLs in an error message to their scheme and host. Their
+// paths and query strings can carry user input or API keys, and the message is
+// published on /status.
+func redact(msg string) string {
+	return urlPattern.ReplaceAllStringFunc(msg, func(raw string) string {
+		u, err := url.Parse(raw)
+		if err != nil || u.Host == "" {
+			return "[redacted]"
+		}
+		return u.Scheme + "://" + u.Host
+	})
+}
+
+// SetState records the circuit breaker state of the named dependency.
+func (r *Registry) SetState(component, name, state string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s := r.get(component, name)
+	if s.state != "" && s.state != state {
+		s.transitions++
+	}
+	s.state = state
+}
+
+// RecordMarkupChange counts a call to the named dependency that failed because
+// the given selector no longer matched its markup.
+func (r *Registry) RecordMarkupChange(component, name, selector string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s := r.get(component, name)
+	s.markupChanges++
+	s.lastMarkupChange = selector
+}
+
+func (r *Registry) get(component, name string) *series {
+	byName, ok := r.series[component]
+	if !ok {
+		byName = make(map[string]*series)
+		r.series[component] = byName
+	}
+	s, ok := byName[name]
+	if !ok {
+		s = &series{}
+		byName[name] = s
+	}
+	return s
+}
+
+// trim drops samples that fell out of the window or exceed maxSamples.
+func (r *Registry) trim(s *series, now time.Time) {
+	cutoff := now.Add(-r.window)
+	i := 0
+	for i < len(s.samples) && s.samples[i].at.Before(cutoff) {
+		i++
+	}
+	if excess := len(s.samples) - i - maxSamples; excess > 0 {
+		i += excess
+	}
+	if i > 0 {
+		s.samples = append([]sample(nil), s.samples[i:]...)
+	}
+}
+
+// Snapshot returns the stats for every dependency observed under the component.
+func (r *Registry) Snapshot(component string) map[string]Stats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	result := make(map[string]Stats)
+	for name, s := range r.series[component] {
+		r.trim(s, now)
+		result[name] = summarise(s)
+	}
+	return result
+}
+
+func summarise(s *series) Stats {
+	stats := Stats{
+		Requests:         len(s.samples),
+		State:            s.state,
+		Transitions:      s.transitions,
+		MarkupChanges:    s.markupChanges,
+		LastMarkupChange: s.lastMarkupChange,
+	}
+	if stats.Requests == 0 {
+		return stats
+	}
+
+	durations := make([]time.Duration, 0, len(s.samples))
+	var total time.Duration
+	for _, smp := range s.samples {
+		if smp.failed {
+			stats.Errors++
+		}
+		total += smp.duration
+		durations = append(durations, smp.duration)
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+
+	stats.SuccessRate = float64(stats.Requests-stats.Errors) / float64(stats.Requests)
+	stats.AvgLatencyMs = (total / time.Duration(stats.Requests)).Milliseconds()
+	stats.P95LatencyMs = durations[(len(durations)*95-1)/100].Milliseconds()
+	if s.lastError != "" {
+		stats.LastError = s.lastError
+		stats.LastErrorAt = s.lastErrorAt.UTC().Format(time.RFC3339)
+	}
+	return stats
+}
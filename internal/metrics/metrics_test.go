@@ -0,0 +1,98 @@
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRegistry_Snapshot(t *testing.T) {
+	r := NewRegistry(time.Minute)
+
+	r.Observe(ComponentBible, "biblegateway", 100*time.Millisecond, nil)
+	r.Observe(ComponentBible, "biblegateway", 300*time.Millisecond, nil)
+	r.Observe(ComponentBible, "biblegateway", 200*time.Millisecond, errors.New("boom"))
+	r.Observe(ComponentLLM, "openai", time.Second, nil)
+
+	stats := r.Snapshot(ComponentBible)
+	gw, ok := stats["biblegateway"]
+	if !ok {
+		t.Fatalf("expected biblegateway stats, got %v", stats)
+	}
+	if gw.Requests != 3 || gw.Errors != 1 {
+		t.Errorf("expected 3 requests and 1 error, got %+v", gw)
+	}
+	if gw.SuccessRate < 0.66 || gw.SuccessRate > 0.67 {
+		t.Errorf("expected success rate ~0.67, got %v", gw.SuccessRate)
+	}
+	if gw.AvgLatencyMs != 200 {
+		t.Errorf("expected avg latency 200ms, got %d", gw.AvgLatencyMs)
+	}
+	if gw.P95LatencyMs != 300 {
+		t.Errorf("expected p95 latency 300ms, got %d", gw.P95LatencyMs)
+	}
+	if gw.LastError != "boom" {
+		t.Errorf("expected last error to be recorded, got %q", gw.LastError)
+	}
+
+	if _, ok := stats["openai"]; ok {
+		t.Error("expected components to be tracked separately")
+	}
+}
+
+func TestRegistry_RedactsLastError(t *testing.T) {
+	r := NewRegistry(time.Minute)
+	r.Observe(ComponentLLM, "gemini", time.Second, errors.New(`Post "https://generativelanguage.googleapis.com/v1beta/models/gemini:generateContent?key=secret": context deadline exceeded`))
+	r.Observe(ComponentBible, "biblegateway", time.Second, errors.New("failed to fetch verse: biblegateway: status code: 503 from https://classic.biblegateway.com/passage/?search=John+3%3A16&version=ESV"))
+
+	if got, want := r.Snapshot(ComponentLLM)["gemini"].LastError, `Post "https://generativelanguage.googleapis.com": context deadline exceeded`; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+	if got, want := r.Snapshot(ComponentBible)["biblegateway"].LastError, "failed to fetch verse: biblegateway: status code: 503 from https://classic.biblegateway.com"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestRegistry_RollingWindow(t *testing.T) {
+	r := NewRegistry(time.Minute)
+	now := time.Now()
+	r.now = func() time.Time { return now }
+
+	r.Observe(ComponentLLM, "gemini", time.Second, errors.New("old failure"))
+
+	now = now.Add(2 * time.Minute)
+	r.Observe(ComponentLLM, "gemini", time.Second, nil)
+
+	stats := r.Snapshot(ComponentLLM)["gemini"]
+	if stats.Requests != 1 || stats.Errors != 0 {
+		t.Errorf("expected expired samples to be dropped, got %+v", stats)
+	}
+	if stats.SuccessRate != 1 {
+		t.Errorf("expected success rate 1, got %v", stats.SuccessRate)
+	}
+}
+
+func TestRegistry_MaxSamples(t *testing.T) {
+	r := NewRegistry(time.Hour)
+	for i := 0; i < maxSamples+50; i++ {
+		r.Observe(ComponentBible, "biblehub", time.Millisecond, nil)
+	}
+	if got := r.Snapshot(ComponentBible)["biblehub"].Requests; got != maxSamples {
+		t.Errorf("expected %d samples, got %d", maxSamples, got)
+	}
+}
+
+func TestRegistry_RecordMarkupChange(t *testing.T) {
+	r := NewRegistry(time.Minute)
+
+	r.RecordMarkupChange(ComponentBible, "biblehub", "verse number")
+	r.RecordMarkupChange(ComponentBible, "biblehub", "search result title")
+
+	stats := r.Snapshot(ComponentBible)["biblehub"]
+	if stats.MarkupChanges != 2 {
+		t.Errorf("expected 2 markup changes, got %d", stats.MarkupChanges)
+	}
+	if stats.LastMarkupChange != "search result title" {
+		t.Errorf("expected the last broken selector, got %q", stats.LastMarkupChange)
+	}
+}
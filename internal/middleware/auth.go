@@ -1,10 +1,11 @@
 package middleware
 
 import (
+	"bible-api-service/internal/logging"
 	"bible-api-service/internal/util"
 	"context"
 	"encoding/json"
-	"log"
+	"log/slog"
 	"net/http"
 )
 
@@ -41,21 +42,22 @@ func (m *AuthMiddleware) APIKeyAuth(next http.Handler) http.Handler {
 		// Secret is expected to be a JSON object: {"client_id": "api_key", ...}
 		secretVal, err := m.secretsClient.GetSecret(ctx, "API_KEYS")
 		if err != nil {
-			log.Printf("could not get API_KEYS from secret manager: %v", err)
+			slog.ErrorContext(ctx, "could not get API_KEYS from secret manager", "error", err)
 			util.JSONError(w, http.StatusInternalServerError, "Internal Authentication Configuration Error")
 			return
 		}
 
 		var apiKeys map[string]string
 		if err := json.Unmarshal([]byte(secretVal), &apiKeys); err != nil {
-			log.Printf("failed to parse API_KEYS secret as JSON: %v", err)
+			slog.ErrorContext(ctx, "failed to parse API_KEYS secret as JSON", "error", err)
 			util.JSONError(w, http.StatusInternalServerError, "Internal Authentication Configuration Error")
 			return
 		}
 
 		for clientID, key := range apiKeys {
 			if clientKey == key {
-				log.Printf("Authenticated request from client: %s", clientID)
+				logging.Set(ctx, logging.KeyClientID, clientID)
+				slog.DebugContext(ctx, "Authenticated request from client")
 				ctx = context.WithValue(ctx, ClientIDKey, clientID)
 				next.ServeHTTP(w, r.WithContext(ctx))
 				return
@@ -0,0 +1,12 @@
+package middleware
+
+import "net/http"
+
+// MaxBytes limits the size of request bodies. Reads past the limit fail with
+// an *http.MaxBytesError, which handlers should report as 413.
+func MaxBytes(limit int64, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, limit)
+		next.ServeHTTP(w, r)
+	})
+}
@@ -1,10 +1,13 @@
 package middleware
 
 import (
+	"bible-api-service/internal/logging"
 	"context"
 	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -155,3 +158,131 @@ func TestLogging(t *testing.T) {
 		t.Errorf("expected status code %d, got %d", http.StatusOK, rr.Code)
 	}
 }
+
+func TestRequestID(t *testing.T) {
+	var seen string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = logging.RequestID(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+
+	t.Run("generates id", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/", nil)
+		rr := httptest.NewRecorder()
+		RequestID(handler).ServeHTTP(rr, req)
+
+		id := rr.Header().Get(RequestIDHeader)
+		if id == "" {
+			t.Fatal("expected generated request id header")
+		}
+		if seen != id {
+			t.Errorf("expected context request id %q, got %q", id, seen)
+		}
+	})
+
+	t.Run("propagates incoming id", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set(RequestIDHeader, "upstream-id-1")
+		rr := httptest.NewRecorder()
+		RequestID(handler).ServeHTTP(rr, req)
+
+		if got := rr.Header().Get(RequestIDHeader); got != "upstream-id-1" {
+			t.Errorf("expected propagated id, got %q", got)
+		}
+		if seen != "upstream-id-1" {
+			t.Errorf("expected context request id to be propagated, got %q", seen)
+		}
+	})
+
+	t.Run("replaces invalid id", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set(RequestIDHeader, "bad id with spaces")
+		rr := httptest.NewRecorder()
+		RequestID(handler).ServeHTTP(rr, req)
+
+		if got := rr.Header().Get(RequestIDHeader); got == "bad id with spaces" || got == "" {
+			t.Errorf("expected invalid id to be replaced, got %q", got)
+		}
+	})
+
+	t.Run("error body carries id", func(t *testing.T) {
+		secretsClient := &mockSecretsClient{
+			getSecretFunc: func(ctx context.Context, name string) (string, error) {
+				return `{"client": "key"}`, nil
+			},
+		}
+		authMiddleware := NewAuthMiddleware(secretsClient)
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set(RequestIDHeader, "err-id")
+		rr := httptest.NewRecorder()
+		RequestID(authMiddleware.APIKeyAuth(handler)).ServeHTTP(rr, req)
+
+		if !strings.Contains(rr.Body.String(), `"request_id":"err-id"`) {
+			t.Errorf("expected request id in error body, got %s", rr.Body.String())
+		}
+	})
+
+	t.Run("client id recorded for logging", func(t *testing.T) {
+		secretsClient := &mockSecretsClient{
+			getSecretFunc: func(ctx context.Context, name string) (string, error) {
+				return `{"clientA": "keyA"}`, nil
+			},
+		}
+		authMiddleware := NewAuthMiddleware(secretsClient)
+		var clientID string
+		inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			clientID = logging.Get(r.Context(), logging.KeyClientID)
+		})
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("X-API-KEY", "keyA")
+		rr := httptest.NewRecorder()
+		RequestID(authMiddleware.APIKeyAuth(inner)).ServeHTTP(rr, req)
+
+		if clientID != "clientA" {
+			t.Errorf("expected client id clientA, got %q", clientID)
+		}
+	})
+}
+
+func TestLoggingPreservesFlusher(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := w.(http.Flusher); !ok {
+			t.Error("expected response writer to implement http.Flusher")
+		}
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	req := httptest.NewRequest("GET", "/", nil)
+	rr := httptest.NewRecorder()
+	Logging(handler).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusAccepted {
+		t.Errorf("expected status code %d, got %d", http.StatusAccepted, rr.Code)
+	}
+}
+
+func TestMaxBytes(t *testing.T) {
+	handler := MaxBytes(4, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := io.ReadAll(r.Body); err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if !errors.As(err, &maxBytesErr) {
+				t.Errorf("expected *http.MaxBytesError, got %v", err)
+			}
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("POST", "/", strings.NewReader("ok")))
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected %d for small body, got %d", http.StatusOK, rr.Code)
+	}
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("POST", "/", strings.NewReader("too large")))
+	if rr.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected %d for large body, got %d", http.StatusRequestEntityTooLarge, rr.Code)
+	}
+}
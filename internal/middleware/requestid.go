@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"bible-api-service/internal/logging"
+	"bible-api-service/internal/util"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+// RequestIDHeader is the header used to propagate request IDs.
+const RequestIDHeader = util.RequestIDHeader
+
+const maxRequestIDLength = 128
+
+// RequestID assigns every request an ID, propagating a valid incoming
+// X-Request-ID header or generating a new one. The ID is returned in the
+// response headers and attached to all log lines for the request.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+
+		ctx := logging.NewContext(r.Context())
+		logging.Set(ctx, logging.KeyRequestID, id)
+		w.Header().Set(RequestIDHeader, id)
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range id {
+		if c < 0x21 || c > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(b)
+}
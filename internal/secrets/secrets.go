@@ -3,7 +3,7 @@ package secrets
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
 
 	secretmanager "cloud.google.com/go/secretmanager/apiv1"
@@ -32,13 +32,13 @@ func New(client secretManagerClient, projectID string) Client {
 // from environment variables.
 func NewClient(ctx context.Context, projectID string) (Client, error) {
 	if projectID == "" {
-		log.Println("GCP_PROJECT_ID is not set; falling back to environment variables")
+		slog.Info("GCP_PROJECT_ID is not set; falling back to environment variables")
 		return &EnvClient{}, nil
 	}
 
 	c, err := secretmanager.NewClient(ctx)
 	if err != nil {
-		log.Printf("failed to create secret manager client, falling back to environment variables: %v", err)
+		slog.Warn("failed to create secret manager client, falling back to environment variables", "error", err)
 		return &EnvClient{}, nil
 	}
 
@@ -5,18 +5,42 @@ import (
 	"net/http"
 )
 
+// RequestIDHeader is the response header carrying the request ID.
+const RequestIDHeader = "X-Request-ID"
+
+// Machine-readable error codes, returned as error.error_code so that clients
+// can tell failures apart without parsing messages.
+const (
+	ErrorCodeInvalidReference    = "invalid_reference"
+	ErrorCodeNotFound            = "not_found"
+	ErrorCodeUnsupportedVersion  = "unsupported_version"
+	ErrorCodeRateLimited         = "rate_limited"
+	ErrorCodeUpstreamUnavailable = "upstream_unavailable"
+	ErrorCodeMarkupChanged       = "markup_changed"
+	ErrorCodeInternal            = "internal_error"
+)
+
 type ErrorResponse struct {
 	Error struct {
-		Code    int    `json:"code"`
-		Message string `json:"message"`
+		Code      int    `json:"code"`
+		ErrorCode string `json:"error_code,omitempty"`
+		Message   string `json:"message"`
+		RequestID string `json:"request_id,omitempty"`
 	} `json:"error"`
 }
 
 func JSONError(w http.ResponseWriter, code int, message string) {
+	JSONErrorWithCode(w, code, "", message)
+}
+
+// JSONErrorWithCode writes an error response carrying a machine-readable error code.
+func JSONErrorWithCode(w http.ResponseWriter, code int, errorCode, message string) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(code)
 	response := ErrorResponse{}
 	response.Error.Code = code
+	response.Error.ErrorCode = errorCode
 	response.Error.Message = message
+	response.Error.RequestID = w.Header().Get(RequestIDHeader)
 	json.NewEncoder(w).Encode(response)
 }
@@ -70,3 +70,17 @@ func TestJSONError(t *testing.T) {
 		})
 	}
 }
+
+func TestJSONErrorWithCode(t *testing.T) {
+	rr := httptest.NewRecorder()
+	rr.Header().Set(RequestIDHeader, "req-1")
+	JSONErrorWithCode(rr, http.StatusNotFound, ErrorCodeNotFound, "Verse not found")
+
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", rr.Code)
+	}
+	expected := `{"error":{"code":404,"error_code":"not_found","message":"Verse not found","request_id":"req-1"}}`
+	if body := strings.TrimSpace(rr.Body.String()); body != expected {
+		t.Errorf("unexpected body: got %v want %v", body, expected)
+	}
+}
@@ -59,9 +59,14 @@ func (c *Client) Query(ctx context.Context, req QueryRequest, result interface{}
 	if resp.StatusCode != http.StatusOK {
 		var errResp ErrorResponse
 		if err := json.Unmarshal(respBody, &errResp); err == nil && errResp.Error.Message != "" {
-			return fmt.Errorf("api error: %s (code: %d)", errResp.Error.Message, errResp.Error.Code)
+			return &APIError{
+				StatusCode: resp.StatusCode,
+				ErrorCode:  errResp.Error.ErrorCode,
+				Message:    errResp.Error.Message,
+				RequestID:  errResp.Error.RequestID,
+			}
 		}
-		return fmt.Errorf("api returned status %d: %s", resp.StatusCode, string(respBody))
+		return &APIError{StatusCode: resp.StatusCode, Message: string(respBody)}
 	}
 
 	if result != nil {
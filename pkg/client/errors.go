@@ -0,0 +1,57 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Errors matched by an *APIError with errors.Is, by the error_code the API
+// returned or, for older servers without one, by the HTTP status.
+var (
+	ErrInvalidReference    = errors.New("invalid reference")
+	ErrNotFound            = errors.New("not found")
+	ErrUnsupportedVersion  = errors.New("unsupported version")
+	ErrRateLimited         = errors.New("rate limited")
+	ErrUpstreamUnavailable = errors.New("upstream unavailable")
+	ErrMarkupChanged       = errors.New("provider markup changed")
+)
+
+var errorsByCode = map[string]error{
+	"invalid_reference":    ErrInvalidReference,
+	"not_found":            ErrNotFound,
+	"unsupported_version":  ErrUnsupportedVersion,
+	"rate_limited":         ErrRateLimited,
+	"upstream_unavailable": ErrUpstreamUnavailable,
+	"markup_changed":       ErrMarkupChanged,
+}
+
+var errorsByStatus = map[int]error{
+	http.StatusNotFound:           ErrNotFound,
+	http.StatusTooManyRequests:    ErrRateLimited,
+	http.StatusServiceUnavailable: ErrUpstreamUnavailable,
+}
+
+// APIError is returned when the API responds with an error status.
+type APIError struct {
+	StatusCode int
+	// ErrorCode is the machine-readable error code, e.g. "not_found".
+	ErrorCode string
+	Message   string
+	RequestID string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("api error: %s (code: %d)", e.Message, e.StatusCode)
+}
+
+// Unwrap returns the sentinel error for the error code, or nil if it is unknown.
+func (e *APIError) Unwrap() error {
+	if err, ok := errorsByCode[e.ErrorCode]; ok {
+		return err
+	}
+	if e.ErrorCode == "" {
+		return errorsByStatus[e.StatusCode]
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestQuery_APIErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   error
+	}{
+		{"error code", http.StatusNotFound, `{"error":{"code":404,"error_code":"not_found","message":"Verse not found","request_id":"req-1"}}`, ErrNotFound},
+		{"invalid reference", http.StatusBadRequest, `{"error":{"code":400,"error_code":"invalid_reference","message":"Invalid verse reference"}}`, ErrInvalidReference},
+		{"markup changed", http.StatusBadGateway, `{"error":{"code":502,"error_code":"markup_changed","message":"Bible provider returned a page that could not be read"}}`, ErrMarkupChanged},
+		{"status only", http.StatusTooManyRequests, `{"error":{"code":429,"message":"slow down"}}`, ErrRateLimited},
+		{"plain text body", http.StatusServiceUnavailable, `upstream connect error`, ErrUpstreamUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer ts.Close()
+
+			_, err := NewClient(ts.URL, "test-key").GetVerses(context.Background(), []string{"John 3:16"}, "ESV")
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, err)
+			}
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.status {
+				t.Errorf("expected an APIError with status %d, got %v", tt.status, err)
+			}
+		})
+	}
+}
+
+func TestAPIError_UnknownCode(t *testing.T) {
+	err := &APIError{StatusCode: http.StatusNotFound, ErrorCode: "something_new", Message: "?"}
+	if errors.Is(err, ErrNotFound) {
+		t.Error("expected an unknown error code not to fall back to the status")
+	}
+	if err.Error() != "api error: ? (code: 404)" {
+		t.Errorf("unexpected message %q", err.Error())
+	}
+}
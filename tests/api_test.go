@@ -3,8 +3,11 @@ package tests
 import (
 	"bible-api-service/internal/bible"
 	"bible-api-service/internal/bible/providers/biblegateway"
+	"bible-api-service/internal/circuit"
 	"bible-api-service/internal/handlers"
+	"bible-api-service/internal/metrics"
 	"bible-api-service/internal/secrets"
+	"bible-api-service/internal/util"
 	"bytes"
 	"encoding/json"
 	"net/http"
@@ -13,6 +16,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestAPI_WordSearch_CleanOutput(t *testing.T) {
@@ -126,3 +130,48 @@ func TestAPI_WordSearch_CleanOutput(t *testing.T) {
 		}
 	})
 }
+
+func TestAPI_VerseNotFound_KeepsCircuitClosed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Bible Gateway's page for a reference it has no text for
+		w.Write([]byte(`<html><body><div class="content-section"><h3>No results found.</h3></div></body></html>`))
+	}))
+	defer server.Close()
+
+	scraper := biblegateway.NewScraper()
+	scraper.SetBaseURL(server.URL)
+	registry := metrics.NewRegistry(time.Minute)
+	provider := bible.NewObservedProvider("biblegateway", scraper, registry).WithBreaker(circuit.Config{ConsecutiveFailures: 1, OpenTimeout: time.Hour})
+
+	tmp := t.TempDir()
+	path := filepath.Join(tmp, "versions.yaml")
+	os.WriteFile(path, []byte("[]"), 0644)
+	vm, _ := bible.NewVersionManager(path)
+
+	handler := handlers.NewQueryHandler(&secrets.EnvClient{}, vm)
+	handler.ProviderManager.RegisterProvider("biblegateway", provider)
+
+	for i := 0; i < 3; i++ {
+		req := httptest.NewRequest("POST", "/query", bytes.NewBufferString(`{"query": {"verses": ["Hezekiah 1:1"]}}`))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		if w.Code != http.StatusNotFound {
+			t.Fatalf("expected status 404, got %d: %s", w.Code, w.Body.String())
+		}
+		var resp util.ErrorResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if resp.Error.ErrorCode != util.ErrorCodeNotFound {
+			t.Errorf("expected error code %s, got %s", util.ErrorCodeNotFound, resp.Error.ErrorCode)
+		}
+	}
+
+	if state := provider.State(); state != circuit.Closed {
+		t.Errorf("expected a missing verse not to trip the breaker, got %s", state)
+	}
+	if stats := registry.Snapshot(metrics.ComponentBible)["biblegateway"]; stats.Errors != 0 || stats.MarkupChanges != 0 {
+		t.Errorf("expected a missing verse not to count as a failure or markup change, got %+v", stats)
+	}
+}